@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AgentRole describes an AI agent a team may run against the project and
+// the default role it plays in the generated AGENTS.md.
+type AgentRole struct {
+	ID    string
+	Label string
+	Role  string
+}
+
+// KnownAgents lists the agents Launchpad can write explicit sections for.
+var KnownAgents = []AgentRole{
+	{
+		ID:    "copilot",
+		Label: "GitHub Copilot (agent mode)",
+		Role:  "interactive implementer in the editor; owns feature work a developer is actively pairing on",
+	},
+	{
+		ID:    "claude-code",
+		Label: "Claude Code",
+		Role:  "terminal-driven implementer for multi-file changes, refactors, and test-driven tasks",
+	},
+	{
+		ID:    "aider",
+		Label: "aider",
+		Role:  "git-native pair programmer for focused, commit-sized edits",
+	},
+	{
+		ID:    "codex",
+		Label: "OpenAI Codex",
+		Role:  "asynchronous task runner for well-specified issues delivered as pull requests",
+	},
+	{
+		ID:    "ci-bot",
+		Label: "CI bot",
+		Role:  "non-interactive reviewer and fixer in CI; limited to lint, formatting, dependency, and test-repair changes",
+	},
+}
+
+// FindAgent returns the known agent with the given ID, or nil.
+func FindAgent(id string) *AgentRole {
+	for i := range KnownAgents {
+		if KnownAgents[i].ID == id {
+			return &KnownAgents[i]
+		}
+	}
+	return nil
+}
+
+// ParseAgents splits a comma-separated agent list, normalizes IDs, and
+// rejects unknown agents.
+func ParseAgents(list string) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(list, ",") {
+		id := strings.ToLower(strings.TrimSpace(part))
+		if id == "" || seen[id] {
+			continue
+		}
+		if FindAgent(id) == nil {
+			return nil, fmt.Errorf("unknown agent %q (known: %s)", id, strings.Join(agentIDs(), ", "))
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func agentIDs() []string {
+	ids := make([]string, len(KnownAgents))
+	for i, a := range KnownAgents {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+// agentGuidance renders the AGENTS.md instructions for an explicit agent
+// roster, or "" when the team didn't name any agents.
+func agentGuidance(agents []string) string {
+	if len(agents) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("AGENT ROSTER:\n")
+	sb.WriteString("The team uses these AI agents. AGENTS.md MUST contain one section per agent\n")
+	sb.WriteString("with its role, the paths and tasks it owns, what it must NOT touch, and handoff\n")
+	sb.WriteString("rules to the other agents and to humans (who reviews, how work is passed on):\n")
+	for _, id := range agents {
+		if a := FindAgent(id); a != nil {
+			fmt.Fprintf(&sb, "- %s (%s): %s\n", a.Label, a.ID, a.Role)
+		}
+	}
+	sb.WriteString("Keep the shared ground rules from the core agents asset above the per-agent sections.\n\n")
+	return sb.String()
+}
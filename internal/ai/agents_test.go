@@ -0,0 +1,32 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAgents(t *testing.T) {
+	got, err := ParseAgents(" Copilot, claude-code,copilot ,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, ",") != "copilot,claude-code" {
+		t.Errorf("ParseAgents = %v, want [copilot claude-code]", got)
+	}
+
+	if _, err := ParseAgents("copilot,skynet"); err == nil {
+		t.Error("expected error for unknown agent")
+	}
+}
+
+func TestAgentGuidance(t *testing.T) {
+	if got := agentGuidance(nil); got != "" {
+		t.Errorf("expected no guidance without agents, got %q", got)
+	}
+	got := agentGuidance([]string{"aider", "ci-bot"})
+	for _, want := range []string{"AGENT ROSTER", "aider (aider)", "CI bot (ci-bot)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("guidance missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Capabilities are the API features a provider's model supports beyond
+// plain text replies.
+type Capabilities struct {
+	StructuredOutput bool `json:"structured_output"` // replies constrained to a JSON schema
+	ToolCalls        bool `json:"tool_calls"`
+	Streaming        bool `json:"streaming"`
+}
+
+// String lists the supported features, or "text only".
+func (c Capabilities) String() string {
+	var have []string
+	if c.StructuredOutput {
+		have = append(have, "structured output")
+	}
+	if c.ToolCalls {
+		have = append(have, "tool calls")
+	}
+	if c.Streaming {
+		have = append(have, "streaming")
+	}
+	if len(have) == 0 {
+		return "text only"
+	}
+	return strings.Join(have, ", ")
+}
+
+// CapabilityProber is implemented by providers that can report what their
+// model supports. Providers that don't are treated as text only.
+type CapabilityProber interface {
+	Capabilities(ctx context.Context) Capabilities
+}
+
+// StructuredSender is implemented by providers that can constrain a reply
+// to a JSON schema. It is only used when the provider's Capabilities
+// report StructuredOutput.
+type StructuredSender interface {
+	SendStructured(ctx context.Context, message, systemPrompt, name string, schema map[string]any) (string, error)
+}
+
+// capCache remembers a provider's capabilities so they are probed once per
+// process, however many forks ask.
+type capCache struct {
+	once sync.Once
+	caps Capabilities
+}
+
+func (c *capCache) get(probe func() Capabilities) Capabilities {
+	c.once.Do(func() { c.caps = probe() })
+	return c.caps
+}
+
+// Capabilities reports what the engine's provider supports, probing it on
+// first use.
+func (e *Engine) Capabilities(ctx context.Context) Capabilities {
+	return e.caps.get(func() Capabilities {
+		p, ok := e.provider.(CapabilityProber)
+		if !ok {
+			return Capabilities{}
+		}
+		return p.Capabilities(ctx)
+	})
+}
+
+// sendSelection asks for the Selection JSON, constrained by schema when the
+// provider supports it. Otherwise the reply is plain text and parseSelection
+// digs the JSON out of it, which works for most models but guarantees less.
+func (e *Engine) sendSelection(ctx context.Context, message, systemPrompt string) (*Selection, error) {
+	var raw string
+	var err error
+	if s, ok := e.provider.(StructuredSender); ok && e.Capabilities(ctx).StructuredOutput {
+		raw, err = s.SendStructured(ctx, message, systemPrompt, "selection", selectionSchema())
+	} else {
+		raw, err = e.provider.Send(ctx, message, systemPrompt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseSelection(raw)
+}
+
+// selectionSchema is the strict JSON schema for Selection. Enums keep the
+// model to IDs this build knows; an empty profile_id is allowed so a vague
+// conversation isn't forced into a pick.
+func selectionSchema() map[string]any {
+	profiles := []any{""}
+	var addons, assets []any
+	for _, a := range allAssets() {
+		switch {
+		case strings.HasPrefix(a.ID, "profile."):
+			profiles = append(profiles, strings.TrimPrefix(a.ID, "profile."))
+		case strings.HasPrefix(a.ID, "addon."):
+			addons = append(addons, strings.TrimPrefix(a.ID, "addon."))
+		case strings.HasPrefix(a.ID, "asset."):
+			assets = append(assets, a.ID)
+		}
+	}
+	var agents []any
+	for _, id := range agentIDs() {
+		agents = append(agents, id)
+	}
+	stringArray := func(enum []any) map[string]any {
+		items := map[string]any{"type": "string"}
+		if len(enum) > 0 {
+			items["enum"] = enum
+		}
+		return map[string]any{"type": "array", "items": items}
+	}
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"profile_id":  map[string]any{"type": "string", "enum": profiles},
+			"addon_ids":   stringArray(addons),
+			"asset_ids":   stringArray(assets),
+			"agents":      stringArray(agents),
+			"confidence":  map[string]any{"type": "number"},
+			"rationale":   map[string]any{"type": "string"},
+			"tags":        stringArray(nil),
+			"constraints": stringArray(nil),
+			"features":    stringArray(nil),
+			"palette_roles": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"asset_id": map[string]any{"type": "string"},
+						"role":     map[string]any{"type": "string", "enum": []any{PalettePrimary, PaletteSecondary}},
+					},
+					"required":             []any{"asset_id", "role"},
+					"additionalProperties": false,
+				},
+			},
+		},
+		"required":             []any{"profile_id", "addon_ids", "asset_ids", "agents", "confidence", "rationale", "tags", "constraints", "features", "palette_roles"},
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,133 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// structuredProvider reports caps and records whether replies were
+// requested with a schema.
+type structuredProvider struct {
+	scriptedProvider
+	caps    Capabilities
+	schemas []string
+}
+
+func (p *structuredProvider) Capabilities(context.Context) Capabilities { return p.caps }
+
+func (p *structuredProvider) SendStructured(ctx context.Context, message, systemPrompt, name string, _ map[string]any) (string, error) {
+	p.schemas = append(p.schemas, name)
+	return p.Send(ctx, message, systemPrompt)
+}
+
+func TestExtractDecision_UsesStructuredOutputWhenSupported(t *testing.T) {
+	const reply = `{"profile_id":"go-service","addon_ids":[],"asset_ids":[],"agents":[],"confidence":0.9,"rationale":"cli"}`
+	tests := []struct {
+		name        string
+		caps        Capabilities
+		reply       string
+		wantSchemas int
+	}{
+		{"structured", Capabilities{StructuredOutput: true}, reply, 1},
+		{"text fallback", Capabilities{ToolCalls: true}, "Sure! ```json\n" + reply + "\n```", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &structuredProvider{scriptedProvider: scriptedProvider{replies: []string{tt.reply}}, caps: tt.caps}
+			sel, err := NewEngine(p).ExtractDecision(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sel.ProfileID != "go-service" {
+				t.Errorf("profile = %q", sel.ProfileID)
+			}
+			if len(p.schemas) != tt.wantSchemas {
+				t.Errorf("structured calls = %d, want %d", len(p.schemas), tt.wantSchemas)
+			}
+		})
+	}
+}
+
+func TestSelectionSchema(t *testing.T) {
+	schema := selectionSchema()
+	props := schema["properties"].(map[string]any)
+	profiles := props["profile_id"].(map[string]any)["enum"].([]any)
+	if profiles[0] != "" || !containsAny(profiles, "electron") || containsAny(profiles, "profile.electron") {
+		t.Errorf("profile enum = %v", profiles)
+	}
+	addons := props["addon_ids"].(map[string]any)["items"].(map[string]any)["enum"].([]any)
+	if !containsAny(addons, "data-intensive") {
+		t.Errorf("addon enum = %v", addons)
+	}
+	if len(schema["required"].([]any)) != len(props) {
+		t.Error("strict schemas must require every property")
+	}
+}
+
+func containsAny(list []any, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOpenAICapabilities_Probe(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body responsesRequest
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &body); err != nil || r.URL.Path != "/v1/responses" {
+			t.Errorf("bad probe %s: %s", r.URL.Path, data)
+		}
+		switch {
+		case body.Text != nil:
+			http.Error(w, `{"error":"response_format not supported"}`, http.StatusBadRequest)
+		case body.Stream:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"output_text":"ok"}`))
+		default:
+			w.Write([]byte(`{"output_text":"ok"}`))
+		}
+	}))
+	defer srv.Close()
+
+	p := NewOpenAIProvider("local", WithBaseURL(srv.URL+"/v1/"), WithModel("llama3"))
+	caps := p.Capabilities(context.Background())
+	if caps != (Capabilities{ToolCalls: true}) {
+		t.Errorf("caps = %+v, want tool calls only", caps)
+	}
+	// Forks share the cached result.
+	p.Fork().(*OpenAIProvider).Capabilities(context.Background())
+	if requests != 3 {
+		t.Errorf("probe requests = %d, want 3", requests)
+	}
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("no network in tests")
+}
+
+func TestOpenAICapabilities_KnownModel(t *testing.T) {
+	p := NewOpenAIProvider("key", WithModel("gpt-4.1-mini"), WithHTTPClient(&http.Client{Transport: failingTransport{}}))
+	if caps := p.Capabilities(context.Background()); !caps.StructuredOutput || !caps.ToolCalls || !caps.Streaming {
+		t.Errorf("caps = %+v, want all from the table", caps)
+	}
+	p = NewOpenAIProvider("key", WithModel("some-new-model"), WithHTTPClient(&http.Client{Transport: failingTransport{}}))
+	if caps := p.Capabilities(context.Background()); caps != (Capabilities{}) {
+		t.Errorf("caps = %+v, want none when probes fail", caps)
+	}
+	if got := (Capabilities{}).String(); !strings.Contains(got, "text only") {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -1,6 +1,8 @@
 package ai
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
@@ -14,7 +16,10 @@ type ContextAsset struct {
 	Category     string
 	Label        string
 	Summary      string
-	TemplatePath string
+	TemplatePath string // inside templates.FS; absolute for imported assets
+	// Outputs lists paths outside the default allowed roots that generation
+	// may emit when this asset is selected. A trailing slash allows a directory.
+	Outputs []string
 }
 
 func catalog() []ContextAsset {
@@ -49,6 +54,15 @@ func catalog() []ContextAsset {
 			TemplatePath: "core/.github/instructions/design-system.instructions.md",
 		},
 
+		// ── Core (optional) ──────────────────────────────────────────
+		{
+			ID:           "core.guardrails",
+			Category:     "safety",
+			Label:        "Destructive Operation Guardrails",
+			Summary:      "Agents must confirm before db drops, force pushes, rm -rf, production commands, or publishing",
+			TemplatePath: "core/.github/instructions/guardrails.instructions.md",
+		},
+
 		// ── Tier 1 Profiles (author's opinionated picks) ────────────
 		{
 			ID:           "profile.elixir-phoenix",
@@ -80,6 +94,13 @@ func catalog() []ContextAsset {
 			Summary:      "React ecosystem full-stack — App Router, RSC, Vercel-optimized",
 			TemplatePath: "profiles/typescript-nextjs/.github/instructions/typescript-nextjs.instructions.md",
 		},
+		{
+			ID:           "profile.typescript-nuxt",
+			Category:     "framework",
+			Label:        "TypeScript + Nuxt",
+			Summary:      "Vue ecosystem full-stack with file-based routing, composables, and Nitro server routes",
+			TemplatePath: "profiles/typescript-nuxt/.github/instructions/typescript-nuxt.instructions.md",
+		},
 		{
 			ID:           "profile.typescript-fastify",
 			Category:     "framework",
@@ -136,6 +157,97 @@ func catalog() []ContextAsset {
 			Summary:      "Laravel + Inertia project conventions for product-focused web apps",
 			TemplatePath: "profiles/laravel/.github/instructions/laravel.instructions.md",
 		},
+		{
+			ID:           "profile.android-compose",
+			Category:     "framework",
+			Label:        "Android + Jetpack Compose",
+			Summary:      "Native Android in Kotlin — Compose UI, ViewModels with StateFlow, Gradle version catalogs",
+			TemplatePath: "profiles/android-compose/.github/instructions/android-compose.instructions.md",
+		},
+		{
+			ID:           "profile.ios-swiftui",
+			Category:     "framework",
+			Label:        "iOS + SwiftUI",
+			Summary:      "Native iOS in Swift — SwiftUI views, @Observable models, async/await, XcodeGen projects",
+			TemplatePath: "profiles/ios-swiftui/.github/instructions/ios-swiftui.instructions.md",
+		},
+		{
+			ID:           "profile.cpp-service",
+			Category:     "framework",
+			Label:        "C++ Service (CMake)",
+			Summary:      "Modern C++20 services — target-based CMake with presets, vcpkg, RAII and value semantics, GoogleTest, sanitizers",
+			TemplatePath: "profiles/cpp-service/.github/instructions/cpp-service.instructions.md",
+		},
+		{
+			ID:           "profile.platform-infra",
+			Category:     "framework",
+			Label:        "Platform/Infra (Terraform + Kubernetes)",
+			Summary:      "Infrastructure as code — Terraform modules and remote state per environment, Kubernetes manifests with Kustomize, GitOps delivery with Argo CD",
+			TemplatePath: "profiles/platform-infra/.github/instructions/platform-infra.instructions.md",
+		},
+		{
+			ID:           "profile.data-dbt",
+			Category:     "framework",
+			Label:        "Data Engineering (dbt + Airflow/Dagster)",
+			Summary:      "Analytics pipelines — layered dbt models, sources and contracts, data tests and unit tests, orchestration with Dagster assets or Airflow DAGs",
+			TemplatePath: "profiles/data-dbt/.github/instructions/data-dbt.instructions.md",
+		},
+		{
+			ID:           "profile.python-ml",
+			Category:     "framework",
+			Label:        "Python ML Research (notebooks)",
+			Summary:      "ML research in Python — notebook hygiene, config-driven experiments with tracked runs, seeds and versioned data for reproducibility, moving notebook code into a tested package",
+			TemplatePath: "profiles/python-ml/.github/instructions/python-ml.instructions.md",
+		},
+		{
+			ID:           "profile.godot",
+			Category:     "framework",
+			Label:        "Godot (GDScript / C#)",
+			Summary:      "Godot 4 games — feature-organized scenes, small composable nodes, signals up and calls down, typed GDScript or C#, custom Resources for data",
+			TemplatePath: "profiles/godot/.github/instructions/godot.instructions.md",
+		},
+		{
+			ID:           "profile.bun-hono",
+			Category:     "framework",
+			Label:        "Bun + Hono",
+			Summary:      "Lightweight TypeScript APIs on Bun — Hono routes, zValidator, typed RPC client, bun test",
+			TemplatePath: "profiles/bun-hono/.github/instructions/bun-hono.instructions.md",
+		},
+		{
+			ID:           "profile.deno-fresh",
+			Category:     "framework",
+			Label:        "Deno + Fresh",
+			Summary:      "Server-rendered web on Deno — file routes, handlers, Preact islands, Deno KV and the std library",
+			TemplatePath: "profiles/deno-fresh/.github/instructions/deno-fresh.instructions.md",
+		},
+		{
+			ID:           "profile.react-native-expo",
+			Category:     "framework",
+			Label:        "React Native + Expo",
+			Summary:      "Cross-platform mobile in TypeScript — Expo Router file-based navigation, EAS Build, Submit, and Update",
+			TemplatePath: "profiles/react-native-expo/.github/instructions/react-native-expo.instructions.md",
+		},
+		{
+			ID:           "profile.electron",
+			Category:     "framework",
+			Label:        "Electron",
+			Summary:      "Desktop apps in TypeScript — main/renderer boundaries, contextBridge preload, Electron Forge packaging and signing",
+			TemplatePath: "profiles/electron/.github/instructions/electron.instructions.md",
+		},
+		{
+			ID:           "profile.browser-extension",
+			Category:     "framework",
+			Label:        "Browser Extension (Manifest V3)",
+			Summary:      "Cross-browser MV3 extensions with WXT — service worker that survives restarts, shadow-DOM content scripts, typed message passing, least-privilege permissions",
+			TemplatePath: "profiles/browser-extension/.github/instructions/browser-extension.instructions.md",
+		},
+		{
+			ID:           "profile.swift-vapor",
+			Category:     "framework",
+			Label:        "Swift + Vapor",
+			Summary:      "Server-side Swift — async/await routes, Fluent models, Codable DTOs",
+			TemplatePath: "profiles/swift-vapor/.github/instructions/swift-vapor.instructions.md",
+		},
 		{
 			ID:           "profile.java-spring",
 			Category:     "framework",
@@ -159,6 +271,97 @@ func catalog() []ContextAsset {
 			Summary:      "Framework-agnostic visual discipline, component composition, accessibility, motion, and styling system guidance",
 			TemplatePath: "addons/frontend-craft/.github/instructions/frontend-craft.instructions.md",
 		},
+		{
+			ID:           "addon.observability",
+			Category:     "operations",
+			Label:        "Observability Add-on",
+			Summary:      "OpenTelemetry traces and metrics, structured JSON logs, liveness and readiness endpoints",
+			TemplatePath: "addons/observability/.github/instructions/observability.instructions.md",
+		},
+		{
+			ID:           "addon.ci-cd",
+			Category:     "workflow",
+			Label:        "CI/CD Add-on",
+			Summary:      "Lint, test, build, and deploy pipeline stages, and the habits that keep main green",
+			TemplatePath: "addons/ci-cd/.github/instructions/ci-cd.instructions.md",
+		},
+		{
+			ID:           "addon.iac",
+			Category:     "infrastructure",
+			Label:        "Infrastructure as Code Add-on",
+			Summary:      "Terraform/Pulumi layout, dev/staging/prod separation, reviewed plans, and drift discipline for the app's own infrastructure",
+			TemplatePath: "addons/iac/.github/instructions/iac.instructions.md",
+		},
+		{
+			ID:           "addon.auth",
+			Category:     "security",
+			Label:        "Authentication & Authorization Add-on",
+			Summary:      "Session vs token auth, password hashing, OAuth/OIDC with PKCE, and role- and policy-based authorization using the framework's own auth libraries",
+			TemplatePath: "addons/auth/.github/instructions/auth.instructions.md",
+		},
+		{
+			ID:           "addon.graphql",
+			Category:     "architecture",
+			Label:        "GraphQL API Add-on",
+			Summary:      "Schema design, thin resolvers, and DataLoader batching against N+1 queries, on the stack's GraphQL server (Absinthe, Pothos, graphql-ruby, gqlgen)",
+			TemplatePath: "addons/graphql/.github/instructions/graphql.instructions.md",
+		},
+		{
+			ID:           "addon.api-design",
+			Category:     "architecture",
+			Label:        "REST API Design Add-on",
+			Summary:      "Resource naming, methods and status codes, cursor pagination, a single error envelope, versioning, and keeping the OpenAPI spec current",
+			TemplatePath: "addons/api-design/.github/instructions/api-design.instructions.md",
+		},
+		{
+			ID:           "addon.a11y",
+			Category:     "ui",
+			Label:        "Accessibility Add-on",
+			Summary:      "WCAG 2.2 AA targets, keyboard navigation patterns, ARIA usage discipline, and testing with axe and screen readers, for UI stacks",
+			TemplatePath: "addons/a11y/.github/instructions/a11y.instructions.md",
+		},
+		{
+			ID:           "addon.performance",
+			Category:     "architecture",
+			Label:        "Performance Budget Add-on",
+			Summary:      "Bundle-size, LCP, and query-latency budgets, and how to stay inside them with code-splitting, query plans, and layered caching",
+			TemplatePath: "addons/performance/.github/instructions/performance.instructions.md",
+		},
+		{
+			ID:           "addon.multi-tenancy",
+			Category:     "architecture",
+			Label:        "Multi-Tenancy Add-on",
+			Summary:      "Tenant isolation strategies (row-level security, schema or database per tenant), tenant scoping on every query, and cross-tenant tests for SaaS",
+			TemplatePath: "addons/multi-tenancy/.github/instructions/multi-tenancy.instructions.md",
+		},
+		{
+			ID:           "addon.event-sourcing",
+			Category:     "architecture",
+			Label:        "Event Sourcing & CQRS Add-on",
+			Summary:      "Aggregates, idempotent projections, optimistic concurrency, and event versioning with upcasters; a deeper companion to data-intensive",
+			TemplatePath: "addons/event-sourcing/.github/instructions/event-sourcing.instructions.md",
+		},
+		{
+			ID:           "addon.ai-integration",
+			Category:     "architecture",
+			Label:        "LLM Integration Add-on",
+			Summary:      "For apps that call LLMs: prompt versioning, structured output validation, evals in CI, cost and rate controls, and streaming UX",
+			TemplatePath: "addons/ai-integration/.github/instructions/ai-integration.instructions.md",
+		},
+		{
+			ID:           "addon.realtime",
+			Category:     "architecture",
+			Label:        "Realtime Add-on",
+			Summary:      "WebSocket and SSE features on the framework's channel layer (Phoenix Channels, Action Cable, SvelteKit/Next sockets): topology, presence, reconnection, backpressure",
+			TemplatePath: "addons/realtime/.github/instructions/realtime.instructions.md",
+		},
+		{
+			ID:           "addon.compliance",
+			Category:     "security",
+			Label:        "Compliance Add-on",
+			Summary:      "GDPR and SOC 2 engineering controls for regulated products: access control, audit logging, data retention, PII handling, and change management evidence",
+			TemplatePath: "addons/compliance/.github/instructions/compliance.instructions.md",
+		},
 
 		// ── Design Assets ────────────────────────────────────────────
 		{
@@ -198,6 +401,87 @@ func catalog() []ContextAsset {
 			Summary:      "Comprehensive testing conventions with framework-specific guidance, test pyramid, and file conventions",
 			TemplatePath: "assets/testing/pragmatic.instructions.md",
 		},
+		{
+			ID:           "asset.mobile.release",
+			Category:     "release",
+			Label:        "Mobile Release & Store Compliance",
+			Summary:      "Versioning, signing, store metadata, and crash reporting for App Store and Google Play releases (mobile-ui profiles only)",
+			TemplatePath: "assets/mobile/release.instructions.md",
+		},
+		{
+			ID:           "asset.app.cli",
+			Category:     "application",
+			Label:        "CLI Application Conventions",
+			Summary:      "Flags/config layering, exit codes, output formatting, and self-update for command-line tools (non-UI profiles only)",
+			TemplatePath: "assets/app/cli.instructions.md",
+		},
+		{
+			ID:           "asset.privacy.data-protection",
+			Category:     "privacy",
+			Label:        "Data Privacy & Protection",
+			Summary:      "PII classification, retention, data subject access/deletion flows, and audit logging for products handling user data",
+			TemplatePath: "assets/privacy/data-protection.instructions.md",
+		},
+		{
+			ID:           "asset.workspace.monorepo",
+			Category:     "workspace",
+			Label:        "Monorepo Workspace Conventions",
+			Summary:      "Workspace tooling (Turborepo/Nx, mix umbrella, go.work, Cargo workspaces), task running, and shared-package boundaries",
+			TemplatePath: "assets/workspace/monorepo.instructions.md",
+		},
+		{
+			ID:           "asset.workflow.releases",
+			Category:     "workflow",
+			Label:        "Changelog & Release Automation",
+			Summary:      "Semantic versioning, conventional commits, and release-please/changesets configuration for the ecosystem",
+			TemplatePath: "assets/workflow/releases.instructions.md",
+			Outputs:      []string{".changeset/", "release-please-config.json", ".release-please-manifest.json"},
+		},
+		{
+			ID:           "asset.workflow.planning",
+			Category:     "workflow",
+			Label:        "Task Decomposition Plan",
+			Summary:      "A plan.prompt.md that breaks the product brief into ordered milestones with checkpoints, for larger projects",
+			TemplatePath: "assets/workflow/planning.instructions.md",
+		},
+		{
+			ID:           "asset.workflow.first-feature",
+			Category:     "workflow",
+			Label:        "First Feature Brief",
+			Summary:      "A first-feature.prompt.md that turns the top features captured while scoping into an implementation brief for the first coding session",
+			TemplatePath: "assets/workflow/first-feature.instructions.md",
+		},
+		{
+			ID:           "asset.api.sdk",
+			Category:     "api",
+			Label:        "API Specs & Client SDK Generation",
+			Summary:      "Publishing an OpenAPI spec and generating typed clients, keeping spec and routes in lockstep (API-layer profiles only)",
+			TemplatePath: "assets/api/sdk.instructions.md",
+		},
+		{
+			ID:           "asset.deploy.fly",
+			Category:     "deploy",
+			Label:        "Fly.io Deployment Quickstart",
+			Summary:      "fly.toml, release commands, health checks, and first-deploy steps for Fly.io",
+			TemplatePath: "assets/deploy/fly.instructions.md",
+			Outputs:      []string{"fly.toml"},
+		},
+		{
+			ID:           "asset.deploy.render",
+			Category:     "deploy",
+			Label:        "Render Deployment Quickstart",
+			Summary:      "render.yaml blueprint, pre-deploy migrations, and env groups for Render",
+			TemplatePath: "assets/deploy/render.instructions.md",
+			Outputs:      []string{"render.yaml"},
+		},
+		{
+			ID:           "asset.deploy.railway",
+			Category:     "deploy",
+			Label:        "Railway Deployment Quickstart",
+			Summary:      "railway.json config-as-code, service variables, and first-deploy steps for Railway",
+			TemplatePath: "assets/deploy/railway.instructions.md",
+			Outputs:      []string{"railway.json"},
+		},
 		{
 			ID:           "asset.server.patterns",
 			Category:     "server",
@@ -208,16 +492,56 @@ func catalog() []ContextAsset {
 	}
 }
 
+// Catalog returns every context asset, in catalog order, followed by the
+// assets imported into the local catalog.
+func Catalog() []ContextAsset {
+	return allAssets()
+}
+
+// AlwaysIncluded reports whether an asset is part of every generation and so
+// can't be selected or deselected.
+func AlwaysIncluded(id string) bool {
+	for _, base := range baseAssetIDs {
+		if id == base {
+			return true
+		}
+	}
+	return false
+}
+
+// baseAssetIDs are resolved for every selection.
+var baseAssetIDs = []string{"core.copilot", "core.architecture", "core.agents", "core.design-system"}
+
+// TemplateHashes returns the SHA-256 of every template a selection draws on,
+// keyed by asset ID, so a later run can tell when the knowledge base changed
+// underneath it.
+func TemplateHashes(sel Selection) (map[string]string, error) {
+	assets, err := resolveContextAssets(sel)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(map[string]string, len(assets))
+	for _, a := range assets {
+		data, err := ReadTemplate(a.TemplatePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading asset %s: %w", a.ID, err)
+		}
+		sum := sha256.Sum256(data)
+		hashes[a.ID] = hex.EncodeToString(sum[:])
+	}
+	return hashes, nil
+}
+
 func catalogMap() map[string]ContextAsset {
 	byID := make(map[string]ContextAsset)
-	for _, item := range catalog() {
+	for _, item := range allAssets() {
 		byID[item.ID] = item
 	}
 	return byID
 }
 
 func catalogSummaryLines() []string {
-	items := catalog()
+	items := allAssets()
 	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
 	lines := make([]string, 0, len(items))
 	for _, item := range items {
@@ -229,9 +553,8 @@ func catalogSummaryLines() []string {
 func resolveContextAssets(selection Selection) ([]ContextAsset, error) {
 	byID := catalogMap()
 
-	base := []string{"core.copilot", "core.architecture", "core.agents", "core.design-system"}
-	resolvedIDs := make([]string, 0, len(base)+len(selection.AddonIDs)+len(selection.AssetIDs)+2)
-	resolvedIDs = append(resolvedIDs, base...)
+	resolvedIDs := make([]string, 0, len(baseAssetIDs)+len(selection.AddonIDs)+len(selection.AssetIDs)+2)
+	resolvedIDs = append(resolvedIDs, baseAssetIDs...)
 
 	if selection.ProfileID != "" {
 		profileID := selection.ProfileID
@@ -248,6 +571,9 @@ func resolveContextAssets(selection Selection) ([]ContextAsset, error) {
 		resolvedIDs = append(resolvedIDs, id)
 	}
 	resolvedIDs = append(resolvedIDs, selection.AssetIDs...)
+	for _, pkg := range selection.Packages {
+		resolvedIDs = append(resolvedIDs, "profile."+pkg.ProfileID)
+	}
 
 	// Auto-include frontend-craft, default palette, and default font for
 	// profiles that have a UI surface. This ensures every generated app
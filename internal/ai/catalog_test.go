@@ -121,3 +121,18 @@ func TestResolveContextAssetsWithServerPatterns(t *testing.T) {
 		t.Error("addon.frontend-craft should be auto-included for UI profile")
 	}
 }
+
+func TestTemplateHashes(t *testing.T) {
+	hashes, err := TemplateHashes(Selection{ProfileID: "go-service", AssetIDs: []string{"asset.app.cli"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"core.copilot", "profile.go-service", "asset.app.cli"} {
+		if len(hashes[id]) != 64 {
+			t.Errorf("hash for %s = %q", id, hashes[id])
+		}
+	}
+	if _, err := TemplateHashes(Selection{ProfileID: "cobol"}); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
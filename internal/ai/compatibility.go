@@ -1,6 +1,11 @@
 package ai
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/scaffold"
+)
 
 // ValidateSelectionCompatibility enforces hard selection constraints.
 func ValidateSelectionCompatibility(selection Selection) []string {
@@ -16,6 +21,7 @@ func ValidateSelectionCompatibility(selection Selection) []string {
 			"ruby-rails":           true,
 			// Tier 2
 			"typescript-nextjs":  true,
+			"typescript-nuxt":    true,
 			"typescript-fastify": true,
 			"go-service":         true,
 			"dotnet-api":         true,
@@ -25,28 +31,67 @@ func ValidateSelectionCompatibility(selection Selection) []string {
 			"rust-axum":          true,
 			"laravel":            true,
 			"java-spring":        true,
+			"swift-vapor":        true,
+			"android-compose":    true,
+			"ios-swiftui":        true,
+			"cpp-service":        true,
+			"platform-infra":     true,
+			"data-dbt":           true,
+			"python-ml":          true,
+			"godot":              true,
+			"bun-hono":           true,
+			"deno-fresh":         true,
+			"react-native-expo":  true,
+			"electron":           true,
+			"browser-extension":  true,
 		}
 		if !validProfile[selection.ProfileID] {
 			issues = append(issues, "profile_id is not supported by this Launchpad build")
 		}
 	}
 
-	// Profiles that have a frontend surface can use frontend-craft.
+	// Profiles that have a frontend surface can use frontend-craft and a11y.
 	// All profiles can use data-intensive.
+	// Profiles that run a server process can use observability, api-design,
+	// multi-tenancy, event-sourcing, and iac; platform-infra already is
+	// infrastructure as code.
+	// Every profile can use ci-cd, and every profile but godot, which holds
+	// no customer data, can use compliance.
+	// Server profiles and app clients that sign users in can use auth.
+	// The same profiles hold live connections and can use realtime.
+	// Server profiles with a maintained GraphQL server library can use
+	// graphql; cpp-service has none.
+	// Server profiles and python-ml, which keep provider keys off the
+	// client, can use ai-integration.
+	// Profiles with a UI or a server process can use performance.
 	allowedAddonsByProfile := map[string]map[string]bool{
-		"elixir-phoenix":       {"frontend-craft": true, "data-intensive": true},
-		"typescript-sveltekit": {"frontend-craft": true, "data-intensive": true},
-		"ruby-rails":           {"frontend-craft": true, "data-intensive": true},
-		"typescript-nextjs":    {"frontend-craft": true, "data-intensive": true},
-		"typescript-fastify":   {"data-intensive": true},
-		"go-service":           {"data-intensive": true},
-		"dotnet-api":           {"data-intensive": true},
-		"python-fastapi":       {"data-intensive": true},
-		"python-django":        {"frontend-craft": true, "data-intensive": true},
-		"dart-flutter":         {"frontend-craft": true},
-		"rust-axum":            {"data-intensive": true},
-		"laravel":              {"frontend-craft": true, "data-intensive": true},
-		"java-spring":          {"data-intensive": true},
+		"elixir-phoenix":       {"frontend-craft": true, "a11y": true, "data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"typescript-sveltekit": {"frontend-craft": true, "a11y": true, "data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"ruby-rails":           {"frontend-craft": true, "a11y": true, "data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"typescript-nextjs":    {"frontend-craft": true, "a11y": true, "data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"typescript-nuxt":      {"frontend-craft": true, "a11y": true, "data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"typescript-fastify":   {"data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"go-service":           {"data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"dotnet-api":           {"data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"python-fastapi":       {"data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"python-django":        {"frontend-craft": true, "a11y": true, "data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"dart-flutter":         {"frontend-craft": true, "a11y": true, "ci-cd": true, "auth": true, "realtime": true, "performance": true, "compliance": true},
+		"rust-axum":            {"data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"laravel":              {"frontend-craft": true, "a11y": true, "data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"java-spring":          {"data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"swift-vapor":          {"data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"android-compose":      {"frontend-craft": true, "a11y": true, "ci-cd": true, "auth": true, "realtime": true, "performance": true, "compliance": true},
+		"ios-swiftui":          {"frontend-craft": true, "a11y": true, "ci-cd": true, "auth": true, "realtime": true, "performance": true, "compliance": true},
+		"cpp-service":          {"data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"platform-infra":       {"ci-cd": true, "compliance": true},
+		"data-dbt":             {"data-intensive": true, "ci-cd": true, "compliance": true},
+		"python-ml":            {"data-intensive": true, "ai-integration": true, "ci-cd": true, "compliance": true},
+		"godot":                {"ci-cd": true},
+		"bun-hono":             {"data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"deno-fresh":           {"frontend-craft": true, "a11y": true, "data-intensive": true, "observability": true, "api-design": true, "multi-tenancy": true, "event-sourcing": true, "ai-integration": true, "auth": true, "realtime": true, "graphql": true, "iac": true, "ci-cd": true, "performance": true, "compliance": true},
+		"react-native-expo":    {"frontend-craft": true, "a11y": true, "ci-cd": true, "auth": true, "realtime": true, "performance": true, "compliance": true},
+		"electron":             {"frontend-craft": true, "a11y": true, "ci-cd": true, "auth": true, "realtime": true, "performance": true, "compliance": true},
+		"browser-extension":    {"frontend-craft": true, "a11y": true, "ci-cd": true, "performance": true, "compliance": true},
 	}
 
 	seenAddons := map[string]bool{}
@@ -67,7 +112,7 @@ func ValidateSelectionCompatibility(selection Selection) []string {
 	}
 
 	seenAssets := map[string]bool{}
-	var paletteCount, fontCount, lintCount, testingCount int
+	var paletteCount, fontCount, lintCount, testingCount, deployCount int
 	for _, assetID := range selection.AssetIDs {
 		if assetID == "" {
 			continue
@@ -87,12 +132,24 @@ func ValidateSelectionCompatibility(selection Selection) []string {
 			lintCount++
 		case strings.HasPrefix(assetID, "asset.testing."):
 			testingCount++
+		case strings.HasPrefix(assetID, "asset.mobile."):
+			if p := scaffold.FindProfile(selection.ProfileID); p == nil || p.Layer != "mobile-ui" {
+				issues = append(issues, "asset_id requires a mobile-ui profile: "+assetID)
+			}
+		case strings.HasPrefix(assetID, "asset.deploy."):
+			deployCount++
+			if p := scaffold.FindProfile(selection.ProfileID); p != nil && p.Layer == "mobile-ui" {
+				issues = append(issues, "deployment asset not compatible with a mobile profile: "+assetID)
+			}
+		case assetID == "asset.app.cli", assetID == "asset.api.sdk":
+			if p := scaffold.FindProfile(selection.ProfileID); p == nil || p.HasUI {
+				issues = append(issues, "asset_id requires a profile without a UI surface: "+assetID)
+			}
 		}
 	}
 
-	if paletteCount > 1 {
-		issues = append(issues, "only one palette asset may be selected")
-	}
+	issues = append(issues, paletteRoleIssues(selection, seenAssets, paletteCount)...)
+	issues = append(issues, constraintIssues(selection)...)
 	if fontCount > 1 {
 		issues = append(issues, "only one font asset may be selected")
 	}
@@ -102,6 +159,36 @@ func ValidateSelectionCompatibility(selection Selection) []string {
 	if testingCount > 1 {
 		issues = append(issues, "only one testing asset may be selected")
 	}
+	if deployCount > 1 {
+		issues = append(issues, "only one deployment asset may be selected")
+	}
+
+	return issues
+}
 
+// paletteRoleIssues checks palette_roles. One palette needs no role; two
+// need one primary and one secondary; more than two are never allowed.
+func paletteRoleIssues(selection Selection, selected map[string]bool, paletteCount int) []string {
+	var issues []string
+	if paletteCount > 2 {
+		issues = append(issues, "at most two palette assets may be selected")
+	}
+	roles := map[string]int{}
+	for _, r := range selection.PaletteRoles {
+		switch {
+		case !strings.HasPrefix(r.AssetID, "asset.palette.") || !selected[r.AssetID]:
+			issues = append(issues, "palette_roles names a palette that isn't selected: "+r.AssetID)
+		case r.Role != PalettePrimary && r.Role != PaletteSecondary:
+			issues = append(issues, fmt.Sprintf("invalid palette role %q for %s (want primary or secondary)", r.Role, r.AssetID))
+		default:
+			roles[r.Role]++
+		}
+	}
+	if paletteCount == 2 && (roles[PalettePrimary] != 1 || roles[PaletteSecondary] != 1) {
+		issues = append(issues, "two palettes need palette_roles naming one primary and one secondary")
+	}
+	if paletteCount == 1 && roles[PaletteSecondary] > 0 {
+		issues = append(issues, "a single palette can't be secondary")
+	}
 	return issues
 }
@@ -40,6 +40,201 @@ func TestValidateSelectionCompatibility(t *testing.T) {
 			selection:  Selection{ProfileID: "rust-axum", AddonIDs: []string{"data-intensive"}},
 			wantIssues: 0,
 		},
+		{
+			name:       "observability allowed for go-service",
+			selection:  Selection{ProfileID: "go-service", AddonIDs: []string{"data-intensive", "observability"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "iac allowed for rust-axum",
+			selection:  Selection{ProfileID: "rust-axum", AddonIDs: []string{"iac", "observability"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "iac redundant for platform-infra",
+			selection:  Selection{ProfileID: "platform-infra", AddonIDs: []string{"iac"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "auth allowed for ios-swiftui",
+			selection:  Selection{ProfileID: "ios-swiftui", AddonIDs: []string{"frontend-craft", "auth"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "auth incompatible with data-dbt",
+			selection:  Selection{ProfileID: "data-dbt", AddonIDs: []string{"auth"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "graphql allowed for go-service",
+			selection:  Selection{ProfileID: "go-service", AddonIDs: []string{"graphql"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "graphql incompatible with cpp-service",
+			selection:  Selection{ProfileID: "cpp-service", AddonIDs: []string{"graphql"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "graphql incompatible with client profiles",
+			selection:  Selection{ProfileID: "react-native-expo", AddonIDs: []string{"graphql"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "api-design allowed for python-fastapi",
+			selection:  Selection{ProfileID: "python-fastapi", AddonIDs: []string{"api-design"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "api-design incompatible with android-compose",
+			selection:  Selection{ProfileID: "android-compose", AddonIDs: []string{"api-design"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "a11y allowed for ios-swiftui",
+			selection:  Selection{ProfileID: "ios-swiftui", AddonIDs: []string{"a11y"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "a11y incompatible with go-service",
+			selection:  Selection{ProfileID: "go-service", AddonIDs: []string{"a11y"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "performance allowed for typescript-nextjs",
+			selection:  Selection{ProfileID: "typescript-nextjs", AddonIDs: []string{"performance"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "performance incompatible with platform-infra",
+			selection:  Selection{ProfileID: "platform-infra", AddonIDs: []string{"performance"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "multi-tenancy allowed for ruby-rails",
+			selection:  Selection{ProfileID: "ruby-rails", AddonIDs: []string{"multi-tenancy"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "multi-tenancy incompatible with ios-swiftui",
+			selection:  Selection{ProfileID: "ios-swiftui", AddonIDs: []string{"multi-tenancy"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "event-sourcing allowed for dotnet-api",
+			selection:  Selection{ProfileID: "dotnet-api", AddonIDs: []string{"data-intensive", "event-sourcing"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "event-sourcing incompatible with data-dbt",
+			selection:  Selection{ProfileID: "data-dbt", AddonIDs: []string{"event-sourcing"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "ai-integration allowed for python-ml",
+			selection:  Selection{ProfileID: "python-ml", AddonIDs: []string{"ai-integration"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "ai-integration incompatible with react-native-expo",
+			selection:  Selection{ProfileID: "react-native-expo", AddonIDs: []string{"ai-integration"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "realtime allowed for elixir-phoenix",
+			selection:  Selection{ProfileID: "elixir-phoenix", AddonIDs: []string{"realtime"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "realtime incompatible with data-dbt",
+			selection:  Selection{ProfileID: "data-dbt", AddonIDs: []string{"realtime"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "compliance allowed for platform-infra",
+			selection:  Selection{ProfileID: "platform-infra", AddonIDs: []string{"compliance"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "compliance incompatible with godot",
+			selection:  Selection{ProfileID: "godot", AddonIDs: []string{"compliance"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "ci-cd allowed for godot",
+			selection:  Selection{ProfileID: "godot", AddonIDs: []string{"ci-cd"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "observability incompatible with android-compose",
+			selection:  Selection{ProfileID: "android-compose", AddonIDs: []string{"observability"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "frontend-craft incompatible with swift-vapor",
+			selection:  Selection{ProfileID: "swift-vapor", AddonIDs: []string{"frontend-craft"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "data-intensive incompatible with android-compose",
+			selection:  Selection{ProfileID: "android-compose", AddonIDs: []string{"data-intensive"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "mobile release allowed for ios-swiftui",
+			selection:  Selection{ProfileID: "ios-swiftui", AddonIDs: []string{"frontend-craft"}, AssetIDs: []string{"asset.mobile.release"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "mobile release allowed for react-native-expo",
+			selection:  Selection{ProfileID: "react-native-expo", AddonIDs: []string{"frontend-craft"}, AssetIDs: []string{"asset.mobile.release"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "deno-fresh takes both add-ons",
+			selection:  Selection{ProfileID: "deno-fresh", AddonIDs: []string{"frontend-craft", "data-intensive"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "bun-hono rejects frontend-craft",
+			selection:  Selection{ProfileID: "bun-hono", AddonIDs: []string{"frontend-craft"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "cpp-service takes data-intensive",
+			selection:  Selection{ProfileID: "cpp-service", AddonIDs: []string{"data-intensive"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "platform-infra takes no add-ons",
+			selection:  Selection{ProfileID: "platform-infra", AddonIDs: []string{"data-intensive"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "python-ml accepts data-intensive",
+			selection:  Selection{ProfileID: "python-ml", AddonIDs: []string{"data-intensive"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "browser-extension accepts frontend-craft",
+			selection:  Selection{ProfileID: "browser-extension", AddonIDs: []string{"frontend-craft"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "godot rejects frontend-craft",
+			selection:  Selection{ProfileID: "godot", AddonIDs: []string{"frontend-craft"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "data-dbt rejects frontend-craft",
+			selection:  Selection{ProfileID: "data-dbt", AddonIDs: []string{"frontend-craft"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "electron rejects mobile release",
+			selection:  Selection{ProfileID: "electron", AddonIDs: []string{"frontend-craft"}, AssetIDs: []string{"asset.mobile.release"}},
+			wantIssues: 1,
+		},
 		{
 			name:       "duplicate addon",
 			selection:  Selection{ProfileID: "ruby-rails", AddonIDs: []string{"data-intensive", "data-intensive"}},
@@ -51,13 +246,49 @@ func TestValidateSelectionCompatibility(t *testing.T) {
 			wantIssues: 1,
 		},
 		{
-			name: "multiple palettes rejected",
+			name: "two palettes without roles rejected",
 			selection: Selection{
 				ProfileID: "ruby-rails",
 				AssetIDs:  []string{"asset.palette.heroui-blue", "asset.palette.obsidian-indigo"},
 			},
 			wantIssues: 1,
 		},
+		{
+			name: "two palettes with primary and secondary roles",
+			selection: Selection{
+				ProfileID: "ruby-rails",
+				AssetIDs:  []string{"asset.palette.heroui-blue", "asset.palette.obsidian-indigo"},
+				PaletteRoles: []PaletteRole{
+					{AssetID: "asset.palette.obsidian-indigo", Role: PalettePrimary},
+					{AssetID: "asset.palette.heroui-blue", Role: PaletteSecondary},
+				},
+			},
+			wantIssues: 0,
+		},
+		{
+			name: "two primary palettes rejected",
+			selection: Selection{
+				ProfileID: "ruby-rails",
+				AssetIDs:  []string{"asset.palette.heroui-blue", "asset.palette.obsidian-indigo"},
+				PaletteRoles: []PaletteRole{
+					{AssetID: "asset.palette.obsidian-indigo", Role: PalettePrimary},
+					{AssetID: "asset.palette.heroui-blue", Role: PalettePrimary},
+				},
+			},
+			wantIssues: 1,
+		},
+		{
+			name: "palette role for an unselected palette and an unknown role",
+			selection: Selection{
+				ProfileID: "ruby-rails",
+				AssetIDs:  []string{"asset.palette.obsidian-indigo"},
+				PaletteRoles: []PaletteRole{
+					{AssetID: "asset.palette.heroui-blue", Role: PalettePrimary},
+					{AssetID: "asset.palette.obsidian-indigo", Role: "marketing"},
+				},
+			},
+			wantIssues: 2,
+		},
 		{
 			name: "multiple testing assets rejected",
 			selection: Selection{
@@ -66,6 +297,51 @@ func TestValidateSelectionCompatibility(t *testing.T) {
 			},
 			wantIssues: 1,
 		},
+		{
+			name:       "mobile release asset on mobile profile",
+			selection:  Selection{ProfileID: "dart-flutter", AssetIDs: []string{"asset.mobile.release"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "mobile release asset rejected on web profile",
+			selection:  Selection{ProfileID: "typescript-sveltekit", AssetIDs: []string{"asset.mobile.release"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "cli asset on go-service",
+			selection:  Selection{ProfileID: "go-service", AssetIDs: []string{"asset.app.cli"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "cli asset rejected on UI profile",
+			selection:  Selection{ProfileID: "ruby-rails", AssetIDs: []string{"asset.app.cli"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "api sdk asset on fastapi",
+			selection:  Selection{ProfileID: "python-fastapi", AssetIDs: []string{"asset.api.sdk"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "api sdk asset rejected on mobile profile",
+			selection:  Selection{ProfileID: "dart-flutter", AssetIDs: []string{"asset.api.sdk"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "one deployment asset",
+			selection:  Selection{ProfileID: "elixir-phoenix", AssetIDs: []string{"asset.deploy.fly"}},
+			wantIssues: 0,
+		},
+		{
+			name:       "multiple deployment assets rejected",
+			selection:  Selection{ProfileID: "elixir-phoenix", AssetIDs: []string{"asset.deploy.fly", "asset.deploy.render"}},
+			wantIssues: 1,
+		},
+		{
+			name:       "deployment asset rejected on mobile profile",
+			selection:  Selection{ProfileID: "dart-flutter", AssetIDs: []string{"asset.deploy.railway"}},
+			wantIssues: 1,
+		},
 		{
 			name: "one of each category is fine",
 			selection: Selection{
@@ -0,0 +1,153 @@
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxConstraints caps how many hard constraints a selection carries.
+const maxConstraints = 8
+
+// MergeConstraints returns the constraints of original followed by any new
+// ones from extra, trimmed, without case-insensitive duplicates, and capped
+// at maxConstraints. Like tags, constraints stated when the project was
+// first generated keep applying on later runs.
+func MergeConstraints(original, extra []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, list := range [][]string{original, extra} {
+		for _, c := range list {
+			c = strings.Join(strings.Fields(c), " ")
+			key := strings.ToLower(c)
+			if c == "" || seen[key] || len(out) == maxConstraints {
+				continue
+			}
+			seen[key] = true
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// constraintRule recognizes a kind of hard constraint by its wording and
+// names what it rules out. A selection that includes anything ruled out is
+// incompatible with the constraint.
+type constraintRule struct {
+	match  *regexp.Regexp
+	reason string   // why, as shown in the issue
+	only   []string // when set, the only profiles allowed
+	not    []string // profile, addon, and asset IDs ruled out; a trailing "." rules out a prefix
+}
+
+// constraintRules cover the constraints that map onto the catalog. Anything
+// else is still passed to generation as a note.
+var constraintRules = []constraintRule{
+	{
+		match:  regexp.MustCompile(`\b(?:no (?:external |third[- ]party )?saas|self[- ]hosted|on[- ]prem(?:ise|ises)?|air[- ]?gapped|no (?:public )?cloud)\b`),
+		reason: "hosted platforms are out",
+		not:    []string{"asset.deploy."},
+	},
+	{
+		match:  regexp.MustCompile(`\b(?:raspberry pi|low[- ]memory|embedded|\d+ ?mb (?:of )?ram|single[- ]board)\b`),
+		reason: "it must fit on small hardware",
+		not:    []string{"profile.java-spring", "profile.electron", "addon.data-intensive"},
+	},
+}
+
+// languageProfiles maps a language a team is limited to onto the profiles
+// written in it.
+var languageProfiles = []struct {
+	pattern  string // regexp alternatives naming the language
+	profiles []string
+}{
+	{`python`, []string{"python-fastapi", "python-django", "python-ml", "data-dbt"}},
+	{`typescript|javascript|ts|js|node(?:\.?js)?`, []string{"typescript-sveltekit", "typescript-nextjs", "typescript-nuxt", "typescript-fastify", "bun-hono", "deno-fresh", "react-native-expo", "electron", "browser-extension"}},
+	{`go|golang`, []string{"go-service"}},
+	{`ruby`, []string{"ruby-rails"}},
+	{`php`, []string{"laravel"}},
+	{`elixir`, []string{"elixir-phoenix"}},
+	{`java|kotlin`, []string{"java-spring", "android-compose"}},
+	{`c#|\.net|dotnet`, []string{"dotnet-api", "godot"}},
+	{`rust`, []string{"rust-axum"}},
+	{`dart`, []string{"dart-flutter"}},
+	{`swift`, []string{"swift-vapor", "ios-swiftui"}},
+	{`c\+\+`, []string{"cpp-service"}},
+}
+
+func init() {
+	for _, l := range languageProfiles {
+		// "team only knows Python", "Python only", "Python-only shop".
+		// Word boundaries don't work around "c#" or "c++", so the edges are
+		// spelled out.
+		lang := `(?:` + l.pattern + `)`
+		constraintRules = append(constraintRules, constraintRule{
+			match:  regexp.MustCompile(`(?:^|[^\w])(?:only (?:knows? |uses? |writes? |speaks? )?` + lang + `|` + lang + `[- ]only)(?:$|[^\w+#])`),
+			reason: "the team is limited to one language",
+			only:   l.profiles,
+		})
+	}
+}
+
+// constraintIssues lists what in selection a stated constraint rules out.
+func constraintIssues(selection Selection) []string {
+	ids := []string{"profile." + selection.ProfileID}
+	for _, a := range selection.AddonIDs {
+		ids = append(ids, "addon."+a)
+	}
+	ids = append(ids, selection.AssetIDs...)
+
+	var issues []string
+	for _, c := range selection.Constraints {
+		text := strings.ToLower(c)
+		for _, r := range constraintRules {
+			if !r.match.MatchString(text) {
+				continue
+			}
+			if len(r.only) > 0 && selection.ProfileID != "" && !containsString(r.only, selection.ProfileID) {
+				issues = append(issues, fmt.Sprintf("constraint %q rules out profile %s (%s)", c, selection.ProfileID, r.reason))
+			}
+			for _, id := range ids {
+				if ruledOut(r.not, id) {
+					issues = append(issues, fmt.Sprintf("constraint %q rules out %s (%s)", c, id, r.reason))
+				}
+			}
+		}
+	}
+	return issues
+}
+
+func ruledOut(not []string, id string) bool {
+	for _, n := range not {
+		if n == id || strings.HasSuffix(n, ".") && strings.HasPrefix(id, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// constraintGuidance tells generation what the user said the project must
+// respect, or "" when there is nothing.
+func constraintGuidance(constraints []string) string {
+	if len(constraints) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("HARD CONSTRAINTS (stated by the user):\n")
+	for _, c := range constraints {
+		sb.WriteString("- " + c + "\n")
+	}
+	sb.WriteString("Every generated file must respect these. Drop or replace any template\n")
+	sb.WriteString("guidance that conflicts with them, and list them under a \"Constraints\"\n")
+	sb.WriteString("section in copilot-instructions.md so agents see them first.\n\n")
+	return sb.String()
+}
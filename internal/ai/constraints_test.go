@@ -0,0 +1,104 @@
+package ai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeConstraints(t *testing.T) {
+	tests := []struct {
+		name            string
+		original, extra []string
+		want            []string
+	}{
+		{"trimmed", nil, []string{"  must run on a   Raspberry Pi ", ""}, []string{"must run on a Raspberry Pi"}},
+		{"original first", []string{"no external SaaS"}, []string{"No external SaaS", "self-hosted"}, []string{"no external SaaS", "self-hosted"}},
+		{"capped", nil, strings.Fields("a b c d e f g h i j"), strings.Fields("a b c d e f g h")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeConstraints(tt.original, tt.extra); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeConstraints = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintIssues(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints []string
+		selection   Selection
+		want        []string // substrings, one per expected issue
+	}{
+		{
+			name:        "python-only team",
+			constraints: []string{"team only knows Python"},
+			selection:   Selection{ProfileID: "go-service"},
+			want:        []string{"rules out profile go-service"},
+		},
+		{
+			name:        "python-only team on a python profile",
+			constraints: []string{"Python-only shop"},
+			selection:   Selection{ProfileID: "python-fastapi", AddonIDs: []string{"data-intensive"}},
+		},
+		{
+			name:        "c# with symbol edges",
+			constraints: []string{"we only write C#"},
+			selection:   Selection{ProfileID: "dotnet-api"},
+		},
+		{
+			name:        "small hardware",
+			constraints: []string{"must run on a Raspberry Pi"},
+			selection:   Selection{ProfileID: "java-spring", AddonIDs: []string{"data-intensive"}},
+			want:        []string{"rules out profile.java-spring", "rules out addon.data-intensive"},
+		},
+		{
+			name:        "no SaaS",
+			constraints: []string{"No external SaaS"},
+			selection:   Selection{ProfileID: "go-service", AssetIDs: []string{"asset.deploy.fly", "asset.lint.strict"}},
+			want:        []string{"rules out asset.deploy.fly"},
+		},
+		{
+			name:        "unrecognized constraint blocks nothing",
+			constraints: []string{"must support right-to-left languages"},
+			selection:   Selection{ProfileID: "go-service", AssetIDs: []string{"asset.deploy.fly"}},
+		},
+		{
+			name:        "go as a verb is not a language",
+			constraints: []string{"we go live only on weekdays"},
+			selection:   Selection{ProfileID: "ruby-rails"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.selection.Constraints = tt.constraints
+			got := constraintIssues(tt.selection)
+			if len(got) != len(tt.want) {
+				t.Fatalf("issues = %q, want %d", got, len(tt.want))
+			}
+			for i, w := range tt.want {
+				if !strings.Contains(got[i], w) {
+					t.Errorf("issue %d = %q, want it to contain %q", i, got[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSelection_Constraints(t *testing.T) {
+	sel, err := parseSelection(`{"profile_id":"go-service","confidence":0.9,"constraints":["no external SaaS"," No external SaaS "]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"no external SaaS"}; !reflect.DeepEqual(sel.Constraints, want) {
+		t.Errorf("constraints = %q, want %q", sel.Constraints, want)
+	}
+	if got := constraintGuidance(sel.Constraints); !strings.Contains(got, "- no external SaaS\n") {
+		t.Errorf("constraintGuidance = %q", got)
+	}
+	if constraintGuidance(nil) != "" {
+		t.Error("constraintGuidance(nil) should be empty")
+	}
+}
@@ -0,0 +1,80 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxContinuations bounds how many follow-ups are sent to finish a reply that
+// was cut off. Generation output is large but finite; if it is still
+// truncated after this many rounds something else is wrong.
+const maxContinuations = 3
+
+// sendFiles sends a prompt whose reply is a series of ===FILE: blocks and
+// keeps asking for the rest while the reply is truncated — either reported by
+// the provider or evident from a block with no ===END_FILE=== marker. The
+// partial block is discarded and the model re-emits that file whole, so the
+// stitched output never contains half a file.
+func (e *Engine) sendFiles(ctx context.Context, p Provider, prompt string) (string, error) {
+	raw, err := p.Send(ctx, prompt, "")
+	if err != nil && !errors.Is(err, ErrTruncated) {
+		return "", err
+	}
+	truncatedByLimit := err != nil
+
+	var out strings.Builder
+	for attempt := 0; ; attempt++ {
+		complete, partial, cut := splitTruncated(raw)
+		out.WriteString(complete)
+		if !cut && !truncatedByLimit {
+			return out.String(), nil
+		}
+		if attempt == maxContinuations {
+			return "", fmt.Errorf("model output was still truncated after %d continuation(s)", maxContinuations)
+		}
+		e.warn("model output was cut off — requesting the rest")
+
+		raw, err = p.Send(ctx, continuationPrompt(partial), "")
+		if err != nil && !errors.Is(err, ErrTruncated) {
+			return "", err
+		}
+		truncatedByLimit = err != nil
+		out.WriteString("\n")
+	}
+}
+
+// splitTruncated separates raw into the text up to the last complete file
+// block and, when the reply ends inside a block, the path of that block.
+// cut reports whether an unterminated block was found.
+func splitTruncated(raw string) (complete, partialPath string, cut bool) {
+	const startMark = "===FILE: "
+	const endMark = "===END_FILE==="
+
+	lastStart := strings.LastIndex(raw, startMark)
+	if lastStart == -1 || strings.Contains(raw[lastStart:], endMark) {
+		return raw, "", false
+	}
+	header := raw[lastStart+len(startMark):]
+	if i := strings.Index(header, "==="); i != -1 {
+		partialPath = strings.TrimSpace(header[:i])
+	}
+	return raw[:lastStart], partialPath, true
+}
+
+// continuationPrompt asks the model to pick up after the last complete file.
+func continuationPrompt(partialPath string) string {
+	var sb strings.Builder
+	sb.WriteString("Your previous reply was cut off before it finished.\n")
+	if partialPath != "" {
+		fmt.Fprintf(&sb, "The file %s was incomplete and has been discarded. Re-emit it in full,\n", partialPath)
+		sb.WriteString("then continue with every remaining file you had not yet written.\n")
+	} else {
+		sb.WriteString("Continue with every remaining file you had not yet written.\n")
+	}
+	sb.WriteString("Do NOT repeat files that were already complete.\n\n")
+	sb.WriteString("Output ONLY file blocks — no prose before or after:\n")
+	sb.WriteString("===FILE: relative/path===\n(content)\n===END_FILE===\n")
+	return sb.String()
+}
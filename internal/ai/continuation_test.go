@@ -0,0 +1,89 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSplitTruncated(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          string
+		wantComplete string
+		wantPath     string
+		wantCut      bool
+	}{
+		{
+			name:         "complete output",
+			raw:          "===FILE: a.md===\nA\n===END_FILE===",
+			wantComplete: "===FILE: a.md===\nA\n===END_FILE===",
+		},
+		{
+			name:         "cut inside second file",
+			raw:          "===FILE: a.md===\nA\n===END_FILE===\n===FILE: b.md===\nhalf",
+			wantComplete: "===FILE: a.md===\nA\n===END_FILE===\n",
+			wantPath:     "b.md",
+			wantCut:      true,
+		},
+		{
+			name:    "cut inside header",
+			raw:     "===FILE: .github/ins",
+			wantCut: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			complete, path, cut := splitTruncated(tt.raw)
+			if complete != tt.wantComplete || path != tt.wantPath || cut != tt.wantCut {
+				t.Errorf("splitTruncated() = (%q, %q, %v), want (%q, %q, %v)",
+					complete, path, cut, tt.wantComplete, tt.wantPath, tt.wantCut)
+			}
+		})
+	}
+}
+
+func TestSendFiles_StitchesTruncatedOutput(t *testing.T) {
+	p := &scriptedProvider{
+		replies: []string{
+			"===FILE: a.md===\nA\n===END_FILE===\n===FILE: b.md===\nhal",
+			"===FILE: b.md===\nB\n===END_FILE===\n===FILE: c.md===\nC\n===END_FILE===",
+		},
+		errs: []error{fmt.Errorf("%w (max_output_tokens)", ErrTruncated)},
+	}
+	e := NewEngine(p)
+
+	raw, err := e.sendFiles(context.Background(), p, "generate")
+	if err != nil {
+		t.Fatalf("sendFiles: %v", err)
+	}
+	if len(p.messages) != 2 {
+		t.Fatalf("expected 1 continuation, got %d messages", len(p.messages))
+	}
+	if !strings.Contains(p.messages[1], "b.md") {
+		t.Errorf("continuation should name the discarded file:\n%s", p.messages[1])
+	}
+
+	files := parseFileOutput(raw)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path+"="+f.Content)
+	}
+	if got := strings.Join(paths, ","); got != "a.md=A,b.md=B,c.md=C" {
+		t.Errorf("stitched files = %s", got)
+	}
+}
+
+func TestSendFiles_GivesUp(t *testing.T) {
+	cut := "===FILE: a.md===\nhal"
+	p := &scriptedProvider{replies: []string{cut, cut, cut, cut, cut}}
+	e := NewEngine(p)
+
+	if _, err := e.sendFiles(context.Background(), p, "generate"); err == nil {
+		t.Fatal("expected error after repeated truncation")
+	}
+	if len(p.messages) != maxContinuations+1 {
+		t.Errorf("expected %d sends, got %d", maxContinuations+1, len(p.messages))
+	}
+}
@@ -3,12 +3,14 @@ package ai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
+	"github.com/ecoker/launchpad/internal/palette"
 	"github.com/ecoker/launchpad/internal/scaffold"
-	"github.com/ecoker/launchpad/templates"
 )
 
 // FileOutput represents a single file the AI wants to create.
@@ -22,29 +24,160 @@ type Selection struct {
 	ProfileID  string   `json:"profile_id"`
 	AddonIDs   []string `json:"addon_ids,omitempty"`
 	AssetIDs   []string `json:"asset_ids,omitempty"`
+	Agents     []string `json:"agents,omitempty"`
 	Confidence float64  `json:"confidence"`
 	Rationale  string   `json:"rationale"`
+	Tags       []string `json:"tags,omitempty"` // short project traits, e.g. realtime, payments
+
+	// Constraints are hard requirements the user stated, in their words:
+	// "must run on a Raspberry Pi", "no external SaaS".
+	Constraints []string `json:"constraints,omitempty"`
+
+	// Features are the most important features settled while scoping,
+	// best first, for the first-feature brief.
+	Features []string `json:"features,omitempty"`
+
+	// PaletteRoles says what each selected palette is for when there are
+	// two: one styles the product, the other brand and marketing surfaces.
+	PaletteRoles []PaletteRole `json:"palette_roles,omitempty"`
+
+	// Packages is filled from the working tree, never by the model.
+	Packages []PackageScope `json:"-"`
+}
+
+// Palette roles. The primary palette is the product UI's; the secondary
+// one is scoped to brand and marketing surfaces such as a landing page.
+const (
+	PalettePrimary   = "primary"
+	PaletteSecondary = "secondary"
+)
+
+// PaletteRole assigns a role to one selected palette asset.
+type PaletteRole struct {
+	AssetID string `json:"asset_id"`
+	Role    string `json:"role"`
 }
 
-// confidenceThreshold is the minimum self-reported confidence the model must
+// PaletteRole returns the role assigned to the palette assetID, or "".
+func (s Selection) PaletteRole(assetID string) string {
+	for _, r := range s.PaletteRoles {
+		if r.AssetID == assetID {
+			return r.Role
+		}
+	}
+	return ""
+}
+
+// ConfidenceThreshold is the minimum self-reported confidence the model must
 // return for us to proceed with generation. This is a soft heuristic — LLM
 // confidence scores are uncalibrated — but in practice it catches cases where
 // the conversation was too vague to produce a useful selection. Tuned through
-// manual testing; not a statistical guarantee.
-const confidenceThreshold = 0.72
+// manual testing; not a statistical guarantee. `launchpad eval` records
+// confidence against rubric results to check it per model.
+const ConfidenceThreshold = 0.72
 
 // ReadyToken is the phrase the model appends to signal readiness.
 const ReadyToken = "READY_TO_GENERATE"
 
+// DefaultAllowedRoots are the locations generated files may be written to
+// regardless of selection. A trailing slash allows everything beneath a
+// directory; anything else must match a file path exactly.
+var DefaultAllowedRoots = []string{".github/", "AGENTS.md"}
+
 // Engine orchestrates the multi-turn conversation and generation workflow.
 // It delegates all LLM communication to a Provider implementation.
 type Engine struct {
-	provider Provider
+	provider       Provider
+	allowedRoots   []string
+	warn           func(string)
+	concurrency    int
+	targets        []string
+	postProcessors []PostProcessor
+	decisions      *DecisionMap
+	caps           *capCache
+	language       string // code from OutputLanguages; "" means English
+	tone           Tone
+	readiness      []ReadinessCheck
+	turns          int      // Chat calls so far
+	scope          []string // concerns to regenerate; empty means every file
+}
+
+// defaultConcurrency is how many generation calls run at once. Enough to
+// overlap the per-file requests without tripping typical rate limits.
+const defaultConcurrency = 4
+
+// EngineOption configures an Engine.
+type EngineOption func(*Engine)
+
+// WithAllowedRoots adds locations generated files may be written to, on top
+// of DefaultAllowedRoots and the outputs declared by selected assets. Like
+// DefaultAllowedRoots, a trailing slash allows a whole directory.
+func WithAllowedRoots(roots ...string) EngineOption {
+	return func(e *Engine) {
+		e.allowedRoots = append(e.allowedRoots, roots...)
+	}
+}
+
+// WithWarningHandler receives non-fatal problems found during generation,
+// such as files dropped by the output policy.
+func WithWarningHandler(fn func(string)) EngineOption {
+	return func(e *Engine) {
+		if fn != nil {
+			e.warn = fn
+		}
+	}
+}
+
+// WithConcurrency sets how many generation calls may run at once. Values
+// below 1 are treated as 1.
+func WithConcurrency(n int) EngineOption {
+	return func(e *Engine) {
+		e.concurrency = n
+	}
+}
+
+// WithTargets chooses the output formats the generated Copilot layout is
+// rendered into; see Targets for the available IDs. Without it only the
+// Copilot layout is written.
+func WithTargets(ids ...string) EngineOption {
+	return func(e *Engine) {
+		e.targets = append(e.targets, ids...)
+	}
+}
+
+// WithDecisionMap replaces the built-in decision map, typically with one
+// carrying organization overrides.
+func WithDecisionMap(m *DecisionMap) EngineOption {
+	return func(e *Engine) {
+		if m != nil {
+			e.decisions = m
+		}
+	}
 }
 
 // NewEngine creates a new Engine backed by the given Provider.
-func NewEngine(provider Provider) *Engine {
-	return &Engine{provider: provider}
+func NewEngine(provider Provider, opts ...EngineOption) *Engine {
+	e := &Engine{
+		provider:     provider,
+		allowedRoots: append([]string(nil), DefaultAllowedRoots...),
+		warn:         func(string) {},
+		concurrency:  defaultConcurrency,
+		decisions:    DefaultDecisionMap(),
+		caps:         &capCache{},
+		readiness:    DefaultReadiness(),
+	}
+	for _, o := range opts {
+		o(e)
+	}
+	// Generation calls run concurrently; handlers needn't be goroutine-safe.
+	var mu sync.Mutex
+	warn := e.warn
+	e.warn = func(msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		warn(msg)
+	}
+	return e
 }
 
 // Chat sends a user message and returns the assistant's reply.
@@ -54,7 +187,13 @@ func (e *Engine) Chat(ctx context.Context, message string) (string, error) {
 	}
 	// Always send instructions — the Responses API does NOT carry them
 	// across previous_response_id chains.
-	return e.provider.Send(ctx, message, conversationSystemPrompt())
+	reply, err := e.provider.Send(ctx, message, conversationSystemPrompt(e.decisions, e.tone))
+	e.turns++
+	if errors.Is(err, ErrTruncated) {
+		// A clipped chat turn is still readable; the user can ask for more.
+		return reply, nil
+	}
+	return reply, err
 }
 
 // IsReady reports whether the assistant reply contains the readiness token.
@@ -69,22 +208,47 @@ func IsReady(reply string) bool {
 // ExtractDecision silently reads the current thread and returns a structured Selection.
 // This call is never shown to the user.
 func (e *Engine) ExtractDecision(ctx context.Context) (*Selection, error) {
-	extractPrompt := "Based on our conversation, extract the final stack decision.\n\n" +
+	return e.sendSelection(ctx, extractPrompt("Based on our conversation, extract the final stack decision."), "")
+}
+
+// ExtractFromTranscript classifies a conversation held elsewhere. The
+// transcript goes out in a single message with the extraction prompt, so
+// the engine's own thread needs no prior turns.
+func (e *Engine) ExtractFromTranscript(ctx context.Context, transcript string) (*Selection, error) {
+	if strings.TrimSpace(transcript) == "" {
+		return nil, fmt.Errorf("empty transcript")
+	}
+	lead := "Here is a conversation about a software project:\n\n" +
+		"===TRANSCRIPT===\n" + strings.TrimSpace(transcript) + "\n===END_TRANSCRIPT===\n\n" +
+		"Based on this conversation, extract the stack decision it reached, or the best fit for what it describes."
+	return e.sendSelection(ctx, extractPrompt(lead), conversationSystemPrompt(e.decisions, e.tone))
+}
+
+// extractPrompt asks for the Selection JSON, after lead sets up what to
+// extract from.
+func extractPrompt(lead string) string {
+	return lead + "\n\n" +
 		"Return ONLY valid JSON — no markdown, no prose:\n" +
 		"{\n" +
-		"  \"profile_id\": \"<elixir-phoenix|typescript-sveltekit|ruby-rails|typescript-nextjs|typescript-fastify|go-service|dotnet-api|java-spring|python-fastapi|python-django|dart-flutter|rust-axum|laravel>\",\n" +
+		"  \"profile_id\": \"<elixir-phoenix|typescript-sveltekit|ruby-rails|typescript-nextjs|typescript-nuxt|typescript-fastify|go-service|dotnet-api|java-spring|python-fastapi|python-django|dart-flutter|rust-axum|laravel|swift-vapor|android-compose|ios-swiftui|cpp-service|platform-infra|data-dbt|python-ml|godot|bun-hono|deno-fresh|react-native-expo|electron|browser-extension>\",\n" +
 		"  \"addon_ids\": [],\n" +
 		"  \"asset_ids\": [],\n" +
+		"  \"agents\": [],\n" +
 		"  \"confidence\": 0.0,\n" +
-		"  \"rationale\": \"one sentence\"\n" +
+		"  \"rationale\": \"one sentence\",\n" +
+		"  \"tags\": [],\n" +
+		"  \"constraints\": [],\n" +
+		"  \"features\": [],\n" +
+		"  \"palette_roles\": []\n" +
 		"}\n\n" +
-		"Asset IDs available:\n" + catalogIDLines()
-
-	raw, err := e.provider.Send(ctx, extractPrompt, "")
-	if err != nil {
-		return nil, err
-	}
-	return parseSelection(raw)
+		"tags: up to 6 short lowercase traits of the project itself, as discussed (e.g. realtime, multiplayer, payments, mobile, offline).\n\n" +
+		"constraints: hard requirements the user stated, each a short phrase close to their words (e.g. \"must run on a Raspberry Pi\", \"no external SaaS\", \"team only knows Python\"). Preferences are not constraints; leave it empty if none were stated.\n\n" +
+		"features: the up to 3 most important features the user confirmed while scoping, best first, each one short sentence naming who does what (e.g. \"a host opens a room and shares a join code\").\n\n" +
+		"palette_roles: empty unless two palette assets are selected (a brand/product split). Then give each a role:\n" +
+		"[{\"asset_id\": \"asset.palette.x\", \"role\": \"primary\"}, {\"asset_id\": \"asset.palette.y\", \"role\": \"secondary\"}] —\n" +
+		"primary styles the product UI, secondary the brand and marketing surfaces.\n\n" +
+		"Asset IDs available:\n" + catalogIDLines() + "\n\n" +
+		"Agent IDs (only those the user said the team uses): " + strings.Join(agentIDs(), ", ")
 }
 
 // GenerateFiles loads the selected context assets and generates instruction files.
@@ -92,10 +256,10 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 	if sel == nil || sel.ProfileID == "" {
 		return nil, fmt.Errorf("no stack selected")
 	}
-	if sel.Confidence < confidenceThreshold {
+	if sel.Confidence < ConfidenceThreshold {
 		return nil, fmt.Errorf(
 			"confidence %.2f is below minimum %.2f — try describing your project in more detail",
-			sel.Confidence, confidenceThreshold,
+			sel.Confidence, ConfidenceThreshold,
 		)
 	}
 	if issues := ValidateSelectionCompatibility(*sel); len(issues) > 0 {
@@ -106,14 +270,22 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 	if err != nil {
 		return nil, fmt.Errorf("resolving assets: %w", err)
 	}
+	assets = scopeAssets(assets, sel.ProfileID, e.scope)
 
 	var contextBlocks strings.Builder
+	var contrastIssues []palette.Issue
 	for _, asset := range assets {
-		data, readErr := templates.FS.ReadFile(asset.TemplatePath)
+		data, readErr := ReadTemplate(asset.TemplatePath)
 		if readErr != nil {
 			return nil, fmt.Errorf("reading asset %s: %w", asset.ID, readErr)
 		}
 		fmt.Fprintf(&contextBlocks, "===ASSET: %s===\n%s\n===END_ASSET===\n\n", asset.ID, string(data))
+		if strings.HasPrefix(asset.ID, "asset.palette.") {
+			contrastIssues = append(contrastIssues, palette.Check(palette.Tokens(string(data)))...)
+		}
+	}
+	for _, issue := range contrastIssues {
+		e.warn("palette contrast: " + issue.String())
 	}
 
 	summary := make([]string, 0, len(assets))
@@ -132,7 +304,30 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 	hasFonts := false
 	hasFrontendCraft := false
 	hasServerPatterns := false
+	hasObservability := false
+	hasCICD := false
+	hasIaC := false
+	hasAuth := false
+	hasGraphQL := false
+	hasAPIDesign := false
+	hasA11y := false
+	hasPerformance := false
+	hasMultiTenancy := false
+	hasEventSourcing := false
+	hasAIIntegration := false
+	hasRealtime := false
+	hasCompliance := false
 	hasTesting := false
+	hasMobileRelease := false
+	hasCLI := false
+	hasPrivacy := false
+	hasMonorepo := false
+	hasReleases := false
+	hasAPISDK := false
+	hasPlanning := false
+	hasFirstFeature := false
+	hasGuardrails := false
+	var deployAsset *ContextAsset
 	for _, a := range assets {
 		switch {
 		case a.ID == "core.design-system":
@@ -143,10 +338,56 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 			hasFonts = true
 		case a.ID == "addon.frontend-craft":
 			hasFrontendCraft = true
+		case a.ID == "addon.observability":
+			hasObservability = true
+		case a.ID == "addon.ci-cd":
+			hasCICD = true
+		case a.ID == "addon.iac":
+			hasIaC = true
+		case a.ID == "addon.auth":
+			hasAuth = true
+		case a.ID == "addon.graphql":
+			hasGraphQL = true
+		case a.ID == "addon.api-design":
+			hasAPIDesign = true
+		case a.ID == "addon.a11y":
+			hasA11y = true
+		case a.ID == "addon.performance":
+			hasPerformance = true
+		case a.ID == "addon.multi-tenancy":
+			hasMultiTenancy = true
+		case a.ID == "addon.event-sourcing":
+			hasEventSourcing = true
+		case a.ID == "addon.ai-integration":
+			hasAIIntegration = true
+		case a.ID == "addon.realtime":
+			hasRealtime = true
+		case a.ID == "addon.compliance":
+			hasCompliance = true
 		case a.ID == "asset.server.patterns":
 			hasServerPatterns = true
 		case a.ID == "asset.testing.pragmatic":
 			hasTesting = true
+		case a.ID == "asset.mobile.release":
+			hasMobileRelease = true
+		case a.ID == "asset.app.cli":
+			hasCLI = true
+		case a.ID == "asset.privacy.data-protection":
+			hasPrivacy = true
+		case a.ID == "asset.workspace.monorepo":
+			hasMonorepo = true
+		case a.ID == "asset.workflow.releases":
+			hasReleases = true
+		case a.ID == "asset.api.sdk":
+			hasAPISDK = true
+		case a.ID == "asset.workflow.planning":
+			hasPlanning = true
+		case a.ID == "asset.workflow.first-feature":
+			hasFirstFeature = true
+		case a.ID == "core.guardrails":
+			hasGuardrails = true
+		case strings.HasPrefix(a.ID, "asset.deploy."):
+			deployAsset = &a
 		}
 	}
 
@@ -162,11 +403,26 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 		designGuidance.WriteString("The assets below include visual identity guidance. When generating output files:\n")
 		designGuidance.WriteString("- Merge the design-system baseline with any selected palette/font assets into\n")
 		designGuidance.WriteString("  a single cohesive visual language. Don't repeat conflicting defaults.\n")
-		if hasPalette {
+		if primary, secondary := paletteByRole(*sel, assets); secondary != "" {
+			designGuidance.WriteString("- Two palette assets are included, with roles:\n")
+			designGuidance.WriteString("  primary   " + primary + " — the product UI. Its tokens are the defaults.\n")
+			designGuidance.WriteString("  secondary " + secondary + " — brand and marketing surfaces only (landing\n")
+			designGuidance.WriteString("  pages, emails, campaign pages). Emit its tokens under a separate namespace\n")
+			designGuidance.WriteString("  (e.g. `brand-*` / `--brand-*`), say exactly where each palette applies, and\n")
+			designGuidance.WriteString("  never mix tokens from both on one surface.\n")
+		} else if hasPalette {
 			designGuidance.WriteString("- A palette asset is included. Use its specific color tokens as the concrete\n")
 			designGuidance.WriteString("  values for the design-system's color guidance. The palette overrides generic\n")
 			designGuidance.WriteString("  color suggestions in the baseline.\n")
 		}
+		if len(contrastIssues) > 0 {
+			designGuidance.WriteString("- These palette combinations fail WCAG AA contrast. Do not pair them as\n")
+			designGuidance.WriteString("  written: use the suggested value as the token for that role, or limit the\n")
+			designGuidance.WriteString("  color to decoration that carries no meaning, and say so in the file:\n")
+			for _, issue := range contrastIssues {
+				designGuidance.WriteString("  - " + issue.String() + "\n")
+			}
+		}
 		if hasFonts {
 			designGuidance.WriteString("- A font pairing asset is included. Use its specific fonts as the concrete\n")
 			designGuidance.WriteString("  values for the design-system's typography guidance.\n")
@@ -197,12 +453,207 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 		assetGuidance.WriteString("data access, and form/action conventions adapted to the selected framework.\n")
 		assetGuidance.WriteString("The applyTo glob MUST target server-side source files for the framework.\n\n")
 	}
+	if hasObservability {
+		assetGuidance.WriteString("OBSERVABILITY:\n")
+		assetGuidance.WriteString("The observability addon is included. Generate a dedicated observability.instructions.md\n")
+		assetGuidance.WriteString("that names the selected framework's OpenTelemetry SDK and auto-instrumentation\n")
+		assetGuidance.WriteString("packages, its structured JSON logger with trace IDs attached, its metrics setup,\n")
+		assetGuidance.WriteString("and where /healthz and /readyz are wired in. Keep only this ecosystem's row of\n")
+		assetGuidance.WriteString("the library table. The applyTo glob MUST target server-side source files.\n\n")
+	}
+	if hasCICD {
+		assetGuidance.WriteString("CI/CD:\n")
+		assetGuidance.WriteString("The ci-cd addon is included. Generate a dedicated ci-cd.instructions.md with the\n")
+		assetGuidance.WriteString("selected stack's exact lint, test, and build commands, and generate\n")
+		assetGuidance.WriteString(".github/workflows/ci.yml running those stages as jobs on push and pull_request,\n")
+		assetGuidance.WriteString("with the stack's setup action and lockfile-keyed caching. Add a deploy job on main\n")
+		assetGuidance.WriteString("only when a deploy asset is selected, and use that platform's deploy command.\n\n")
+	}
+	if hasCompliance {
+		assetGuidance.WriteString("COMPLIANCE:\n")
+		assetGuidance.WriteString("The compliance addon is included. Generate a dedicated compliance.instructions.md\n")
+		assetGuidance.WriteString("showing where this framework enforces permissions (e.g. a Pundit policy, a FastAPI\n")
+		assetGuidance.WriteString("dependency, a Phoenix plug), the audit log table or library written in the same\n")
+		assetGuidance.WriteString("transaction as the change, and the retention job on its background job system.\n")
+		assetGuidance.WriteString("When a data privacy asset is also selected, leave PII classification and data subject\n")
+		assetGuidance.WriteString("requests to privacy.instructions.md and link to it instead of repeating them.\n\n")
+	}
+	if hasRealtime {
+		assetGuidance.WriteString("REALTIME:\n")
+		assetGuidance.WriteString("The realtime addon is included. Generate a dedicated realtime.instructions.md built\n")
+		assetGuidance.WriteString("on this framework's realtime layer: Phoenix Channels and Presence, Action Cable,\n")
+		assetGuidance.WriteString("Django Channels, SignalR, or for SvelteKit and Next.js a WebSocket or SSE endpoint\n")
+		assetGuidance.WriteString("beside the app with a pub/sub backplane. App clients describe the socket client,\n")
+		assetGuidance.WriteString("reconnection, and connection-state UI instead of the server side. Show the join\n")
+		assetGuidance.WriteString("authorization and channel test helpers. The applyTo glob MUST target the\n")
+		assetGuidance.WriteString("framework's channel, socket, and client store files.\n\n")
+	}
+	if hasAIIntegration {
+		assetGuidance.WriteString("LLM INTEGRATION:\n")
+		assetGuidance.WriteString("The ai-integration addon is included. Generate a dedicated ai-integration.instructions.md\n")
+		assetGuidance.WriteString("that names this ecosystem's SDK or client for the gateway module, how the framework\n")
+		assetGuidance.WriteString("validates structured output (e.g. Zod, Pydantic, Ecto embedded schemas), and how it\n")
+		assetGuidance.WriteString("streams a response (e.g. a ReadableStream route handler, FastAPI StreamingResponse,\n")
+		assetGuidance.WriteString("LiveView stream). Skip the streaming UX section for stacks with no UI. The applyTo glob\n")
+		assetGuidance.WriteString("MUST target the framework's source files.\n\n")
+	}
+	if hasEventSourcing {
+		assetGuidance.WriteString("EVENT SOURCING & CQRS:\n")
+		assetGuidance.WriteString("The event-sourcing addon is included. Generate a dedicated event-sourcing.instructions.md\n")
+		assetGuidance.WriteString("using this ecosystem's event store or library where one is established (e.g.\n")
+		assetGuidance.WriteString("Commanded for Elixir, Marten for .NET, Axon for Spring), otherwise an events table in\n")
+		assetGuidance.WriteString("the project's database. Show an aggregate's decide and apply functions, a projection,\n")
+		assetGuidance.WriteString("and an upcaster in the framework's idioms. If data-intensive is also selected, go\n")
+		assetGuidance.WriteString("deeper than its notes rather than repeating them. The applyTo glob MUST target the\n")
+		assetGuidance.WriteString("framework's domain, projection, and migration files.\n\n")
+	}
+	if hasMultiTenancy {
+		assetGuidance.WriteString("MULTI-TENANCY:\n")
+		assetGuidance.WriteString("The multi-tenancy addon is included. Generate a dedicated multi-tenancy.instructions.md\n")
+		assetGuidance.WriteString("that recommends one isolation strategy for this project and shows it in this\n")
+		assetGuidance.WriteString("framework: the middleware that resolves the tenant, how the ORM or query layer\n")
+		assetGuidance.WriteString("applies the tenant scope by default (e.g. an Ecto prepare_query, a Rails\n")
+		assetGuidance.WriteString("default_scope, an EF Core global query filter), the row-level security policy, and\n")
+		assetGuidance.WriteString("a two-tenant test in its test framework. The applyTo glob MUST target the\n")
+		assetGuidance.WriteString("framework's data access, middleware, and migration files.\n\n")
+	}
+	if hasPerformance {
+		assetGuidance.WriteString("PERFORMANCE BUDGETS:\n")
+		assetGuidance.WriteString("The performance addon is included. Generate a dedicated performance.instructions.md\n")
+		assetGuidance.WriteString("that keeps only the budget rows this stack can break: no bundle or LCP rows for a\n")
+		assetGuidance.WriteString("headless API, no query rows for a client without a database. Name this framework's\n")
+		assetGuidance.WriteString("tools: its bundle analyzer and route-level code-splitting, its ORM's eager loading\n")
+		assetGuidance.WriteString("and query logging, and its cache API. Show the CI step that fails on a broken budget.\n")
+		assetGuidance.WriteString("The applyTo glob MUST target the framework's source files.\n\n")
+	}
+	if hasA11y {
+		assetGuidance.WriteString("ACCESSIBILITY:\n")
+		assetGuidance.WriteString("The a11y addon is included. Generate a dedicated a11y.instructions.md for the\n")
+		assetGuidance.WriteString("selected framework: its accessible component primitives, how it handles focus on\n")
+		assetGuidance.WriteString("navigation, and the axe or platform accessibility test that runs in its test suite.\n")
+		assetGuidance.WriteString("Web stacks drop the native apps table; native stacks keep only their platform's\n")
+		assetGuidance.WriteString("row. It goes deeper than the frontend-craft accessibility notes; don't repeat them.\n")
+		assetGuidance.WriteString("The applyTo glob MUST target the framework's template, component, and style files.\n\n")
+	}
+	if hasAPIDesign {
+		assetGuidance.WriteString("REST API DESIGN:\n")
+		assetGuidance.WriteString("The api-design addon is included. Generate a dedicated api-design.instructions.md\n")
+		assetGuidance.WriteString("showing how this framework declares routes, serializes the error envelope, and\n")
+		assetGuidance.WriteString("paginates (e.g. a Phoenix FallbackController, a FastAPI exception handler), and\n")
+		assetGuidance.WriteString("whether its OpenAPI spec is generated from code or maintained by hand. Keep the\n")
+		assetGuidance.WriteString("naming, status code, and versioning rules. The applyTo glob MUST target the\n")
+		assetGuidance.WriteString("framework's route and handler files and the spec file.\n\n")
+	}
+	if hasGraphQL {
+		assetGuidance.WriteString("GRAPHQL API:\n")
+		assetGuidance.WriteString("The graphql addon is included. Generate a dedicated graphql.instructions.md for the\n")
+		assetGuidance.WriteString("selected framework's GraphQL server from the library table (e.g. Absinthe for Phoenix,\n")
+		assetGuidance.WriteString("gqlgen for Go) — keep only that row. Show where the schema, resolvers, and loaders\n")
+		assetGuidance.WriteString("live in this framework's layout and how its batching API removes N+1 queries. Keep\n")
+		assetGuidance.WriteString("the schema design and limits rules. The applyTo glob MUST target the schema files\n")
+		assetGuidance.WriteString("and server-side source files.\n\n")
+	}
+	if hasAuth {
+		assetGuidance.WriteString("AUTHENTICATION & AUTHORIZATION:\n")
+		assetGuidance.WriteString("The auth addon is included. Generate a dedicated auth.instructions.md built on the\n")
+		assetGuidance.WriteString("selected framework's idiomatic auth library from the table (e.g. phx.gen.auth for\n")
+		assetGuidance.WriteString("Phoenix, Devise or the Rails generator for Rails) — keep only that row. Say whether\n")
+		assetGuidance.WriteString("this project uses sessions or tokens and why, show where role and policy checks live\n")
+		assetGuidance.WriteString("in this framework, and keep the password and OAuth rules. Client stacks cover the\n")
+		assetGuidance.WriteString("OAuth PKCE flow and secure token storage only.\n\n")
+	}
+	if hasIaC {
+		assetGuidance.WriteString("INFRASTRUCTURE AS CODE:\n")
+		assetGuidance.WriteString("The iac addon is included. Generate a dedicated iac.instructions.md for ONE tool:\n")
+		assetGuidance.WriteString("Pulumi in the project's language for TypeScript, Python, Go, and .NET stacks,\n")
+		assetGuidance.WriteString("Terraform otherwise. Name the resources THIS app needs (its database, cache,\n")
+		assetGuidance.WriteString("queues, storage) and, if a deploy asset is selected, how they connect to that\n")
+		assetGuidance.WriteString("platform. Keep the environment, change, and drift rules. applyTo MUST target infra/**.\n\n")
+	}
 	if hasTesting {
 		assetGuidance.WriteString("TESTING:\n")
 		assetGuidance.WriteString("A testing asset is included. Generate a dedicated testing.instructions.md\n")
 		assetGuidance.WriteString("with ONLY the framework-specific testing guidance (runner, file conventions,\n")
 		assetGuidance.WriteString("setup/teardown, assertion style). Drop guidance for other frameworks.\n\n")
 	}
+	if hasMobileRelease {
+		assetGuidance.WriteString("MOBILE RELEASE:\n")
+		assetGuidance.WriteString("A mobile release asset is included. Generate a dedicated release.instructions.md\n")
+		assetGuidance.WriteString("covering versioning, signing, store metadata, and crash reporting using the\n")
+		assetGuidance.WriteString("selected framework's build tooling (e.g. pubspec.yaml versions and flutter build\n")
+		assetGuidance.WriteString("flags for Flutter). The applyTo glob MUST target source and build config files.\n\n")
+	}
+	if hasCLI {
+		assetGuidance.WriteString("CLI APPLICATION:\n")
+		assetGuidance.WriteString("A CLI conventions asset is included — this codebase is a command-line tool.\n")
+		assetGuidance.WriteString("Generate a dedicated cli.instructions.md covering command structure, flag/config\n")
+		assetGuidance.WriteString("layering, exit codes, output formatting, and releases using the selected\n")
+		assetGuidance.WriteString("language's idiomatic CLI libraries (e.g. cobra for Go, clap for Rust).\n\n")
+	}
+	if hasPrivacy {
+		assetGuidance.WriteString("DATA PRIVACY:\n")
+		assetGuidance.WriteString("A data privacy asset is included. Generate a dedicated privacy.instructions.md\n")
+		assetGuidance.WriteString("covering PII classification, retention jobs, data subject access/deletion flows,\n")
+		assetGuidance.WriteString("log redaction, and audit logging, expressed with the selected framework's models,\n")
+		assetGuidance.WriteString("background jobs, and logging libraries.\n\n")
+	}
+	if hasMonorepo {
+		assetGuidance.WriteString("MONOREPO:\n")
+		assetGuidance.WriteString("A monorepo workspace asset is included. Generate a dedicated monorepo.instructions.md\n")
+		assetGuidance.WriteString("with ONLY the workspace tooling and task runner for the selected ecosystem\n")
+		assetGuidance.WriteString("(e.g. mix umbrella for Phoenix, pnpm + Turborepo for TypeScript, go.work for Go).\n")
+		assetGuidance.WriteString("Keep the apps/packages boundary rules. Drop rows for other ecosystems.\n\n")
+	}
+	if hasReleases {
+		assetGuidance.WriteString("RELEASE AUTOMATION:\n")
+		assetGuidance.WriteString("A release automation asset is included. Generate a dedicated releases.instructions.md\n")
+		assetGuidance.WriteString("with semantic versioning and conventional commit rules, plus the release tool\n")
+		assetGuidance.WriteString("configuration for the selected ecosystem as real files: either\n")
+		assetGuidance.WriteString(".changeset/config.json (TypeScript packages), or release-please-config.json,\n")
+		assetGuidance.WriteString(".release-please-manifest.json, and .github/workflows/release-please.yml with\n")
+		assetGuidance.WriteString("the matching release-type. Emit only ONE tool's configuration.\n\n")
+	}
+	if hasAPISDK {
+		assetGuidance.WriteString("API SPEC AND CLIENTS:\n")
+		assetGuidance.WriteString("An API SDK asset is included. Generate a dedicated api-clients.instructions.md\n")
+		assetGuidance.WriteString("naming the selected framework's OpenAPI tooling (code-first where the framework\n")
+		assetGuidance.WriteString("supports it), the spec path and regeneration command, and the client generators.\n")
+		assetGuidance.WriteString("It MUST instruct the agent to regenerate the spec and clients whenever routes change.\n\n")
+	}
+	if hasGuardrails {
+		assetGuidance.WriteString("DESTRUCTIVE OPERATION GUARDRAILS:\n")
+		assetGuidance.WriteString("A guardrails asset is included. AGENTS.md MUST contain a \"## Safety\" section that\n")
+		assetGuidance.WriteString("lists the always-ask-first categories as imperative rules, with the concrete destructive\n")
+		assetGuidance.WriteString("commands for THIS stack (its migration/reset tasks, its deploy and publish commands).\n")
+		assetGuidance.WriteString("Also generate a dedicated guardrails.instructions.md with applyTo: \"**\".\n")
+		assetGuidance.WriteString("Do not soften the rules into suggestions.\n\n")
+	}
+	if hasPlanning {
+		assetGuidance.WriteString("TASK DECOMPOSITION:\n")
+		assetGuidance.WriteString("A planning asset is included. Generate .github/prompts/plan.prompt.md with the same\n")
+		assetGuidance.WriteString("frontmatter rules as start.prompt.md. Its body MUST tell the agent to read the product\n")
+		assetGuidance.WriteString("brief (PRODUCT.md if present, otherwise the project description), write PLAN.md as\n")
+		assetGuidance.WriteString("ordered milestones of PR-sized tasks each ending in a testable checkpoint, and stop\n")
+		assetGuidance.WriteString("for review before implementing. Fill milestone 1 with this project's scaffold step.\n")
+		assetGuidance.WriteString("start.prompt.md should mention plan.prompt.md as the entry point for larger work.\n\n")
+	}
+	if hasFirstFeature {
+		assetGuidance.WriteString(firstFeatureGuidance(sel.Features))
+	}
+	assetGuidance.WriteString(importedGuidance(assets))
+	if deployAsset != nil {
+		deployConfig := map[string]string{
+			"asset.deploy.fly":     "fly.toml",
+			"asset.deploy.render":  "render.yaml",
+			"asset.deploy.railway": "railway.json",
+		}[deployAsset.ID]
+		assetGuidance.WriteString("DEPLOYMENT:\n")
+		fmt.Fprintf(&assetGuidance, "A %s asset is included. Emit %s at the repository root,\n", deployAsset.Label, deployConfig)
+		assetGuidance.WriteString("filled in for the selected framework (runtime, build/start commands, port, health\n")
+		assetGuidance.WriteString("check path, pre-deploy migrations) using the per-stack notes in the asset.\n")
+		assetGuidance.WriteString("Also add a \"Deployment\" section at the end of start.prompt.md with the first-deploy\n")
+		assetGuidance.WriteString("steps. Never put secret values in the config file.\n\n")
+	}
 
 	// Resolve the actual scaffold command with project name substituted.
 	scaffoldResolved := strings.ReplaceAll(scaffoldInfo, "{{name}}", projectName)
@@ -215,6 +666,8 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 		profileFileGlob = "**/*.{ex,exs,heex,leex}"
 	case "typescript-sveltekit", "typescript-nextjs", "typescript-fastify":
 		profileFileGlob = "**/*.{ts,tsx,svelte,js,jsx}"
+	case "typescript-nuxt":
+		profileFileGlob = "**/*.{ts,vue,js}"
 	case "ruby-rails":
 		profileFileGlob = "**/*.{rb,erb,haml}"
 	case "go-service":
@@ -231,6 +684,30 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 		profileFileGlob = "**/*.dart"
 	case "laravel":
 		profileFileGlob = "**/*.{php,blade.php}"
+	case "swift-vapor", "ios-swiftui":
+		profileFileGlob = "**/*.swift"
+	case "android-compose":
+		profileFileGlob = "**/*.{kt,kts}"
+	case "cpp-service":
+		profileFileGlob = "**/*.{cpp,cc,h,hpp,cmake}"
+	case "platform-infra":
+		profileFileGlob = "**/*.{tf,tfvars,hcl,yaml,yml}"
+	case "data-dbt":
+		profileFileGlob = "**/*.{sql,py,yml,yaml}"
+	case "python-ml":
+		profileFileGlob = "**/*.{py,ipynb}"
+	case "godot":
+		profileFileGlob = "**/*.{gd,cs,tscn,tres,gdshader}"
+	case "bun-hono":
+		profileFileGlob = "**/*.ts"
+	case "deno-fresh":
+		profileFileGlob = "**/*.{ts,tsx}"
+	case "react-native-expo":
+		profileFileGlob = "**/*.{ts,tsx,js,jsx}"
+	case "electron":
+		profileFileGlob = "**/*.{ts,tsx,js,html}"
+	case "browser-extension":
+		profileFileGlob = "**/*.{ts,tsx,html,css}"
 	}
 
 	var uiGuidance string
@@ -241,7 +718,7 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 			"A brief reference is sufficient — detailed tokens belong in design-system.instructions.md.\n\n"
 	}
 
-	prompt := fmt.Sprintf(
+	shared := fmt.Sprintf(
 		"Generate AI instruction files for the project %q.\n\n"+
 			"Selected: profile=%s | addons=%s | assets=%s\n\n"+
 			"IMPORTANT — SCAFFOLD COMMAND:\n"+
@@ -258,34 +735,17 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 			"%s"+
 			"%s"+
 			"%s"+
+			"%s"+
+			"%s"+
+			"%s"+
+			"%s"+
+			"%s"+
 			"ADAPTATION RULE:\n"+
 			"All generated instruction files MUST use the selected framework's idioms.\n"+
 			"Code examples, component patterns, styling approaches, and file globs must\n"+
 			"match the framework. Do NOT emit patterns from a different ecosystem.\n\n"+
 			"Use ONLY the asset content below as your source. Do not invent conventions.\n\n"+
-			"%s\n"+
-			"Output ONLY file blocks — no prose before or after:\n"+
-			"===FILE: relative/path===\n(content)\n===END_FILE===\n\n"+
-			"Required:\n"+
-			"1. .github/copilot-instructions.md — always-on standards from core + profile assets\n"+
-			"2. .github/instructions/<profile>.instructions.md — framework-specific conventions from the\n"+
-			"   profile asset. YAML frontmatter with applyTo: %q to scope to framework source files.\n"+
-			"   This MUST be a SEPARATE file from copilot-instructions.md.\n"+
-			"3. .github/instructions/*.instructions.md — one per additional concern (architecture,\n"+
-			"   design-system, frontend-craft, testing, server-patterns, etc.) with YAML frontmatter applyTo glob\n"+
-			"4. AGENTS.md — multi-agent ground rules\n"+
-			"5. .github/prompts/start.prompt.md — YAML frontmatter MUST be exactly:\n"+
-			"   ---\n"+
-			"   description: \"<one-sentence description>\"\n"+
-			"   mode: agent\n"+
-			"   tools: [\"terminal\", \"editFiles\", \"codebase\"]\n"+
-			"   ---\n"+
-			"   Do NOT invent tool names. The only valid tools are: terminal, editFiles,\n"+
-			"   codebase, fetch. Use exactly these identifiers.\n"+
-			"   Body MUST:\n"+
-			"   a) Run the framework scaffold command first: %s\n"+
-			"   b) Then proceed with application-specific implementation\n"+
-			"   c) Never manually create files the scaffold already provides\n",
+			"%s",
 		projectName,
 		sel.ProfileID,
 		strings.Join(sel.AddonIDs, ", "),
@@ -294,22 +754,48 @@ func (e *Engine) GenerateFiles(ctx context.Context, projectName string, sel *Sel
 		projectName,
 		projectName,
 		uiGuidance,
+		tagGuidance(sel.Tags),
+		constraintGuidance(sel.Constraints),
 		designGuidance.String(),
 		assetGuidance.String(),
+		agentGuidance(sel.Agents),
+		packageGuidance(sel.Packages),
+		languageGuidance(e.language),
 		contextBlocks.String(),
-		profileFileGlob,
-		scaffoldResolved,
 	)
 
-	raw, err := e.provider.Send(ctx, prompt, "")
+	specs := scopeSpecs(coreFileSpecs(sel.ProfileID, profileFileGlob, scaffoldResolved, designGuidance.Len() > 0), e.scope)
+	files := scopeFiles(e.generateEach(ctx, shared, specs), e.scope)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("model returned no file blocks")
+	}
+	files, err = sanitizeFiles(files)
 	if err != nil {
 		return nil, err
 	}
-	files := parseFileOutput(raw)
-	if len(files) == 0 {
-		return nil, fmt.Errorf("model returned no file blocks")
+
+	roots := append([]string(nil), e.allowedRoots...)
+	for _, a := range assets {
+		roots = append(roots, a.Outputs...)
 	}
-	return files, nil
+	kept, err := e.keepAllowed(files, roots)
+	if err != nil {
+		return nil, err
+	}
+	kept, removed := normalizePromptTools(kept)
+	for _, w := range removed {
+		e.warn(w)
+	}
+	// Language first, so a rewrite that breaks frontmatter is still repaired.
+	kept, err = e.correctLanguage(ctx, kept)
+	if err != nil {
+		return nil, err
+	}
+	repaired, err := e.repairFiles(ctx, kept)
+	if err != nil {
+		return nil, err
+	}
+	return e.finish(projectName, repaired, sel, roots)
 }
 
 // ParseSelection parses raw LLM JSON output into a normalized Selection.
@@ -318,7 +804,9 @@ func ParseSelection(raw string) (*Selection, error) {
 	return parseSelection(raw)
 }
 
-func parseSelection(raw string) (*Selection, error) {
+// jsonObject strips code fences and surrounding prose from a model reply
+// that should hold a single JSON object.
+func jsonObject(raw string) string {
 	clean := strings.TrimSpace(raw)
 	clean = strings.TrimPrefix(clean, "```json")
 	clean = strings.TrimPrefix(clean, "```")
@@ -329,8 +817,12 @@ func parseSelection(raw string) (*Selection, error) {
 			clean = clean[i : j+1]
 		}
 	}
+	return clean
+}
+
+func parseSelection(raw string) (*Selection, error) {
 	var sel Selection
-	if err := json.Unmarshal([]byte(clean), &sel); err != nil {
+	if err := json.Unmarshal([]byte(jsonObject(raw)), &sel); err != nil {
 		return nil, fmt.Errorf("parse selection: %w\nraw output: %s", err, raw)
 	}
 	sel.ProfileID = strings.TrimPrefix(strings.TrimSpace(sel.ProfileID), "profile.")
@@ -359,9 +851,56 @@ func parseSelection(raw string) (*Selection, error) {
 	}
 	sel.AssetIDs = normalizedAssets
 
+	normalizedAgents := make([]string, 0, len(sel.Agents))
+	seenAgents := make(map[string]bool)
+	for _, agentID := range sel.Agents {
+		id := strings.ToLower(strings.TrimSpace(agentID))
+		if id == "" || seenAgents[id] || FindAgent(id) == nil {
+			continue
+		}
+		seenAgents[id] = true
+		normalizedAgents = append(normalizedAgents, id)
+	}
+	sel.Agents = normalizedAgents
+	sel.Tags = MergeTags(nil, sel.Tags)
+	sel.Constraints = MergeConstraints(nil, sel.Constraints)
+	sel.Features = normalizeFeatures(sel.Features)
+
+	// Roles only mean something for palettes that made it into the
+	// selection; an invalid role is left for compatibility checks to name.
+	normalizedRoles := make([]PaletteRole, 0, len(sel.PaletteRoles))
+	seenRoles := make(map[string]bool)
+	for _, r := range sel.PaletteRoles {
+		r.AssetID = strings.TrimSpace(r.AssetID)
+		r.Role = strings.ToLower(strings.TrimSpace(r.Role))
+		if !seenAssets[r.AssetID] || seenRoles[r.AssetID] {
+			continue
+		}
+		seenRoles[r.AssetID] = true
+		normalizedRoles = append(normalizedRoles, r)
+	}
+	sel.PaletteRoles = normalizedRoles
+
 	return &sel, nil
 }
 
+// paletteByRole returns the primary and secondary palette asset IDs among
+// assets. secondary is "" unless sel assigns one.
+func paletteByRole(sel Selection, assets []ContextAsset) (primary, secondary string) {
+	for _, a := range assets {
+		if !strings.HasPrefix(a.ID, "asset.palette.") {
+			continue
+		}
+		switch sel.PaletteRole(a.ID) {
+		case PaletteSecondary:
+			secondary = a.ID
+		default:
+			primary = a.ID
+		}
+	}
+	return primary, secondary
+}
+
 // ParseFileOutput parses raw LLM output containing ===FILE: blocks.
 // Exported for testing.
 func ParseFileOutput(raw string) []FileOutput {
@@ -400,17 +939,17 @@ func catalogIDLines() string {
 	return strings.Join(catalogSummaryLines(), "\n")
 }
 
-func conversationSystemPrompt() string {
+func conversationSystemPrompt(decisions *DecisionMap, tone Tone) string {
 	var sb strings.Builder
 
 	// CONSTRAINTS FIRST — these override everything
 	sb.WriteString("CONSTRAINTS — violating any of these is a failure:\n")
 	sb.WriteString("1. NEVER write code, code blocks, folder structures, data models, or architecture.\n")
 	sb.WriteString("2. NEVER use markdown headers (###) in replies.\n")
-	sb.WriteString("3. ONLY recommend stacks from the catalog below. Express, Socket.IO, and React Native do not exist in the catalog.\n")
+	sb.WriteString("3. ONLY recommend stacks from the catalog below. Express and Socket.IO do not exist in the catalog.\n")
 	sb.WriteString("4. NEVER skip Phase 1. Your first reply MUST be scope questions, not a recommendation.\n")
 	sb.WriteString("5. ONE phase per reply. Never combine phases.\n")
-	sb.WriteString("6. Maximum 6 sentences per reply.\n\n")
+	fmt.Fprintf(&sb, "6. Maximum %d sentences per reply.\n\n", tone.maxSentences())
 
 	sb.WriteString("WRONG OUTPUT (this is what failure looks like — never do this):\n")
 	sb.WriteString("User: 'I want a real-time voting app'\n")
@@ -418,6 +957,7 @@ func conversationSystemPrompt() string {
 	sb.WriteString("This is wrong because it skips Phase 1, uses headers, writes code, and recommends stacks not in the catalog.\n\n")
 
 	sb.WriteString("You are Launchpad, a stack advisor. You follow three phases in strict order.\n\n")
+	sb.WriteString(tone.promptSection())
 
 	// PHASE 1
 	sb.WriteString("PHASE 1 — SCOPE (1-3 rounds, start here ALWAYS):\n")
@@ -427,31 +967,44 @@ func conversationSystemPrompt() string {
 
 	// PHASE 2
 	sb.WriteString("PHASE 2 — OPTIONS (exactly 1 turn):\n")
-	sb.WriteString("Present 2-3 stack options from the catalog. For each: name, one sentence why it fits, and the scaffold command. Mark your top pick with ★.\n")
-	sb.WriteString("After presenting stacks, briefly mention relevant add-ons and design assets.\n")
+	sb.WriteString("Present 2-3 stack options from the catalog. For each: name, its catalog ID in backticks (e.g. `elixir-phoenix`), and one sentence why it fits. Mark your top pick with ★.\n")
+	sb.WriteString("Hard constraints the user stated (hardware, hosting, languages the team knows) rule options out; never present an option that breaks one.\n")
+	sb.WriteString("Do NOT write scaffold commands — Launchpad shows the exact command for each ID from its catalog.\n")
+	sb.WriteString("After presenting stacks, briefly mention relevant add-ons and design assets, also by ID in backticks.\n")
 	sb.WriteString("Note: for any stack with a UI surface, frontend-craft visual guidance and default palette/font assets are included automatically — no need for the user to opt in. You can mention this as a bonus.\n")
 	sb.WriteString("For data-heavy projects, suggest the data-intensive add-on.\n")
-	sb.WriteString("Ask which stack (and optionally which add-ons/assets) they want.\n\n")
+	sb.WriteString("For live features (chat, presence, collaborative editing, dashboards that update themselves), suggest the realtime add-on.\n")
+	sb.WriteString("When the product itself calls an LLM (chat, summaries, agents, extraction), suggest the ai-integration add-on.\n")
+	sb.WriteString("When the history itself matters (ledgers, audit trails, workflows replayed as of a date), suggest the event-sourcing add-on.\n")
+	sb.WriteString("For public-facing UIs, or when the user mentions accessibility, compliance, or government or education customers, suggest the a11y add-on.\n")
+	sb.WriteString("For services headed to production, suggest the observability add-on.\n")
+	sb.WriteString("When the user mentions SOC 2, GDPR, HIPAA, audits, enterprise customers, or a regulated industry, suggest the compliance add-on.\n")
+	sb.WriteString("For SaaS products where several customers, organizations, or workspaces share one deployment, suggest the multi-tenancy add-on.\n")
+	sb.WriteString("When the user mentions speed, page load, slow queries, scale, or mobile users on weak networks, suggest the performance add-on.\n")
+	sb.WriteString("For projects a team will ship continuously, suggest the ci-cd add-on.\n")
+	sb.WriteString("For services that provision their own cloud resources (databases, queues, buckets), suggest the iac add-on.\n")
+	sb.WriteString("For products with user accounts, logins, or roles, suggest the auth add-on.\n")
+	sb.WriteString("For services that expose a public or partner-facing HTTP API, suggest the api-design add-on.\n")
+	sb.WriteString("When the user wants a GraphQL API, or several clients that each need different slices of the data, suggest the graphql add-on.\n")
+	sb.WriteString("For mobile apps headed to the App Store or Google Play, suggest the asset.mobile.release asset.\n")
+	sb.WriteString("For command-line tools (typically go-service or rust-axum), suggest the asset.app.cli asset.\n")
+	sb.WriteString("For products that store personal or user data, suggest the asset.privacy.data-protection asset.\n")
+	sb.WriteString("For repositories that will hold several apps or shared packages, suggest the asset.workspace.monorepo asset.\n")
+	sb.WriteString("For libraries or products with versioned releases, suggest the asset.workflow.releases asset.\n")
+	sb.WriteString("For API services consumed by other apps or teams, suggest the asset.api.sdk asset.\n")
+	sb.WriteString("For larger projects with many features or milestones, suggest the asset.workflow.planning asset.\n")
+	sb.WriteString("When the user wants to start building right after setup, suggest the asset.workflow.first-feature asset.\n")
+	sb.WriteString("If agents will run commands unattended or the project touches production data, suggest the core.guardrails asset.\n")
+	sb.WriteString("If the user names Fly.io, Render, or Railway as their host, include the matching asset.deploy.* asset (only one).\n")
+	sb.WriteString("Ask which stack (and optionally which add-ons/assets) they want, and which AI coding agents the team uses (e.g. Copilot agent mode, Claude Code, aider, a CI bot).\n\n")
 
 	// PHASE 3
 	sb.WriteString("PHASE 3 — COMMIT (exactly 1 turn):\n")
 	sb.WriteString("Confirm their choice in one sentence. Emit READY_TO_GENERATE on its own line.\n\n")
 
-	// DECISION MAP — derived from profile metadata
-	sb.WriteString("DECISION MAP (★ = your top pick for that use case):\n")
-	sb.WriteString("real-time/live/presence/chat/voting/collaborative -> ★ elixir-phoenix | typescript-sveltekit\n")
-	sb.WriteString("full-stack JS web/SSR/content -> ★ typescript-sveltekit | typescript-nextjs\n")
-	sb.WriteString("CRUD/MVP/admin/content platform -> ★ ruby-rails | python-django\n")
-	sb.WriteString("React required/Vercel -> typescript-nextjs\n")
-	sb.WriteString("Node.js API/microservice -> typescript-fastify\n")
-	sb.WriteString("high-perf API/CLI/infra -> ★ go-service | rust-axum\n")
-	sb.WriteString("enterprise API/C# -> dotnet-api\n")
-	sb.WriteString("enterprise API/Java/JVM -> java-spring\n")
-	sb.WriteString("Python API/ML/data -> python-fastapi\n")
-	sb.WriteString("Python full-stack/admin/CMS -> python-django\n")
-	sb.WriteString("native mobile -> dart-flutter\n")
-	sb.WriteString("perf-critical systems -> ★ rust-axum | go-service\n")
-	sb.WriteString("PHP -> laravel\n\n")
+	// DECISION MAP — derived from profile metadata and org overrides
+	sb.WriteString(decisions.promptSection())
+	sb.WriteByte('\n')
 
 	// LAYER TAXONOMY — helps the model understand architectural roles
 	sb.WriteString("LAYER TAXONOMY (how stacks map to architectural roles):\n")
@@ -1,6 +1,8 @@
 package ai
 
 import (
+	"context"
+	"strings"
 	"testing"
 )
 
@@ -83,6 +85,44 @@ func TestParseSelection_FiltersProfileAndAddonFromAssets(t *testing.T) {
 	}
 }
 
+func TestParseSelection_NormalizesAgents(t *testing.T) {
+	input := `{"profile_id":"go-service","agents":["Copilot","copilot","made-up-bot","ci-bot"],"confidence":0.9,"rationale":"test"}`
+	sel, err := ParseSelection(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sel.Agents) != 2 || sel.Agents[0] != "copilot" || sel.Agents[1] != "ci-bot" {
+		t.Errorf("agents = %v, want [copilot ci-bot]", sel.Agents)
+	}
+}
+
+func TestParseSelection_NormalizesPaletteRoles(t *testing.T) {
+	input := `{"profile_id":"typescript-sveltekit","asset_ids":["asset.palette.heroui-blue","asset.palette.obsidian-indigo"],` +
+		`"palette_roles":[{"asset_id":" asset.palette.obsidian-indigo ","role":"Primary"},` +
+		`{"asset_id":"asset.palette.obsidian-indigo","role":"secondary"},` +
+		`{"asset_id":"asset.palette.unknown","role":"secondary"},` +
+		`{"asset_id":"asset.palette.heroui-blue","role":"secondary"}],"confidence":0.9,"rationale":"test"}`
+	sel, err := ParseSelection(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []PaletteRole{
+		{AssetID: "asset.palette.obsidian-indigo", Role: PalettePrimary},
+		{AssetID: "asset.palette.heroui-blue", Role: PaletteSecondary},
+	}
+	if len(sel.PaletteRoles) != len(want) {
+		t.Fatalf("palette roles = %v, want %v", sel.PaletteRoles, want)
+	}
+	for i := range want {
+		if sel.PaletteRoles[i] != want[i] {
+			t.Errorf("palette role %d = %v, want %v", i, sel.PaletteRoles[i], want[i])
+		}
+	}
+	if issues := ValidateSelectionCompatibility(*sel); len(issues) > 0 {
+		t.Errorf("normalized selection has issues: %v", issues)
+	}
+}
+
 func TestParseFileOutput(t *testing.T) {
 	input := "===FILE: .github/copilot-instructions.md===\n# Project Standards\n\nSome content here.\n===END_FILE===\n\n===FILE: AGENTS.md===\n# Agent Rules\n\nMore content.\n===END_FILE===\n"
 	files := ParseFileOutput(input)
@@ -120,3 +160,21 @@ func TestIsReady(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractFromTranscript(t *testing.T) {
+	p := &scriptedProvider{replies: []string{`{"profile_id":"elixir-phoenix","confidence":0.9,"rationale":"live"}`}}
+	sel, err := NewEngine(p).ExtractFromTranscript(context.Background(), "user: a live voting app\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sel.ProfileID != "elixir-phoenix" {
+		t.Errorf("ProfileID = %q", sel.ProfileID)
+	}
+	if len(p.messages) != 1 || !strings.Contains(p.messages[0], "a live voting app") {
+		t.Errorf("messages = %q, want the transcript in a single message", p.messages)
+	}
+
+	if _, err := NewEngine(&scriptedProvider{}).ExtractFromTranscript(context.Background(), "  "); err == nil {
+		t.Error("empty transcript should fail")
+	}
+}
@@ -0,0 +1,55 @@
+package ai
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/scaffold"
+)
+
+// CatalogFact is an accurate, registry-sourced line about something the
+// model mentioned, shown next to its reply.
+type CatalogFact struct {
+	ID       string
+	Summary  string
+	Scaffold string // resolved scaffold command; profiles only
+}
+
+// catalogIDPattern matches catalog IDs the way the model is asked to write
+// them: in backticks. Bare words are too ambiguous ("laravel" in prose).
+var catalogIDPattern = regexp.MustCompile("`([a-z0-9][a-z0-9.-]*)`")
+
+// OptionFacts returns registry facts for the stacks and assets named in an
+// options reply, so scaffold commands and summaries come from the catalog
+// rather than the model's memory. Replies naming fewer than two stacks are
+// not an options message and yield nil.
+func OptionFacts(reply, projectName string) []CatalogFact {
+	byID := catalogMap()
+	var profiles, others []CatalogFact
+	seen := make(map[string]bool)
+	for _, m := range catalogIDPattern.FindAllStringSubmatch(reply, -1) {
+		id := strings.TrimPrefix(m[1], "profile.")
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if p := scaffold.FindProfile(id); p != nil {
+			cmd := strings.ReplaceAll(p.ScaffoldCmd, "{{name}}", projectName)
+			cmd = strings.ReplaceAll(cmd, "{{module}}", projectName)
+			profiles = append(profiles, CatalogFact{ID: p.ID, Summary: p.Summary, Scaffold: cmd})
+			continue
+		}
+		if a := scaffold.FindAddon(strings.TrimPrefix(id, "addon.")); a != nil {
+			others = append(others, CatalogFact{ID: "addon." + a.ID, Summary: a.Summary})
+			continue
+		}
+		if asset, ok := byID[id]; ok {
+			others = append(others, CatalogFact{ID: asset.ID, Summary: asset.Summary})
+		}
+	}
+	if len(profiles) < 2 {
+		return nil
+	}
+	return append(profiles, others...)
+}
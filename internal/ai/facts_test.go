@@ -0,0 +1,39 @@
+package ai
+
+import "testing"
+
+func TestOptionFacts(t *testing.T) {
+	reply := "★ Elixir + Phoenix (`elixir-phoenix`) fits real-time voting.\n" +
+		"SvelteKit (`typescript-sveltekit`) is a lighter option.\n" +
+		"Consider `addon.data-intensive` and `asset.workflow.releases`, or `elixir-phoenix` again."
+
+	facts := OptionFacts(reply, "voter")
+	var ids []string
+	for _, f := range facts {
+		ids = append(ids, f.ID)
+	}
+	want := []string{"elixir-phoenix", "typescript-sveltekit", "addon.data-intensive", "asset.workflow.releases"}
+	if len(ids) != len(want) {
+		t.Fatalf("facts = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("facts[%d] = %s, want %s", i, ids[i], want[i])
+		}
+	}
+	if facts[0].Scaffold != "mix phx.new voter" {
+		t.Errorf("scaffold = %q, want project name substituted", facts[0].Scaffold)
+	}
+	if facts[2].Scaffold != "" {
+		t.Error("add-ons have no scaffold command")
+	}
+}
+
+func TestOptionFacts_NotAnOptionsReply(t *testing.T) {
+	if facts := OptionFacts("Confirmed: `go-service`. READY_TO_GENERATE", "x"); facts != nil {
+		t.Errorf("single-stack reply should yield nil, got %v", facts)
+	}
+	if facts := OptionFacts("Would you want a leaderboard?", "x"); facts != nil {
+		t.Errorf("scope question should yield nil, got %v", facts)
+	}
+}
@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxFeatures is how many features the first-feature brief covers.
+const maxFeatures = 3
+
+// normalizeFeatures trims features, drops case-insensitive duplicates, and
+// keeps the first maxFeatures.
+func normalizeFeatures(features []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, f := range features {
+		f = strings.Join(strings.Fields(f), " ")
+		key := strings.ToLower(f)
+		if f == "" || seen[key] || len(out) == maxFeatures {
+			continue
+		}
+		seen[key] = true
+		out = append(out, f)
+	}
+	return out
+}
+
+// firstFeatureGuidance asks for first-feature.prompt.md built from the
+// features captured while scoping, or from the project description when
+// the conversation settled none.
+func firstFeatureGuidance(features []string) string {
+	var sb strings.Builder
+	sb.WriteString("FIRST FEATURE BRIEF:\n")
+	sb.WriteString("A first-feature asset is included. Generate .github/prompts/first-feature.prompt.md with\n")
+	sb.WriteString("the same frontmatter rules as start.prompt.md, shaped as the asset describes: a goal,\n")
+	sb.WriteString("then each feature as a user story with acceptance criteria and what is out of scope,\n")
+	sb.WriteString("where it lives in THIS framework's layout, the order of work, and the tests and\n")
+	sb.WriteString("test command that prove it is done.\n")
+	if len(features) > 0 {
+		sb.WriteString("Cover exactly these features from the conversation, in this order:\n")
+		for i, f := range features {
+			fmt.Fprintf(&sb, "  %d. %s\n", i+1, f)
+		}
+	} else {
+		sb.WriteString("No features were settled while scoping; take the single most important feature\n")
+		sb.WriteString("from the project description.\n")
+	}
+	sb.WriteString("start.prompt.md should end by pointing at first-feature.prompt.md as the next step.\n\n")
+	return sb.String()
+}
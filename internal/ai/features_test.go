@@ -0,0 +1,55 @@
+package ai
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeFeatures(t *testing.T) {
+	got := normalizeFeatures([]string{"  sign   up ", "", "Sign up", "invite a teammate", "export to CSV", "billing"})
+	want := []string{"sign up", "invite a teammate", "export to CSV"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeFeatures = %q, want %q", got, want)
+	}
+}
+
+func TestParseSelection_Features(t *testing.T) {
+	sel, err := ParseSelection(`{"profile_id":"go-service","features":["track orders","track orders"],"confidence":0.9,"rationale":"test"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"track orders"}; !reflect.DeepEqual(sel.Features, want) {
+		t.Errorf("features = %q, want %q", sel.Features, want)
+	}
+}
+
+func TestGenerateFiles_FirstFeature(t *testing.T) {
+	tests := []struct {
+		name     string
+		features []string
+		want     string
+	}{
+		{"from conversation", []string{"track orders", "refund an order"}, "  2. refund an order\n"},
+		{"from description", nil, "No features were settled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &scriptedProvider{}
+			e := NewEngine(p)
+			sel := &Selection{ProfileID: "go-service", AssetIDs: []string{"asset.workflow.first-feature"}, Features: tt.features, Confidence: 0.9}
+
+			_, _ = e.GenerateFiles(context.Background(), "app", sel)
+
+			if len(p.messages) == 0 {
+				t.Fatal("no generation prompt sent")
+			}
+			for _, want := range []string{".github/prompts/first-feature.prompt.md", "acceptance criteria", tt.want} {
+				if !strings.Contains(p.messages[0], want) {
+					t.Errorf("prompt missing %q", want)
+				}
+			}
+		})
+	}
+}
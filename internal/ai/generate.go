@@ -0,0 +1,185 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ecoker/launchpad/internal/validate"
+)
+
+// fileSpec is one output file generated by its own model call.
+type fileSpec struct {
+	Path    string
+	Purpose string // what the file must contain, appended to the shared context
+}
+
+// noExtraFiles is what the model replies when the guidance asks for no files
+// beyond the core set.
+const noExtraFiles = "NO_ADDITIONAL_FILES"
+
+// coreFileSpecs lists the files every generation produces, one model call
+// each, so a large context doesn't have to be shared across all outputs.
+func coreFileSpecs(profileID, profileGlob, scaffoldCmd string, withDesign bool) []fileSpec {
+	specs := []fileSpec{
+		{
+			Path:    ".github/copilot-instructions.md",
+			Purpose: "Always-on standards synthesized from the core and profile assets.",
+		},
+		{
+			Path: ".github/instructions/" + profileID + ".instructions.md",
+			Purpose: fmt.Sprintf("Framework-specific conventions from the profile asset. YAML frontmatter with\n"+
+				"applyTo: %q to scope to framework source files. Do not repeat the always-on\n"+
+				"standards from copilot-instructions.md.", profileGlob),
+		},
+	}
+	if withDesign {
+		specs = append(specs, fileSpec{
+			Path: ".github/instructions/design-system.instructions.md",
+			Purpose: "The design-system synthesis described above, with YAML frontmatter whose applyTo\n" +
+				"glob matches the selected framework's template/style files.",
+		})
+	}
+	specs = append(specs,
+		fileSpec{
+			Path:    "AGENTS.md",
+			Purpose: "Multi-agent ground rules from the core agents asset, plus any sections the guidance above requires.",
+		},
+		fileSpec{
+			Path: ".github/prompts/start.prompt.md",
+			Purpose: "YAML frontmatter MUST be exactly:\n" +
+				"---\n" +
+				"description: \"<one-sentence description>\"\n" +
+				"mode: agent\n" +
+				"tools: [\"terminal\", \"editFiles\", \"codebase\"]\n" +
+				"---\n" +
+				"Do NOT invent tool names. The only valid tools are: " +
+				strings.Join(validate.AllowedPromptTools, ", ") + ".\nUse exactly these identifiers.\n" +
+				"Body MUST:\n" +
+				"a) Run the framework scaffold command first: " + scaffoldCmd + "\n" +
+				"b) Then proceed with application-specific implementation\n" +
+				"c) Never manually create files the scaffold already provides\n" +
+				"Include any start.prompt.md sections the guidance above requires.",
+		},
+	)
+	return specs
+}
+
+// filePrompt asks for exactly one file.
+func filePrompt(shared string, spec fileSpec) string {
+	return shared + "\n" +
+		"YOUR TASK: generate ONLY the file " + spec.Path + ".\n" +
+		spec.Purpose + "\n\n" +
+		"Output exactly one file block — no prose before or after:\n" +
+		"===FILE: " + spec.Path + "===\n(content)\n===END_FILE===\n"
+}
+
+// extrasPrompt asks for every file the guidance requires beyond the core set:
+// per-concern instruction files, tool configuration, package-scoped files.
+func extrasPrompt(shared string, core []fileSpec) string {
+	paths := make([]string, len(core))
+	for i, s := range core {
+		paths[i] = s.Path
+	}
+	return shared + "\n" +
+		"YOUR TASK: generate every ADDITIONAL file the guidance above asks for — one\n" +
+		".github/instructions/*.instructions.md per additional concern (architecture,\n" +
+		"frontend-craft, testing, server-patterns, etc.) with a YAML frontmatter applyTo glob,\n" +
+		"plus any configuration or prompt files the asset guidance requires.\n" +
+		"These files are generated separately — do NOT emit them: " + strings.Join(paths, ", ") + ".\n" +
+		"If nothing else is needed, reply with exactly " + noExtraFiles + ".\n\n" +
+		"Output ONLY file blocks — no prose before or after:\n" +
+		"===FILE: relative/path===\n(content)\n===END_FILE===\n"
+}
+
+// generateEach runs one model call per core file plus one for the extras.
+// Calls run concurrently on forked threads, at most e.concurrency at a time;
+// providers that can't fork get one call at a time on their single thread.
+// A bad or failed response costs only that file: failures are reported as
+// warnings and the rest of the output is kept, in spec order.
+func (e *Engine) generateEach(ctx context.Context, shared string, specs []fileSpec) []FileOutput {
+	prompts := make([]string, 0, len(specs)+1)
+	core := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		core[spec.Path] = true
+		prompts = append(prompts, filePrompt(shared, spec))
+	}
+	prompts = append(prompts, extrasPrompt(shared, specs))
+
+	workers := e.concurrency
+	if _, ok := e.provider.(Forker); !ok || workers < 1 {
+		workers = 1
+	}
+	raws := make([]string, len(prompts))
+	errs := make([]error, len(prompts))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				raws[i], errs[i] = e.sendFiles(ctx, e.fork(), prompts[i])
+			}
+		}()
+	}
+	for i := range prompts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var files []FileOutput
+	for i, spec := range specs {
+		if errs[i] != nil {
+			e.warn(fmt.Sprintf("generating %s failed: %v", spec.Path, errs[i]))
+			continue
+		}
+		got := fileByPath(parseFileOutput(raws[i]), spec.Path)
+		if got == nil {
+			e.warn(fmt.Sprintf("model returned no %s", spec.Path))
+			continue
+		}
+		files = append(files, *got)
+	}
+
+	last := len(prompts) - 1
+	if errs[last] != nil {
+		e.warn(fmt.Sprintf("generating additional files failed: %v", errs[last]))
+		return files
+	}
+	for _, f := range parseFileOutput(raws[last]) {
+		if core[f.Path] {
+			continue
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// fileByPath picks the block for path, falling back to the only block when
+// the model mislabeled a single-file reply.
+func fileByPath(files []FileOutput, path string) *FileOutput {
+	for i := range files {
+		if files[i].Path == path {
+			return &files[i]
+		}
+	}
+	if len(files) == 1 {
+		f := files[0]
+		f.Path = path
+		return &f
+	}
+	return nil
+}
+
+// fork returns a provider for one independent generation call. Providers
+// that can't branch their conversation are shared, so calls run in sequence
+// on the same thread.
+func (e *Engine) fork() Provider {
+	if f, ok := e.provider.(Forker); ok {
+		return f.Fork()
+	}
+	return e.provider
+}
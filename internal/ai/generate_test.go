@@ -0,0 +1,246 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenerateEach_IsolatesFailures(t *testing.T) {
+	p := &scriptedProvider{
+		replies: []string{
+			"===FILE: .github/copilot-instructions.md===\n# Standards\n===END_FILE===",
+			"",
+			"===FILE: AGENTS.md===\nduplicate\n===END_FILE===\n" +
+				"===FILE: .github/instructions/testing.instructions.md===\n---\napplyTo: \"**\"\n---\n===END_FILE===",
+		},
+		errs: []error{nil, errors.New("boom")},
+	}
+	var warnings []string
+	e := NewEngine(p, WithWarningHandler(func(msg string) { warnings = append(warnings, msg) }))
+	specs := []fileSpec{
+		{Path: ".github/copilot-instructions.md", Purpose: "standards"},
+		{Path: "AGENTS.md", Purpose: "agents"},
+	}
+
+	files := e.generateEach(context.Background(), "SHARED", specs)
+
+	if len(p.messages) != 3 {
+		t.Fatalf("expected one call per spec plus extras, got %d", len(p.messages))
+	}
+	if !strings.Contains(p.messages[0], "generate ONLY the file .github/copilot-instructions.md") {
+		t.Errorf("first call should target copilot-instructions:\n%s", p.messages[0])
+	}
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	if got := strings.Join(paths, ","); got != ".github/copilot-instructions.md,.github/instructions/testing.instructions.md" {
+		t.Errorf("files = %s", got)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "AGENTS.md") {
+		t.Errorf("expected one warning about AGENTS.md, got %v", warnings)
+	}
+}
+
+func TestFileByPath_RelabelsSingleBlock(t *testing.T) {
+	got := fileByPath([]FileOutput{{Path: "agents.md", Content: "x"}}, "AGENTS.md")
+	if got == nil || got.Path != "AGENTS.md" {
+		t.Fatalf("fileByPath() = %+v", got)
+	}
+	if fileByPath([]FileOutput{{Path: "a"}, {Path: "b"}}, "c") != nil {
+		t.Error("expected nil when several blocks and none match")
+	}
+}
+
+// forkingProvider answers every prompt with the file it asks for and tracks
+// how many calls are in flight at once across forks.
+type forkingProvider struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (p *forkingProvider) Fork() Provider { return p }
+
+func (p *forkingProvider) Send(_ context.Context, message, _ string) (string, error) {
+	p.mu.Lock()
+	p.inFlight++
+	if p.inFlight > p.peak {
+		p.peak = p.inFlight
+	}
+	p.mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	p.mu.Lock()
+	p.inFlight--
+	p.mu.Unlock()
+
+	const marker = "generate ONLY the file "
+	i := strings.Index(message, marker)
+	if i == -1 {
+		return noExtraFiles, nil
+	}
+	path := strings.TrimSuffix(strings.SplitN(message[i+len(marker):], "\n", 2)[0], ".")
+	return "===FILE: " + path + "===\nok\n===END_FILE===", nil
+}
+
+func TestGenerateEach_BoundedConcurrency(t *testing.T) {
+	p := &forkingProvider{}
+	e := NewEngine(p, WithConcurrency(2))
+	specs := coreFileSpecs("go-service", "**/*.go", "go mod init demo", true)
+
+	files := e.generateEach(context.Background(), "SHARED", specs)
+
+	if p.peak != 2 {
+		t.Errorf("peak concurrency = %d, want 2", p.peak)
+	}
+	if len(files) != len(specs) {
+		t.Fatalf("got %d files, want %d", len(files), len(specs))
+	}
+	for i, spec := range specs {
+		if files[i].Path != spec.Path {
+			t.Errorf("files[%d] = %s, want %s (spec order)", i, files[i].Path, spec.Path)
+		}
+	}
+}
+
+func TestGenerateFiles_WarnsOnPaletteContrast(t *testing.T) {
+	p := &scriptedProvider{}
+	var warnings []string
+	e := NewEngine(p, WithWarningHandler(func(msg string) { warnings = append(warnings, msg) }))
+	sel := &Selection{ProfileID: "typescript-sveltekit", AssetIDs: []string{"asset.palette.heroui-blue"}, Confidence: 0.9}
+
+	_, _ = e.GenerateFiles(context.Background(), "app", sel)
+
+	var contrast []string
+	for _, w := range warnings {
+		if strings.HasPrefix(w, "palette contrast: ") {
+			contrast = append(contrast, w)
+		}
+	}
+	if len(contrast) == 0 || !strings.Contains(strings.Join(contrast, "\n"), "Warning 500 (#f5a524)") {
+		t.Errorf("expected a contrast warning for Warning 500, got %v", warnings)
+	}
+	if len(p.messages) == 0 || !strings.Contains(p.messages[0], "fail WCAG AA contrast") {
+		t.Error("generation prompt should carry the contrast failures")
+	}
+}
+
+func TestGenerateFiles_PaletteRoles(t *testing.T) {
+	p := &scriptedProvider{}
+	e := NewEngine(p)
+	sel := &Selection{
+		ProfileID:  "typescript-sveltekit",
+		AssetIDs:   []string{"asset.palette.heroui-blue", "asset.palette.obsidian-indigo"},
+		Confidence: 0.9,
+		PaletteRoles: []PaletteRole{
+			{AssetID: "asset.palette.obsidian-indigo", Role: PalettePrimary},
+			{AssetID: "asset.palette.heroui-blue", Role: PaletteSecondary},
+		},
+	}
+
+	_, _ = e.GenerateFiles(context.Background(), "app", sel)
+
+	if len(p.messages) == 0 {
+		t.Fatal("no generation prompt sent")
+	}
+	for _, want := range []string{"primary   asset.palette.obsidian-indigo", "secondary asset.palette.heroui-blue"} {
+		if !strings.Contains(p.messages[0], want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+// addonProvider answers each per-file prompt with a valid file and the
+// extras prompt with extra, the add-on's own instruction file.
+type addonProvider struct {
+	mu       sync.Mutex
+	extra    string
+	messages []string
+}
+
+func (p *addonProvider) Send(_ context.Context, message, _ string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, message)
+	const marker = "generate ONLY the file "
+	path := p.extra
+	if i := strings.Index(message, marker); i != -1 {
+		path = strings.TrimSuffix(strings.SplitN(message[i+len(marker):], "\n", 2)[0], ".")
+	}
+	content := "# " + path
+	switch {
+	case strings.HasSuffix(path, ".instructions.md"):
+		content = "---\napplyTo: \"**\"\n---\n" + content
+	case strings.HasSuffix(path, ".prompt.md"):
+		content = "---\ndescription: \"Start\"\nmode: agent\n---\n" + content
+	}
+	return "===FILE: " + path + "===\n" + content + "\n===END_FILE===", nil
+}
+
+func TestGenerateFiles_Addons(t *testing.T) {
+	tests := []struct {
+		name  string
+		sel   Selection
+		file  string   // the add-on's instruction file
+		wants []string // guidance the generation prompt must carry
+	}{
+		{"observability", Selection{ProfileID: "python-fastapi", AddonIDs: []string{"observability"}},
+			"observability", []string{"OpenTelemetry SDK", "## Health endpoints"}},
+		{"ci-cd", Selection{ProfileID: "go-service", AddonIDs: []string{"ci-cd"}},
+			"ci-cd", []string{".github/workflows/ci.yml", "## Pipeline stages"}},
+		{"iac", Selection{ProfileID: "typescript-fastify", AddonIDs: []string{"iac"}, AssetIDs: []string{"asset.deploy.fly"}},
+			"iac", []string{"iac.instructions.md for ONE tool", "## Drift discipline"}},
+		{"auth", Selection{ProfileID: "elixir-phoenix", AddonIDs: []string{"auth"}},
+			"auth", []string{"mix phx.gen.auth"}},
+		{"graphql", Selection{ProfileID: "go-service", AddonIDs: []string{"graphql"}},
+			"graphql", []string{"gqlgen", "DataLoader"}},
+		{"api-design", Selection{ProfileID: "python-fastapi", AddonIDs: []string{"api-design"}},
+			"api-design", []string{"problem+json", "cursor pagination"}},
+		{"a11y", Selection{ProfileID: "typescript-sveltekit", AddonIDs: []string{"a11y"}},
+			"a11y", []string{"WCAG 2.2", "@axe-core/playwright"}},
+		{"performance", Selection{ProfileID: "typescript-nextjs", AddonIDs: []string{"performance"}},
+			"performance", []string{"Largest Contentful Paint", "EXPLAIN ANALYZE"}},
+		{"multi-tenancy", Selection{ProfileID: "ruby-rails", AddonIDs: []string{"multi-tenancy"}},
+			"multi-tenancy", []string{"row-level security", "at least two tenants"}},
+		{"event-sourcing", Selection{ProfileID: "dotnet-api", AddonIDs: []string{"data-intensive", "event-sourcing"}},
+			"event-sourcing", []string{"Marten", "upcasters"}},
+		{"ai-integration", Selection{ProfileID: "python-fastapi", AddonIDs: []string{"ai-integration"}},
+			"ai-integration", []string{"StreamingResponse", "max_output_tokens"}},
+		{"realtime", Selection{ProfileID: "elixir-phoenix", AddonIDs: []string{"realtime"}},
+			"realtime", []string{"Phoenix Channels", "Authorize **every join**"}},
+		{"compliance", Selection{ProfileID: "ruby-rails", AddonIDs: []string{"compliance"}, AssetIDs: []string{"asset.privacy.data-protection"}},
+			"compliance", []string{"leave PII classification", "Audit logging"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := ".github/instructions/" + tt.file + ".instructions.md"
+			p := &addonProvider{extra: path}
+			sel := tt.sel
+			sel.Confidence = 0.9
+
+			files, err := NewEngine(p).GenerateFiles(context.Background(), "app", &sel)
+			if err != nil {
+				t.Fatalf("GenerateFiles: %v", err)
+			}
+			if len(p.messages) == 0 {
+				t.Fatal("no generation prompt sent")
+			}
+			for _, want := range append([]string{tt.file + ".instructions.md"}, tt.wants...) {
+				if !strings.Contains(p.messages[0], want) {
+					t.Errorf("prompt missing %q", want)
+				}
+			}
+			if fileByPath(files, path) == nil {
+				var paths []string
+				for _, f := range files {
+					paths = append(paths, f.Path)
+				}
+				t.Errorf("generated %v, want %s among them", paths, path)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// OutputLanguages are the languages generated prose can be requested in,
+// by code.
+var OutputLanguages = map[string]string{
+	"en": "English", "es": "Spanish", "fr": "French", "de": "German", "pt": "Portuguese",
+	"it": "Italian", "nl": "Dutch", "ru": "Russian", "ja": "Japanese", "zh": "Chinese", "ko": "Korean",
+}
+
+// ParseLanguage normalizes a --language value to a code in OutputLanguages.
+// Names are accepted too, in any case.
+func ParseLanguage(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "en", nil
+	}
+	for code, name := range OutputLanguages {
+		if s == code || s == strings.ToLower(name) {
+			return code, nil
+		}
+	}
+	codes := make([]string, 0, len(OutputLanguages))
+	for code := range OutputLanguages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return "", fmt.Errorf("unsupported language %q (supported: %s)", s, strings.Join(codes, ", "))
+}
+
+// WithLanguage asks for generated prose in the language with the given
+// code, and has files that come back in another language rewritten. The
+// default is English.
+func WithLanguage(code string) EngineOption {
+	return func(e *Engine) {
+		if code != "" {
+			e.language = code
+		}
+	}
+}
+
+// languageGuidance is the prompt block requesting a language other than
+// English. English needs none; the prompts are written in it.
+func languageGuidance(code string) string {
+	if code == "" || code == "en" {
+		return ""
+	}
+	return "OUTPUT LANGUAGE:\n" +
+		"Write all prose in " + OutputLanguages[code] + ". Keep code, commands, file paths,\n" +
+		"identifiers, frontmatter keys, and applyTo globs exactly as they would be in English.\n\n"
+}
+
+// stopwords are common words that identify a Latin-script language. Words
+// shared between languages are dropped at init, so each hit is unambiguous.
+var stopwords = map[string]map[string]bool{}
+
+func init() {
+	lists := map[string]string{
+		"en": "the and is are with this that for of to you be when should not from it into your each use",
+		"es": "el los las y es con para que una del por se su como cuando debe usar esta este",
+		"fr": "le les et est avec pour une des du dans ce sont pas vous doit qui sur lorsque",
+		"de": "der die das und ist mit für nicht ein eine den zu werden sie wenn auf immer",
+		"pt": "os com é uma do da não em ao são deve quando usar nunca",
+		"it": "il gli e è con per che una della non sono deve nel quando sempre",
+		"nl": "de het en een van met voor niet zijn wordt op bij altijd wanneer",
+	}
+	seen := map[string]int{}
+	for _, words := range lists {
+		for _, w := range strings.Fields(words) {
+			seen[w]++
+		}
+	}
+	for code, words := range lists {
+		stopwords[code] = map[string]bool{}
+		for _, w := range strings.Fields(words) {
+			if seen[w] == 1 {
+				stopwords[code][w] = true
+			}
+		}
+	}
+}
+
+var (
+	fencedCode = regexp.MustCompile("(?s)```.*?```")
+	inlineCode = regexp.MustCompile("`[^`\n]*`")
+	htmlNote   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	urlText    = regexp.MustCompile(`https?://\S+`)
+)
+
+// prose strips what isn't written in a natural language from markdown:
+// frontmatter, code, comments, and URLs.
+func prose(content string) string {
+	if strings.HasPrefix(content, "---\n") {
+		if end := strings.Index(content[4:], "\n---"); end != -1 {
+			content = content[4+end+4:]
+		}
+	}
+	for _, re := range []*regexp.Regexp{fencedCode, htmlNote, inlineCode, urlText} {
+		content = re.ReplaceAllString(content, " ")
+	}
+	return content
+}
+
+// Detection thresholds. Short files say too little to judge.
+const (
+	minStopwords   = 20  // stopword hits needed before a Latin-script file is judged
+	minLetters     = 200 // letters needed before the script is judged
+	minLatinShare  = 0.6 // of stopword hits that must be in the requested language
+	minScriptShare = 0.2 // of letters that must be in the requested non-Latin script
+	maxScriptShare = 0.3 // of letters a Latin-script file may have in another script
+)
+
+// scriptLanguage names the language of each non-Latin script.
+var scriptLanguage = []struct {
+	code  string
+	table *unicode.RangeTable
+}{
+	{"ja", unicode.Hiragana}, {"ja", unicode.Katakana}, {"ko", unicode.Hangul},
+	{"zh", unicode.Han}, {"ru", unicode.Cyrillic},
+}
+
+// driftedLanguage reports the language the prose of content is mostly in,
+// when that isn't want. It returns "" when the file is in want, or too
+// short or mixed to tell.
+func driftedLanguage(content, want string) string {
+	text := prose(content)
+	letters, latin := 0, 0
+	scripts := map[string]int{}
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		for _, s := range scriptLanguage {
+			if unicode.Is(s.table, r) {
+				scripts[s.code]++
+				break
+			}
+		}
+	}
+	// Japanese mixes kanji with kana; a little kana is enough to tell it
+	// from Chinese.
+	if scripts["ja"] > 0 {
+		scripts["ja"] += scripts["zh"]
+		scripts["zh"] = 0
+	}
+	if letters < minLetters {
+		return ""
+	}
+
+	hits := map[string]int{}
+	total := 0
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		for code, words := range stopwords {
+			if words[w] {
+				hits[code]++
+				total++
+			}
+		}
+	}
+
+	if _, latinWanted := stopwords[want]; !latinWanted {
+		if float64(scripts[want]) >= minScriptShare*float64(letters) {
+			return ""
+		}
+		if top := topLanguage(scripts); top != "" && float64(scripts[top]) >= minScriptShare*float64(letters) {
+			return top
+		}
+		if total >= minStopwords {
+			return topLanguage(hits)
+		}
+		return ""
+	}
+	if top := topLanguage(scripts); top != "" && float64(scripts[top]) > maxScriptShare*float64(letters) {
+		return top
+	}
+	if total < minStopwords || float64(hits[want]) >= minLatinShare*float64(total) {
+		return ""
+	}
+	if top := topLanguage(hits); top != want {
+		return top
+	}
+	return ""
+}
+
+func topLanguage(counts map[string]int) string {
+	best := ""
+	for code, n := range counts {
+		if n > 0 && (best == "" || n > counts[best] || n == counts[best] && code < best) {
+			best = code
+		}
+	}
+	return best
+}
+
+// correctLanguage asks the model to rewrite markdown files whose prose
+// drifted out of the requested language, as some local models do. Like
+// repairFiles it retries up to maxRepairAttempts, then refuses to ship
+// files still in the wrong language.
+func (e *Engine) correctLanguage(ctx context.Context, files []FileOutput) ([]FileOutput, error) {
+	want := e.language
+	if want == "" {
+		want = "en"
+	}
+	drifted := driftedFiles(files, want)
+	for attempt := 1; len(drifted) > 0 && attempt <= maxRepairAttempts; attempt++ {
+		e.warn(fmt.Sprintf("%d generated file(s) aren't in %s — asking for a rewrite (attempt %d/%d)",
+			len(drifted), OutputLanguages[want], attempt, maxRepairAttempts))
+
+		raw, err := e.sendFiles(ctx, e.fork(), languagePrompt(want, drifted, files))
+		if err != nil {
+			return nil, err
+		}
+		fixed, err := sanitizeFiles(parseFileOutput(raw))
+		if err != nil {
+			return nil, err
+		}
+		files = replaceFiles(files, fixed)
+		drifted = driftedFiles(files, want)
+	}
+	if len(drifted) > 0 {
+		var lines []string
+		for _, path := range sortedKeys(drifted) {
+			lines = append(lines, fmt.Sprintf("%s (%s)", path, OutputLanguages[drifted[path]]))
+		}
+		return nil, fmt.Errorf("generated files are still not in %s after %d rewrite(s):\n  %s",
+			OutputLanguages[want], maxRepairAttempts, strings.Join(lines, "\n  "))
+	}
+	return files, nil
+}
+
+// driftedFiles maps each markdown file not written in want to the language
+// it is in.
+func driftedFiles(files []FileOutput, want string) map[string]string {
+	drifted := map[string]string{}
+	for _, f := range files {
+		if !strings.HasSuffix(f.Path, ".md") {
+			continue
+		}
+		if lang := driftedLanguage(f.Content, want); lang != "" {
+			drifted[f.Path] = lang
+		}
+	}
+	return drifted
+}
+
+// languagePrompt quotes the drifted files and asks for them back in want,
+// in the same block format as the original generation.
+func languagePrompt(want string, drifted map[string]string, files []FileOutput) string {
+	name := OutputLanguages[want]
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Some of the generated files are not written in %s:\n\n", name)
+	for _, path := range sortedKeys(drifted) {
+		fmt.Fprintf(&sb, "- %s is in %s\n", path, OutputLanguages[drifted[path]])
+	}
+	sb.WriteString("\nCurrent content:\n\n")
+	for _, f := range files {
+		if _, ok := drifted[f.Path]; ok {
+			fmt.Fprintf(&sb, "===FILE: %s===\n%s\n===END_FILE===\n\n", f.Path, f.Content)
+		}
+	}
+	fmt.Fprintf(&sb, "Re-emit ONLY these files, complete, with every sentence in %s. Keep the meaning,\n", name)
+	sb.WriteString("structure, code blocks, commands, paths, and frontmatter unchanged.\n\n")
+	sb.WriteString("Output ONLY file blocks — no prose before or after:\n")
+	sb.WriteString("===FILE: relative/path===\n(content)\n===END_FILE===\n")
+	return sb.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const englishDoc = "---\napplyTo: \"**/*.go\"\n---\n# Errors\n\n" +
+	"Wrap every error with the context of the call that failed, so the log tells you what broke. " +
+	"When a function returns an error, check it before you use the other results. " +
+	"Do not panic in library code; return the error to the caller and let it decide. " +
+	"Each package should define its own sentinel errors for the cases callers handle, and it is " +
+	"fine to compare them with errors.Is. Keep messages lower case and without punctuation.\n\n" +
+	"```go\nif err != nil {\n\treturn fmt.Errorf(\"load config: %w\", err)\n}\n```\n"
+
+const spanishDoc = "---\napplyTo: \"**/*.go\"\n---\n# Errores\n\n" +
+	"Envuelve cada error con el contexto de la llamada que falló, para que el registro diga qué se rompió. " +
+	"Cuando una función devuelve un error, compruébalo antes de usar los otros resultados. " +
+	"No uses panic en el código de una biblioteca; devuelve el error para que quien llama decida. " +
+	"Cada paquete debe definir sus propios errores para los casos que se manejan, y se pueden comparar " +
+	"con errors.Is. Los mensajes van en minúsculas y sin puntuación, como dice la guía del equipo.\n"
+
+const japaneseDoc = "# エラー処理\n\n" +
+	"失敗した呼び出しの文脈でエラーをラップし、ログから何が壊れたのかが分かるようにします。" +
+	"関数がエラーを返したら、他の戻り値を使う前に必ず確認してください。" +
+	"ライブラリのコードではパニックを使わず、呼び出し元にエラーを返して判断させます。" +
+	"各パッケージは呼び出し元が扱うケースのためにセンチネルエラーを定義し、それらは比較できます。" +
+	"メッセージは小文字で書き、句読点を付けません。チームのガイドに従ってください。" +
+	"テストでは、期待するエラーが返ることを確認し、メッセージの文字列には依存しないでください。"
+
+func TestDriftedLanguage(t *testing.T) {
+	tests := []struct {
+		name, content, want, drift string
+	}{
+		{"english as requested", englishDoc, "en", ""},
+		{"spanish instead of english", spanishDoc, "en", "es"},
+		{"spanish as requested", spanishDoc, "es", ""},
+		{"english instead of spanish", englishDoc, "es", "en"},
+		{"japanese instead of english", japaneseDoc, "en", "ja"},
+		{"japanese as requested", japaneseDoc, "ja", ""},
+		{"english instead of japanese", englishDoc, "ja", "en"},
+		{"too short to judge", "# Errores\n\nEnvuelve cada error con el contexto.\n", "en", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := driftedLanguage(tt.content, tt.want); got != tt.drift {
+				t.Errorf("driftedLanguage(..., %q) = %q, want %q", tt.want, got, tt.drift)
+			}
+		})
+	}
+}
+
+func TestCorrectLanguage_RewritesDriftedFile(t *testing.T) {
+	p := &scriptedProvider{replies: []string{
+		"===FILE: .github/instructions/errors.instructions.md===\n" + englishDoc + "\n===END_FILE===",
+	}}
+	var warnings []string
+	e := NewEngine(p, WithWarningHandler(func(msg string) { warnings = append(warnings, msg) }))
+	files := []FileOutput{
+		{Path: "AGENTS.md", Content: englishDoc},
+		{Path: ".github/instructions/errors.instructions.md", Content: spanishDoc},
+	}
+
+	got, err := e.correctLanguage(context.Background(), files)
+	if err != nil {
+		t.Fatalf("correctLanguage: %v", err)
+	}
+	if len(p.messages) != 1 {
+		t.Fatalf("expected 1 rewrite prompt, got %d", len(p.messages))
+	}
+	if !strings.Contains(p.messages[0], "errors.instructions.md is in Spanish") || strings.Contains(p.messages[0], "===FILE: AGENTS.md") {
+		t.Errorf("rewrite prompt should quote only the drifted file:\n%s", p.messages[0])
+	}
+	if got[1].Content != strings.TrimSpace(englishDoc) {
+		t.Errorf("file not replaced: %q", got[1].Content)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v", warnings)
+	}
+}
+
+func TestCorrectLanguage_GivesUp(t *testing.T) {
+	block := "===FILE: AGENTS.md===\n" + spanishDoc + "\n===END_FILE==="
+	p := &scriptedProvider{replies: []string{block, block}}
+	e := NewEngine(p)
+
+	_, err := e.correctLanguage(context.Background(), []FileOutput{{Path: "AGENTS.md", Content: spanishDoc}})
+	if err == nil || !strings.Contains(err.Error(), "AGENTS.md (Spanish)") {
+		t.Fatalf("err = %v, want the file still in Spanish", err)
+	}
+	if len(p.messages) != maxRepairAttempts {
+		t.Errorf("expected %d attempts, got %d", maxRepairAttempts, len(p.messages))
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	for in, want := range map[string]string{"": "en", "de": "de", "Japanese": "ja", " FR ": "fr"} {
+		if got, err := ParseLanguage(in); err != nil || got != want {
+			t.Errorf("ParseLanguage(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseLanguage("klingon"); err == nil {
+		t.Error("expected an error for an unsupported language")
+	}
+}
+
+func TestGenerateFiles_LanguageGuidance(t *testing.T) {
+	p := &scriptedProvider{}
+	e := NewEngine(p, WithLanguage("de"))
+	sel := &Selection{ProfileID: "go-service", Confidence: 0.9}
+
+	_, _ = e.GenerateFiles(context.Background(), "api", sel)
+
+	if len(p.messages) == 0 || !strings.Contains(p.messages[0], "Write all prose in German") {
+		t.Error("generation prompt should request German prose")
+	}
+}
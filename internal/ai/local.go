@@ -0,0 +1,232 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/sandbox"
+	"github.com/ecoker/launchpad/templates"
+)
+
+// localIndex lists the imported assets in the local catalog directory; each
+// template sits next to it as <id>.instructions.md.
+const localIndex = "catalog.json"
+
+// maxSummary caps an inferred summary, which is shown in catalog listings
+// and the conversation prompt.
+const maxSummary = 160
+
+// localAssetID is the shape of an imported asset's ID: asset.<category>.<name>.
+var localAssetID = regexp.MustCompile(`^asset\.[a-z0-9-]+\.[a-z0-9-]+$`)
+
+// localEntry is one imported asset as recorded in the index.
+type localEntry struct {
+	ID       string `json:"id"`
+	Category string `json:"category"`
+	Label    string `json:"label"`
+	Summary  string `json:"summary"`
+}
+
+// LocalDir is the local catalog: instruction files imported with launchpad
+// import, selectable in every project on this machine.
+func LocalDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating config dir: %w", err)
+	}
+	return filepath.Join(dir, "launchpad", "assets"), nil
+}
+
+// ReadTemplate reads an asset's template: from the embedded templates, or
+// from the local catalog for imported assets, whose TemplatePath is absolute.
+func ReadTemplate(path string) ([]byte, error) {
+	if filepath.IsAbs(path) {
+		return os.ReadFile(path)
+	}
+	return templates.FS.ReadFile(path)
+}
+
+// allAssets is the built-in catalog followed by the imported assets. A
+// local catalog that can't be read contributes nothing.
+func allAssets() []ContextAsset {
+	local, _ := localAssets()
+	return append(catalog(), local...)
+}
+
+// localAssets reads the local catalog index. A missing index is an empty
+// catalog.
+func localAssets() ([]ContextAsset, error) {
+	dir, err := LocalDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := readLocalIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	assets := make([]ContextAsset, 0, len(entries))
+	for _, e := range entries {
+		assets = append(assets, ContextAsset{
+			ID:           e.ID,
+			Category:     e.Category,
+			Label:        e.Label,
+			Summary:      e.Summary,
+			TemplatePath: filepath.Join(dir, e.ID+".instructions.md"),
+		})
+	}
+	return assets, nil
+}
+
+func readLocalIndex(dir string) ([]localEntry, error) {
+	data, err := os.ReadFile(filepath.Join(dir, localIndex))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []localEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Join(dir, localIndex), err)
+	}
+	return entries, nil
+}
+
+// ImportAsset copies an instruction file into the local catalog as id,
+// inferring its label and summary from the frontmatter name and
+// description (or the first heading and paragraph) and its category from
+// the ID. Importing an ID again replaces it; built-in IDs are refused.
+// It reports whether an earlier import was replaced.
+func ImportAsset(id string, content []byte) (ContextAsset, bool, error) {
+	if !localAssetID.MatchString(id) {
+		return ContextAsset{}, false, fmt.Errorf("asset ID %q must look like asset.<category>.<name>, e.g. asset.org.payments", id)
+	}
+	if _, ok := catalogByID(catalog(), id); ok {
+		return ContextAsset{}, false, fmt.Errorf("%s is a built-in asset", id)
+	}
+	dir, err := LocalDir()
+	if err != nil {
+		return ContextAsset{}, false, err
+	}
+	entries, err := readLocalIndex(dir)
+	if err != nil {
+		return ContextAsset{}, false, err
+	}
+	entry := inferEntry(id, string(content))
+
+	replaced := false
+	for i := range entries {
+		if entries[i].ID == id {
+			entries[i], replaced = entry, true
+		}
+	}
+	if !replaced {
+		entries = append(entries, entry)
+	}
+	index, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return ContextAsset{}, false, err
+	}
+	path := filepath.Join(dir, id+".instructions.md")
+	if err := sandbox.MkdirAll(dir, 0o755); err != nil {
+		return ContextAsset{}, false, fmt.Errorf("create %s: %w", dir, err)
+	}
+	if err := sandbox.WriteFile(path, content, 0o644); err != nil {
+		return ContextAsset{}, false, fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := sandbox.WriteFile(filepath.Join(dir, localIndex), append(index, '\n'), 0o644); err != nil {
+		return ContextAsset{}, false, fmt.Errorf("write %s: %w", localIndex, err)
+	}
+	return ContextAsset{ID: id, Category: entry.Category, Label: entry.Label, Summary: entry.Summary, TemplatePath: path}, replaced, nil
+}
+
+func catalogByID(assets []ContextAsset, id string) (ContextAsset, bool) {
+	for _, a := range assets {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return ContextAsset{}, false
+}
+
+// inferEntry derives catalog metadata from an instruction file.
+func inferEntry(id, content string) localEntry {
+	parts := strings.Split(id, ".")
+	fields, body := splitFrontmatter(content)
+	e := localEntry{ID: id, Category: parts[1], Label: fields["name"], Summary: fields["description"]}
+	if e.Label == "" {
+		e.Label = firstHeading(body)
+	}
+	if e.Label == "" {
+		e.Label = strings.ToUpper(parts[2][:1]) + strings.ReplaceAll(parts[2][1:], "-", " ")
+	}
+	if e.Summary == "" {
+		e.Summary = firstParagraph(body)
+	}
+	if len(e.Summary) > maxSummary {
+		cut := strings.LastIndex(e.Summary[:maxSummary], " ")
+		if cut < 1 {
+			cut = maxSummary
+		}
+		e.Summary = e.Summary[:cut] + "…"
+	}
+	return e
+}
+
+func firstHeading(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		if title, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok {
+			return strings.TrimSpace(title)
+		}
+	}
+	return ""
+}
+
+// firstParagraph returns the first run of plain prose lines, skipping
+// headings, quotes, lists, tables, and code.
+func firstParagraph(body string) string {
+	var para []string
+	fenced := false
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			fenced = !fenced
+		}
+		prose := !fenced && line != "" && !strings.ContainsAny(line[:1], "#>-*|`0123456789")
+		if prose {
+			para = append(para, line)
+		} else if len(para) > 0 {
+			break
+		}
+	}
+	return strings.Join(para, " ")
+}
+
+// importedGuidance asks for an instruction file per imported asset, which
+// has no dedicated synthesis rules of its own.
+func importedGuidance(assets []ContextAsset) string {
+	var sb strings.Builder
+	for _, a := range assets {
+		if !filepath.IsAbs(a.TemplatePath) {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("IMPORTED ASSETS:\n")
+			sb.WriteString("These assets come from the organization's own repositories. Generate a dedicated\n")
+			sb.WriteString(".github/instructions/<name>.instructions.md for each, keeping its rules and its\n")
+			sb.WriteString("applyTo scope, with examples adapted to the selected framework:\n")
+		}
+		name := a.ID[strings.LastIndex(a.ID, ".")+1:]
+		fmt.Fprintf(&sb, "  - %s → %s.instructions.md\n", a.ID, name)
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
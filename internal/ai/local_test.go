@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const orgPayments = "---\napplyTo: \"**/*.go\"\n---\n\n# Payments\n\n> House rules.\n\nMoney is integer cents,\nnever floats.\n\n- Idempotency keys on every charge.\n"
+
+func TestImportAsset(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	asset, replaced, err := ImportAsset("asset.org.payments", []byte(orgPayments))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replaced {
+		t.Error("first import reported as a replacement")
+	}
+	if asset.Label != "Payments" || asset.Category != "org" || asset.Summary != "Money is integer cents, never floats." {
+		t.Errorf("inferred %+v", asset)
+	}
+	if _, replaced, _ := ImportAsset("asset.org.payments", []byte(orgPayments)); !replaced {
+		t.Error("second import not reported as a replacement")
+	}
+	if n := strings.Count(strings.Join(catalogSummaryLines(), "\n"), "asset.org.payments"); n != 1 {
+		t.Errorf("catalog lists the import %d times, want once", n)
+	}
+
+	for _, id := range []string{"asset.testing.pragmatic", "org.payments", "asset.payments"} {
+		if _, _, err := ImportAsset(id, []byte(orgPayments)); err == nil {
+			t.Errorf("ImportAsset(%q) succeeded, want an error", id)
+		}
+	}
+}
+
+func TestGenerateFiles_ImportedAsset(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if _, _, err := ImportAsset("asset.org.payments", []byte(orgPayments)); err != nil {
+		t.Fatal(err)
+	}
+	p := &scriptedProvider{}
+	e := NewEngine(p)
+	sel := &Selection{ProfileID: "go-service", AssetIDs: []string{"asset.org.payments"}, Confidence: 0.9}
+
+	_, _ = e.GenerateFiles(context.Background(), "app", sel)
+
+	if len(p.messages) == 0 {
+		t.Fatal("no generation prompt sent")
+	}
+	for _, want := range []string{"IMPORTED ASSETS:", "asset.org.payments → payments.instructions.md", "Money is integer cents"} {
+		if !strings.Contains(p.messages[0], want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
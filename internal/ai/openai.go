@@ -7,21 +7,76 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	openAIResponsesURL = "https://api.openai.com/v1/responses"
-	defaultModel       = "gpt-4.1"
+	openAIBaseURL = "https://api.openai.com/v1"
+	defaultModel  = "gpt-4.1"
 )
 
 // OpenAIProvider implements Provider using the OpenAI Responses API.
 type OpenAIProvider struct {
 	apiKey             string
 	model              string
+	baseURL            string
 	httpClient         *http.Client
 	previousResponseID string
+	gate               *rateGate   // shared with forks
+	usage              *tokenUsage // shared with forks
+	caps               *capCache   // shared with forks
+}
+
+// tokenUsage totals the tokens billed across a provider and its forks.
+type tokenUsage struct {
+	mu            sync.Mutex
+	input, output int
+}
+
+// rateGate pauses every thread of a provider after any one of them is rate
+// limited, so concurrent forks back off together instead of each burning its
+// retries against the same limit.
+type rateGate struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// wait blocks until the shared backoff has elapsed.
+func (g *rateGate) wait(ctx context.Context) error {
+	g.mu.Lock()
+	d := time.Until(g.until)
+	g.mu.Unlock()
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// backoff extends the shared pause to at least d from now.
+func (g *rateGate) backoff(d time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until := time.Now().Add(d); until.After(g.until) {
+		g.until = until
+	}
+}
+
+// retryAfter reads a Retry-After header in seconds, falling back to fallback.
+func retryAfter(h http.Header, fallback time.Duration) time.Duration {
+	if secs, err := strconv.Atoi(strings.TrimSpace(h.Get("Retry-After"))); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return fallback
 }
 
 // OpenAIOption configures an OpenAIProvider.
@@ -43,12 +98,27 @@ func WithHTTPClient(c *http.Client) OpenAIOption {
 	}
 }
 
+// WithBaseURL points the provider at another server implementing the
+// Responses API, such as a local model runner. The URL includes the version
+// path, e.g. http://localhost:11434/v1.
+func WithBaseURL(url string) OpenAIOption {
+	return func(p *OpenAIProvider) {
+		if url != "" {
+			p.baseURL = strings.TrimRight(url, "/")
+		}
+	}
+}
+
 // NewOpenAIProvider creates a provider backed by the OpenAI Responses API.
 func NewOpenAIProvider(apiKey string, opts ...OpenAIOption) *OpenAIProvider {
 	p := &OpenAIProvider{
 		apiKey:     strings.TrimSpace(apiKey),
 		model:      defaultModel,
+		baseURL:    openAIBaseURL,
 		httpClient: &http.Client{Timeout: 180 * time.Second},
+		gate:       &rateGate{},
+		usage:      &tokenUsage{},
+		caps:       &capCache{},
 	}
 	for _, o := range opts {
 		o(p)
@@ -56,34 +126,93 @@ func NewOpenAIProvider(apiKey string, opts ...OpenAIOption) *OpenAIProvider {
 	return p
 }
 
+// Usage returns the input and output tokens billed so far by p and every
+// fork of it.
+func (p *OpenAIProvider) Usage() (input, output int) {
+	p.usage.mu.Lock()
+	defer p.usage.mu.Unlock()
+	return p.usage.input, p.usage.output
+}
+
+// Model returns the model this provider sends requests to.
+func (p *OpenAIProvider) Model() string {
+	return p.model
+}
+
+// Fork implements Forker. The fork shares the HTTP client and continues from
+// the last response of p.
+func (p *OpenAIProvider) Fork() Provider {
+	clone := *p
+	return &clone
+}
+
+// responsesRequest is the body of a Responses API call.
+type responsesRequest struct {
+	Model              string         `json:"model"`
+	Instructions       string         `json:"instructions,omitempty"`
+	PreviousResponseID string         `json:"previous_response_id,omitempty"`
+	Input              string         `json:"input"`
+	Text               *textOptions   `json:"text,omitempty"`
+	Tools              []functionTool `json:"tools,omitempty"`
+	Stream             bool           `json:"stream,omitempty"`
+	MaxOutputTokens    int            `json:"max_output_tokens,omitempty"`
+	Store              *bool          `json:"store,omitempty"`
+}
+
+type textOptions struct {
+	Format textFormat `json:"format"`
+}
+
+type textFormat struct {
+	Type   string         `json:"type"`
+	Name   string         `json:"name,omitempty"`
+	Schema map[string]any `json:"schema,omitempty"`
+	Strict bool           `json:"strict,omitempty"`
+}
+
+type functionTool struct {
+	Type        string         `json:"type"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters"`
+}
+
 // Send implements Provider.
 func (p *OpenAIProvider) Send(ctx context.Context, message, systemPrompt string) (string, error) {
-	type reqBody struct {
-		Model              string `json:"model"`
-		Instructions       string `json:"instructions,omitempty"`
-		PreviousResponseID string `json:"previous_response_id,omitempty"`
-		Input              string `json:"input"`
-	}
-	body := reqBody{
+	return p.send(ctx, responsesRequest{
 		Model:              p.model,
 		Input:              message,
 		PreviousResponseID: p.previousResponseID,
 		Instructions:       systemPrompt,
-	}
+	})
+}
+
+// SendStructured implements StructuredSender with a strict JSON schema
+// response format.
+func (p *OpenAIProvider) SendStructured(ctx context.Context, message, systemPrompt, name string, schema map[string]any) (string, error) {
+	return p.send(ctx, responsesRequest{
+		Model:              p.model,
+		Input:              message,
+		PreviousResponseID: p.previousResponseID,
+		Instructions:       systemPrompt,
+		Text:               &textOptions{Format: textFormat{Type: "json_schema", Name: name, Schema: schema, Strict: true}},
+	})
+}
+
+func (p *OpenAIProvider) send(ctx context.Context, body responsesRequest) (string, error) {
 	payload, err := json.Marshal(body)
 	if err != nil {
 		return "", fmt.Errorf("marshal: %w", err)
 	}
 
 	for attempt := 1; attempt <= 3; attempt++ {
-		req, reqErr := http.NewRequestWithContext(
-			ctx, http.MethodPost, openAIResponsesURL, bytes.NewReader(payload),
-		)
+		if err := p.gate.wait(ctx); err != nil {
+			return "", err
+		}
+		req, reqErr := p.newRequest(ctx, payload)
 		if reqErr != nil {
 			return "", fmt.Errorf("build request: %w", reqErr)
 		}
-		req.Header.Set("Authorization", "Bearer "+p.apiKey)
-		req.Header.Set("Content-Type", "application/json")
 
 		res, doErr := p.httpClient.Do(req)
 		if doErr != nil {
@@ -96,7 +225,7 @@ func (p *OpenAIProvider) Send(ctx context.Context, message, systemPrompt string)
 		}
 
 		if res.StatusCode == http.StatusTooManyRequests {
-			time.Sleep(time.Duration(attempt) * 2 * time.Second)
+			p.gate.backoff(retryAfter(res.Header, time.Duration(attempt)*2*time.Second))
 			continue
 		}
 		if res.StatusCode < 200 || res.StatusCode >= 300 {
@@ -110,18 +239,40 @@ func (p *OpenAIProvider) Send(ctx context.Context, message, systemPrompt string)
 		if jsonErr := json.Unmarshal(respBytes, &out); jsonErr != nil {
 			return "", fmt.Errorf("decode response: %w", jsonErr)
 		}
+		p.usage.mu.Lock()
+		p.usage.input += out.Usage.InputTokens
+		p.usage.output += out.Usage.OutputTokens
+		p.usage.mu.Unlock()
+
 		text := out.text()
 		if text == "" {
 			return "", fmt.Errorf("empty response from API — try again or check your input")
 		}
 		p.previousResponseID = out.ID
+		if out.truncated() {
+			return text, fmt.Errorf("%w (%s)", ErrTruncated, out.IncompleteDetails.Reason)
+		}
 		return text, nil
 	}
 	return "", fmt.Errorf("rate limited after 3 retries — wait a moment and try again")
 }
 
+func (p *OpenAIProvider) newRequest(ctx context.Context, payload []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/responses", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
 type responsesAPIResponse struct {
-	ID     string `json:"id"`
+	ID                string `json:"id"`
+	Status            string `json:"status"`
+	IncompleteDetails struct {
+		Reason string `json:"reason"`
+	} `json:"incomplete_details"`
 	Output []struct {
 		Content []struct {
 			Type string `json:"type"`
@@ -129,6 +280,16 @@ type responsesAPIResponse struct {
 		} `json:"content"`
 	} `json:"output"`
 	OutputText string `json:"output_text"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// truncated reports whether generation stopped at the output token limit
+// rather than finishing on its own.
+func (r responsesAPIResponse) truncated() bool {
+	return r.Status == "incomplete" && r.IncompleteDetails.Reason == "max_output_tokens"
 }
 
 func (r responsesAPIResponse) text() string {
@@ -148,3 +309,89 @@ func (r responsesAPIResponse) text() string {
 	}
 	return strings.TrimSpace(sb.String())
 }
+
+// capableModels are OpenAI model families known to support structured
+// outputs, function tools, and streaming on the Responses API. They skip
+// probing when talking to OpenAI itself.
+var capableModels = []string{"gpt-4o", "gpt-4.1", "gpt-5", "o3", "o4"}
+
+// Capabilities implements CapabilityProber. Known OpenAI models are
+// answered from a table; anything else, including every model behind
+// WithBaseURL, is probed with three tiny requests, once per provider and
+// its forks. A failed probe counts as unsupported.
+func (p *OpenAIProvider) Capabilities(ctx context.Context) Capabilities {
+	return p.caps.get(func() Capabilities {
+		if p.baseURL == openAIBaseURL {
+			for _, family := range capableModels {
+				if strings.HasPrefix(p.model, family) {
+					return Capabilities{StructuredOutput: true, ToolCalls: true, Streaming: true}
+				}
+			}
+		}
+		return p.probe(ctx)
+	})
+}
+
+func (p *OpenAIProvider) probe(ctx context.Context) Capabilities {
+	noStore := false
+	base := responsesRequest{Model: p.model, Input: "Reply with ok.", MaxOutputTokens: 16, Store: &noStore}
+
+	var caps Capabilities
+	structured := base
+	structured.Text = &textOptions{Format: textFormat{
+		Type: "json_schema", Name: "probe", Strict: true,
+		Schema: map[string]any{
+			"type":                 "object",
+			"properties":           map[string]any{"ok": map[string]any{"type": "boolean"}},
+			"required":             []any{"ok"},
+			"additionalProperties": false,
+		},
+	}}
+	if res, body := p.probeRequest(ctx, structured); res != nil && res.StatusCode/100 == 2 {
+		var out responsesAPIResponse
+		var reply map[string]any
+		caps.StructuredOutput = json.Unmarshal(body, &out) == nil && json.Unmarshal([]byte(out.text()), &reply) == nil
+	}
+
+	tools := base
+	tools.Tools = []functionTool{{
+		Type: "function", Name: "noop", Description: "Does nothing.",
+		Parameters: map[string]any{"type": "object", "properties": map[string]any{}},
+	}}
+	if res, _ := p.probeRequest(ctx, tools); res != nil {
+		caps.ToolCalls = res.StatusCode/100 == 2
+	}
+
+	stream := base
+	stream.Stream = true
+	if res, _ := p.probeRequest(ctx, stream); res != nil {
+		caps.Streaming = res.StatusCode/100 == 2 &&
+			strings.HasPrefix(res.Header.Get("Content-Type"), "text/event-stream")
+	}
+	return caps
+}
+
+// probeRequest sends one probe and returns the response with at most 64 KiB
+// of its body, or nil when the request failed outright.
+func (p *OpenAIProvider) probeRequest(ctx context.Context, body responsesRequest) (*http.Response, []byte) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil
+	}
+	req, err := p.newRequest(ctx, payload)
+	if err != nil {
+		return nil, nil
+	}
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, nil
+	}
+	defer res.Body.Close()
+	if body.Stream {
+		return res, nil
+	}
+	data, _ := io.ReadAll(io.LimitReader(res.Body, 64<<10))
+	return res, data
+}
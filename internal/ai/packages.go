@@ -0,0 +1,41 @@
+package ai
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// PackageScope is one member of an existing monorepo and the stack detected
+// for it. Packages are found on disk, not chosen in conversation.
+type PackageScope struct {
+	Path      string `json:"path"`
+	ProfileID string `json:"profile_id"`
+}
+
+// packageSlug turns a package path into an instruction file name stem,
+// e.g. "apps/web" -> "apps-web".
+func packageSlug(p string) string {
+	return strings.ReplaceAll(path.Clean(p), "/", "-")
+}
+
+// packageGuidance asks for one path-scoped instruction file per detected
+// package and a root overview, so each package follows its own stack.
+func packageGuidance(pkgs []PackageScope) string {
+	if len(pkgs) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("EXISTING MONOREPO PACKAGES:\n")
+	sb.WriteString("This repository already contains the packages below. For EACH one generate\n")
+	sb.WriteString(".github/instructions/<file>.instructions.md scoped to that package only, using the\n")
+	sb.WriteString("matching profile asset's idioms (not the root profile's) for code under that path:\n")
+	for _, p := range pkgs {
+		fmt.Fprintf(&sb, "- %s (profile.%s) -> .github/instructions/%s.instructions.md with applyTo: \"%s/**\"\n",
+			p.Path, p.ProfileID, packageSlug(p.Path), p.Path)
+	}
+	sb.WriteString("copilot-instructions.md MUST open with a \"Repository layout\" overview listing each\n")
+	sb.WriteString("package, its stack, and its role, and keep only cross-cutting rules at the root.\n")
+	sb.WriteString("The packages already exist: start.prompt.md must NOT re-run their scaffold commands.\n\n")
+	return sb.String()
+}
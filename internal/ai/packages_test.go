@@ -0,0 +1,42 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveContextAssets_IncludesPackageProfiles(t *testing.T) {
+	sel := Selection{
+		ProfileID: "go-service",
+		Packages: []PackageScope{
+			{Path: "apps/web", ProfileID: "typescript-sveltekit"},
+			{Path: "services/api", ProfileID: "go-service"},
+		},
+	}
+	assets, err := resolveContextAssets(sel)
+	if err != nil {
+		t.Fatalf("resolveContextAssets: %v", err)
+	}
+	counts := map[string]int{}
+	for _, a := range assets {
+		counts[a.ID]++
+	}
+	if counts["profile.typescript-sveltekit"] != 1 {
+		t.Error("package profile profile.typescript-sveltekit not included")
+	}
+	if counts["profile.go-service"] != 1 {
+		t.Errorf("profile.go-service included %d times, want 1", counts["profile.go-service"])
+	}
+}
+
+func TestPackageGuidance(t *testing.T) {
+	if got := packageGuidance(nil); got != "" {
+		t.Errorf("expected no guidance without packages, got %q", got)
+	}
+	got := packageGuidance([]PackageScope{{Path: "apps/web", ProfileID: "typescript-sveltekit"}})
+	for _, want := range []string{`applyTo: "apps/web/**"`, ".github/instructions/apps-web.instructions.md", "Repository layout"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("guidance missing %q:\n%s", want, got)
+		}
+	}
+}
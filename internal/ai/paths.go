@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// SanitizePath validates a file path emitted by the model and returns it in
+// clean, slash-separated, relative form. Absolute paths, drive letters, and
+// any ".." segment are rejected outright rather than cleaned away, because a
+// model asking to write outside the project is a sign of prompt injection.
+func SanitizePath(p string) (string, error) {
+	raw := strings.TrimSpace(p)
+	if raw == "" {
+		return "", fmt.Errorf("empty file path")
+	}
+	slashed := strings.ReplaceAll(raw, `\`, "/")
+	if strings.HasPrefix(slashed, "/") {
+		return "", fmt.Errorf("absolute file path %q", raw)
+	}
+	if len(slashed) >= 2 && slashed[1] == ':' {
+		return "", fmt.Errorf("absolute file path %q", raw)
+	}
+	for _, seg := range strings.Split(slashed, "/") {
+		if seg == ".." {
+			return "", fmt.Errorf("file path %q escapes the project directory", raw)
+		}
+	}
+	clean := path.Clean(slashed)
+	if clean == "." {
+		return "", fmt.Errorf("file path %q does not name a file", raw)
+	}
+	return clean, nil
+}
+
+// sanitizeFiles applies SanitizePath to every file, failing on the first
+// unsafe path.
+func sanitizeFiles(files []FileOutput) ([]FileOutput, error) {
+	out := make([]FileOutput, 0, len(files))
+	for _, f := range files {
+		clean, err := SanitizePath(f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("model returned an unsafe path: %w", err)
+		}
+		out = append(out, FileOutput{Path: clean, Content: f.Content})
+	}
+	return out, nil
+}
+
+// filterAllowedFiles splits files into those under one of roots and those
+// outside every root. Paths must already be sanitized.
+func filterAllowedFiles(files []FileOutput, roots []string) (kept, dropped []FileOutput) {
+	for _, f := range files {
+		if pathAllowed(f.Path, roots) {
+			kept = append(kept, f)
+		} else {
+			dropped = append(dropped, f)
+		}
+	}
+	return kept, dropped
+}
+
+// keepAllowed drops, with a warning, the files outside every root. It fails
+// when none are left.
+func (e *Engine) keepAllowed(files []FileOutput, roots []string) ([]FileOutput, error) {
+	kept, dropped := filterAllowedFiles(files, roots)
+	for _, f := range dropped {
+		e.warn(fmt.Sprintf("dropped %s — generated files may only be written to %s", f.Path, strings.Join(roots, ", ")))
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("model returned no files in allowed locations")
+	}
+	return kept, nil
+}
+
+func pathAllowed(p string, roots []string) bool {
+	for _, root := range roots {
+		if strings.HasSuffix(root, "/") {
+			if strings.HasPrefix(p, root) {
+				return true
+			}
+		} else if p == root {
+			return true
+		}
+	}
+	return false
+}
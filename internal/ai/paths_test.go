@@ -0,0 +1,65 @@
+package ai
+
+import "testing"
+
+func TestSanitizePath(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: ".github/copilot-instructions.md", want: ".github/copilot-instructions.md"},
+		{input: "  AGENTS.md ", want: "AGENTS.md"},
+		{input: "./.github//instructions/go.instructions.md", want: ".github/instructions/go.instructions.md"},
+		{input: `.github\prompts\start.prompt.md`, want: ".github/prompts/start.prompt.md"},
+		{input: "/etc/passwd", wantErr: true},
+		{input: `C:\Windows\system.ini`, wantErr: true},
+		{input: "../outside.md", wantErr: true},
+		{input: ".github/../../outside.md", wantErr: true},
+		{input: "docs/../AGENTS.md", wantErr: true},
+		{input: "", wantErr: true},
+		{input: ".", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := SanitizePath(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("SanitizePath(%q) = %q, want error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SanitizePath(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("SanitizePath(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFilterAllowedFiles(t *testing.T) {
+	files := []FileOutput{
+		{Path: ".github/copilot-instructions.md"},
+		{Path: ".github/instructions/go.instructions.md"},
+		{Path: "AGENTS.md"},
+		{Path: "fly.toml"},
+		{Path: "src/main.go"},
+		{Path: "AGENTS.md.bak"},
+		{Path: ".githubx/evil.md"},
+	}
+	roots := append(append([]string(nil), DefaultAllowedRoots...), "fly.toml")
+
+	kept, dropped := filterAllowedFiles(files, roots)
+	if len(kept) != 4 {
+		t.Errorf("kept %d files, want 4: %v", len(kept), kept)
+	}
+	if len(dropped) != 3 {
+		t.Fatalf("dropped %d files, want 3: %v", len(dropped), dropped)
+	}
+	for i, want := range []string{"src/main.go", "AGENTS.md.bak", ".githubx/evil.md"} {
+		if dropped[i].Path != want {
+			t.Errorf("dropped[%d] = %q, want %q", i, dropped[i].Path, want)
+		}
+	}
+}
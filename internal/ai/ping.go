@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// pingPrompt is the smallest useful request: it measures the round trip,
+// not generation speed.
+const pingPrompt = "Reply with the single word OK."
+
+// PingResult is the outcome of probing a provider.
+type PingResult struct {
+	Probes    int
+	Latencies []time.Duration // successful probes, fastest first
+	Errors    []error
+}
+
+// Available is the fraction of probes that succeeded.
+func (r PingResult) Available() float64 {
+	if r.Probes == 0 {
+		return 0
+	}
+	return float64(len(r.Latencies)) / float64(r.Probes)
+}
+
+// Percentile returns the nearest-rank latency at p (0–100) over the
+// successful probes, or 0 when none succeeded.
+func (r PingResult) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p/100*float64(len(r.Latencies)))) - 1
+	return r.Latencies[min(max(i, 0), len(r.Latencies)-1)]
+}
+
+// Ping sends probes one at a time, each on a fresh fork where the provider
+// supports it, and records how long each took.
+func Ping(ctx context.Context, p Provider, probes int) PingResult {
+	res := PingResult{Probes: probes}
+	for i := 0; i < probes; i++ {
+		sender := p
+		if f, ok := p.(Forker); ok {
+			sender = f.Fork()
+		}
+		start := time.Now()
+		if _, err := sender.Send(ctx, pingPrompt, ""); err != nil {
+			res.Errors = append(res.Errors, err)
+			continue
+		}
+		res.Latencies = append(res.Latencies, time.Since(start))
+	}
+	sort.Slice(res.Latencies, func(i, j int) bool { return res.Latencies[i] < res.Latencies[j] })
+	return res
+}
@@ -0,0 +1,37 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPing(t *testing.T) {
+	p := &scriptedProvider{errs: []error{nil, errors.New("503"), nil}}
+	res := Ping(context.Background(), p, 3)
+	if len(p.messages) != 3 {
+		t.Fatalf("sent %d probes, want 3", len(p.messages))
+	}
+	if len(res.Latencies) != 2 || len(res.Errors) != 1 {
+		t.Errorf("got %d ok, %d failed; want 2 ok, 1 failed", len(res.Latencies), len(res.Errors))
+	}
+	if got := res.Available(); got < 0.66 || got > 0.67 {
+		t.Errorf("Available = %.2f, want 2/3", got)
+	}
+}
+
+func TestPingResult_Percentile(t *testing.T) {
+	var res PingResult
+	for i := 1; i <= 20; i++ {
+		res.Latencies = append(res.Latencies, time.Duration(i)*time.Millisecond)
+	}
+	for p, want := range map[float64]time.Duration{50: 10 * time.Millisecond, 95: 19 * time.Millisecond, 100: 20 * time.Millisecond, 0: time.Millisecond} {
+		if got := res.Percentile(p); got != want {
+			t.Errorf("Percentile(%v) = %v, want %v", p, got, want)
+		}
+	}
+	if got := (PingResult{}).Percentile(50); got != 0 {
+		t.Errorf("no successful probes: Percentile = %v, want 0", got)
+	}
+}
@@ -0,0 +1,83 @@
+package ai
+
+import "fmt"
+
+// PostProcessor transforms generated files after validation and repair, and
+// before they are rendered into other targets and written (see Relocator). It returns the
+// full set of files, so it may modify, add, or drop them.
+type PostProcessor interface {
+	Name() string
+	Process(files []FileOutput, sel *Selection) ([]FileOutput, error)
+}
+
+// A Relocator is a PostProcessor that moves files. Relocators run after
+// the files are rendered into each output target, because the targets find
+// the Copilot files at their default paths.
+type Relocator interface {
+	PostProcessor
+	Relocates()
+}
+
+// WithPostProcessors appends processors that run, in order, on every
+// generation.
+func WithPostProcessors(procs ...PostProcessor) EngineOption {
+	return func(e *Engine) {
+		e.postProcessors = append(e.postProcessors, procs...)
+	}
+}
+
+// finish runs the post-processors on the Copilot layout, renders the
+// requested targets from it, and then lets the relocators move every
+// target's files. Files the processors or targets add must stay within
+// roots, or the targets' own outputs. A scoped run leaves out the files
+// that combine every concern, since it only generated some of them.
+func (e *Engine) finish(projectName string, files []FileOutput, sel *Selection, roots []string) ([]FileOutput, error) {
+	var edits, moves []PostProcessor
+	for _, p := range e.postProcessors {
+		if _, ok := p.(Relocator); ok {
+			moves = append(moves, p)
+		} else {
+			edits = append(edits, p)
+		}
+	}
+	files, err := postProcess(files, sel, edits)
+	if err != nil {
+		return nil, err
+	}
+	if files, err = e.keepAllowed(files, roots); err != nil {
+		return nil, err
+	}
+	if files, err = renderTargets(projectName, files, e.targets); err != nil {
+		return nil, err
+	}
+	if files, err = e.keepAllowed(files, append(append([]string(nil), roots...), ExpectedOutputs(e.targets)...)); err != nil {
+		return nil, err
+	}
+	if len(e.scope) > 0 {
+		kept := files[:0]
+		for _, f := range files {
+			if combinedOutput(f.Path, e.targets) {
+				e.warn(fmt.Sprintf("left %s as it is — it combines every concern, so only a full run regenerates it", f.Path))
+				continue
+			}
+			kept = append(kept, f)
+		}
+		files = kept
+	}
+	return postProcess(files, sel, moves)
+}
+
+// postProcess runs each processor in turn. Paths a processor introduces are
+// sanitized like model output, so a processor can't write outside the project.
+func postProcess(files []FileOutput, sel *Selection, procs []PostProcessor) ([]FileOutput, error) {
+	for _, p := range procs {
+		out, err := p.Process(files, sel)
+		if err != nil {
+			return nil, fmt.Errorf("post-processor %s: %w", p.Name(), err)
+		}
+		if files, err = sanitizeFiles(out); err != nil {
+			return nil, fmt.Errorf("post-processor %s: %w", p.Name(), err)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,146 @@
+package ai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type funcProcessor struct {
+	name string
+	fn   func([]FileOutput) ([]FileOutput, error)
+}
+
+func (p funcProcessor) Name() string { return p.name }
+
+func (p funcProcessor) Process(files []FileOutput, _ *Selection) ([]FileOutput, error) {
+	return p.fn(files)
+}
+
+func TestPostProcess(t *testing.T) {
+	upper := funcProcessor{"upper", func(files []FileOutput) ([]FileOutput, error) {
+		out := make([]FileOutput, len(files))
+		for i, f := range files {
+			out[i] = FileOutput{Path: f.Path, Content: strings.ToUpper(f.Content)}
+		}
+		return out, nil
+	}}
+	add := funcProcessor{"add", func(files []FileOutput) ([]FileOutput, error) {
+		return append(files, FileOutput{Path: "./docs/extra.md", Content: "x"}), nil
+	}}
+
+	out, err := postProcess([]FileOutput{{Path: "AGENTS.md", Content: "a"}}, nil, []PostProcessor{upper, add})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 || out[0].Content != "A" || out[1].Path != "docs/extra.md" {
+		t.Errorf("got %+v", out)
+	}
+}
+
+func TestPostProcess_Errors(t *testing.T) {
+	fail := funcProcessor{"fail", func([]FileOutput) ([]FileOutput, error) { return nil, errors.New("boom") }}
+	if _, err := postProcess(nil, nil, []PostProcessor{fail}); err == nil || !strings.Contains(err.Error(), "post-processor fail: boom") {
+		t.Errorf("err = %v", err)
+	}
+	escape := funcProcessor{"escape", func([]FileOutput) ([]FileOutput, error) {
+		return []FileOutput{{Path: "../outside.md"}}, nil
+	}}
+	if _, err := postProcess(nil, nil, []PostProcessor{escape}); err == nil {
+		t.Error("expected error for path outside the project")
+	}
+}
+
+type funcRelocator struct{ funcProcessor }
+
+func (funcRelocator) Relocates() {}
+
+func TestFinish_RelocatesAfterTargets(t *testing.T) {
+	var sawPaths []string
+	edit := funcProcessor{"edit", func(files []FileOutput) ([]FileOutput, error) {
+		for _, f := range files {
+			sawPaths = append(sawPaths, f.Path)
+		}
+		return files, nil
+	}}
+	move := funcRelocator{funcProcessor{"layout", func(files []FileOutput) ([]FileOutput, error) {
+		out := make([]FileOutput, len(files))
+		for i, f := range files {
+			out[i] = f
+			if strings.HasPrefix(f.Path, ".github/") {
+				out[i].Path = "docs/ai/" + strings.TrimPrefix(f.Path, ".github/")
+			} else if f.Path == "AGENTS.md" {
+				out[i].Path = "AGENT_GUIDE.md"
+			}
+		}
+		return out, nil
+	}}}
+
+	e := NewEngine(nil, WithPostProcessors(move, edit), WithTargets("copilot", "cursor", "claude"))
+	out, err := e.finish("demo", copilotLayout, nil, DefaultAllowedRoots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(sawPaths, ",") != ".github/copilot-instructions.md,.github/instructions/testing.instructions.md,.github/instructions/architecture.instructions.md,AGENTS.md,.github/prompts/start.prompt.md" {
+		t.Errorf("processors before targets saw %v", sawPaths)
+	}
+	paths := map[string]bool{}
+	for _, f := range out {
+		paths[f.Path] = true
+	}
+	for _, want := range []string{"docs/ai/copilot-instructions.md", "AGENT_GUIDE.md", ".cursor/rules/testing.mdc", "CLAUDE.md"} {
+		if !paths[want] {
+			t.Errorf("missing %s in %v", want, paths)
+		}
+	}
+
+	// Without copilot, the moved files must still be recognized and left out.
+	out, err = NewEngine(nil, WithPostProcessors(move), WithTargets("cursor")).finish("demo", copilotLayout, nil, DefaultAllowedRoots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range out {
+		if strings.HasPrefix(f.Path, "docs/ai/") || f.Path == ".github/copilot-instructions.md" {
+			t.Errorf("copilot file %s kept without the copilot target", f.Path)
+		}
+	}
+	if len(out) == 0 {
+		t.Error("cursor rendered nothing from the layout")
+	}
+}
+
+func TestFinish_RechecksAllowedRoots(t *testing.T) {
+	add := funcProcessor{"add", func(files []FileOutput) ([]FileOutput, error) {
+		return append(files, FileOutput{Path: "Makefile", Content: "all:"}), nil
+	}}
+	var warnings []string
+	e := NewEngine(nil, WithPostProcessors(add), WithTargets("cursor"),
+		WithWarningHandler(func(msg string) { warnings = append(warnings, msg) }))
+	out, err := e.finish("demo", copilotLayout, nil, DefaultAllowedRoots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range out {
+		if f.Path == "Makefile" {
+			t.Error("post-processor wrote outside the allowed roots")
+		}
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "dropped Makefile") {
+		t.Errorf("warnings = %v", warnings)
+	}
+	if len(out) == 0 || !strings.HasPrefix(out[0].Path, ".cursor/") && out[0].Path != "AGENTS.md" {
+		t.Errorf("target outputs should pass the check, got %v", out)
+	}
+
+	out, err = e.finish("demo", copilotLayout, nil, append([]string{"Makefile"}, DefaultAllowedRoots...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, f := range out {
+		found = found || f.Path == "Makefile"
+	}
+	if !found {
+		t.Error("a configured root should let the processor's file through")
+	}
+}
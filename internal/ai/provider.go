@@ -1,6 +1,14 @@
 package ai
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrTruncated is returned (wrapped) alongside the partial text when a reply
+// was cut off by the output token limit. Callers may continue the thread to
+// get the rest.
+var ErrTruncated = errors.New("response truncated at the output token limit")
 
 // Provider abstracts an LLM backend. Implementations must support stateful
 // conversation threading — each call may reference prior context.
@@ -8,5 +16,14 @@ type Provider interface {
 	// Send sends a user message and returns the assistant reply.
 	// systemPrompt is injected as instructions when non-empty.
 	// The provider is responsible for maintaining conversational state.
+	// A reply cut off by the output limit returns the partial text with an
+	// error wrapping ErrTruncated.
 	Send(ctx context.Context, message, systemPrompt string) (string, error)
 }
+
+// Forker is implemented by providers that can branch their conversation.
+// A fork starts from the current conversational state, and calls on it
+// never advance the original thread or other forks.
+type Forker interface {
+	Fork() Provider
+}
@@ -0,0 +1,102 @@
+package ai
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/scaffold"
+)
+
+// ReadinessCheck decides whether the conversation has reached a stack
+// decision. reply is the advisor's latest reply and turns counts the chat
+// turns so far, the opening one included.
+type ReadinessCheck interface {
+	Ready(ctx context.Context, e *Engine, reply string, turns int) (bool, error)
+}
+
+// TokenCheck is ready when the reply carries ReadyToken, as the system
+// prompt asks the model to emit in Phase 3.
+type TokenCheck struct{}
+
+func (TokenCheck) Ready(_ context.Context, _ *Engine, reply string, _ int) (bool, error) {
+	return IsReady(reply), nil
+}
+
+// ConfirmationCheck catches a Phase 3 confirmation that forgot the token,
+// which smaller local models often do. A reply that names a catalog
+// profile and asks nothing is put to the model as a yes/no question on a
+// forked thread; other replies cost no extra call.
+type ConfirmationCheck struct{}
+
+// confirmQuestion is the classifier call. It follows the conversation, so
+// the model judges its own last reply in context.
+const confirmQuestion = "Answer with exactly one word, YES or NO. Did your last reply confirm the " +
+	"user's final stack choice, so that the conversation is finished and files can be generated?"
+
+func (ConfirmationCheck) Ready(ctx context.Context, e *Engine, reply string, _ int) (bool, error) {
+	if strings.Contains(reply, "?") || !namesProfile(reply) {
+		return false, nil
+	}
+	answer, err := e.fork().Send(ctx, confirmQuestion, "")
+	if err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(answer)), "YES"), nil
+}
+
+var profileIDs = func() *regexp.Regexp {
+	ids := make([]string, len(scaffold.Profiles))
+	for i, p := range scaffold.Profiles {
+		ids[i] = regexp.QuoteMeta(p.ID)
+	}
+	return regexp.MustCompile(`(?:^|[^\w-])(?:` + strings.Join(ids, "|") + `)(?:$|[^\w-])`)
+}()
+
+func namesProfile(reply string) bool {
+	return profileIDs.MatchString(reply)
+}
+
+// TurnLimit is ready once the conversation has run Max turns, so a model
+// that never settles can't keep the user talking forever.
+type TurnLimit struct {
+	Max int
+}
+
+func (l TurnLimit) Ready(_ context.Context, _ *Engine, _ string, turns int) (bool, error) {
+	return l.Max > 0 && turns >= l.Max, nil
+}
+
+// defaultMaxTurns is where the default TurnLimit stops the conversation:
+// well past the three phases the system prompt asks for.
+const defaultMaxTurns = 12
+
+// DefaultReadiness is the token, then the confirmation classifier, then a
+// turn limit.
+func DefaultReadiness() []ReadinessCheck {
+	return []ReadinessCheck{TokenCheck{}, ConfirmationCheck{}, TurnLimit{Max: defaultMaxTurns}}
+}
+
+// WithReadiness replaces the checks Ready runs, in order.
+func WithReadiness(checks ...ReadinessCheck) EngineOption {
+	return func(e *Engine) {
+		e.readiness = checks
+	}
+}
+
+// Ready reports whether reply, the latest from Chat, ends the conversation.
+// The first check that says so wins; a failing check is skipped so a
+// broken classifier call never blocks the user.
+func (e *Engine) Ready(ctx context.Context, reply string) bool {
+	for _, c := range e.readiness {
+		ok, err := c.Ready(ctx, e, reply, e.turns)
+		if err != nil {
+			e.warn("readiness check failed: " + err.Error())
+			continue
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
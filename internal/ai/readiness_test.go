@@ -0,0 +1,73 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestReady_Token(t *testing.T) {
+	p := &scriptedProvider{}
+	e := NewEngine(p)
+	if !e.Ready(context.Background(), "Going with `go-service`.\nREADY_TO_GENERATE") {
+		t.Error("the token should end the conversation")
+	}
+	if len(p.messages) != 0 {
+		t.Errorf("the token needs no classifier call, got %d", len(p.messages))
+	}
+}
+
+func TestReady_ConfirmationClassifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		reply     string
+		answer    string
+		want      bool
+		wantCalls int
+	}{
+		{"confirmation without token", "Great — going with `elixir-phoenix` and the observability add-on.", "YES", true, 1},
+		{"classifier says no", "Going with elixir-phoenix sounds right to me.", "No.", false, 1},
+		{"options ask a question", "1. `elixir-phoenix` ★ 2. `go-service`. Which one do you want?", "YES", false, 0},
+		{"scope questions name no profile", "Should results persist after the session.", "YES", false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &scriptedProvider{replies: []string{tt.answer}}
+			e := NewEngine(p)
+			if got := e.Ready(context.Background(), tt.reply); got != tt.want {
+				t.Errorf("Ready = %v, want %v", got, tt.want)
+			}
+			if len(p.messages) != tt.wantCalls {
+				t.Errorf("classifier calls = %d, want %d", len(p.messages), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestReady_TurnLimit(t *testing.T) {
+	p := &scriptedProvider{}
+	e := NewEngine(p, WithReadiness(TokenCheck{}, TurnLimit{Max: 2}))
+	ctx := context.Background()
+
+	reply, _ := e.Chat(ctx, "a voting app")
+	if e.Ready(ctx, reply) {
+		t.Fatal("ready after one turn")
+	}
+	reply, _ = e.Chat(ctx, "with rounds")
+	if !e.Ready(ctx, reply) {
+		t.Error("the turn limit should end the conversation")
+	}
+}
+
+func TestReady_FailingCheckIsSkipped(t *testing.T) {
+	p := &scriptedProvider{errs: []error{errors.New("offline")}}
+	var warnings []string
+	e := NewEngine(p, WithWarningHandler(func(msg string) { warnings = append(warnings, msg) }))
+
+	if e.Ready(context.Background(), "Going with `go-service`.") {
+		t.Error("a failed classifier call should not count as ready")
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v", warnings)
+	}
+}
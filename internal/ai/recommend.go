@@ -0,0 +1,287 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/scaffold"
+)
+
+// decisionRule maps a use case to the profiles that fit it, best first.
+// The same rules feed the conversation prompt's decision map and offline
+// recommendations.
+type decisionRule struct {
+	UseCase  string   // as shown in the prompt
+	Keywords []string // lowercase terms that signal the use case in a description
+	Profiles []string
+	Starred  bool // the first profile is the top pick
+}
+
+// decisionMap holds the built-in rules; see DefaultDecisionMap.
+var decisionMap = []decisionRule{
+	{"real-time/live/presence/chat/voting/collaborative", []string{"real-time", "realtime", "live", "presence", "chat", "voting", "collaborative", "multiplayer", "whiteboard", "dashboard"}, []string{"elixir-phoenix", "typescript-sveltekit"}, true},
+	{"full-stack JS web/SSR/content", []string{"javascript", "typescript", "full-stack", "ssr", "content", "blog", "website", "landing"}, []string{"typescript-sveltekit", "typescript-nextjs"}, true},
+	{"CRUD/MVP/admin/content platform", []string{"crud", "mvp", "admin", "saas", "marketplace", "booking", "inventory"}, []string{"ruby-rails", "python-django"}, true},
+	{"React required/Vercel", []string{"react", "vercel"}, []string{"typescript-nextjs"}, false},
+	{"Vue team/Vue ecosystem", []string{"vue", "nuxt"}, []string{"typescript-nuxt"}, false},
+	{"Node.js API/microservice", []string{"node", "node.js", "microservice"}, []string{"typescript-fastify"}, false},
+	{"high-perf API/CLI/infra", []string{"high-perf", "cli", "command-line", "infra", "infrastructure", "proxy", "api"}, []string{"go-service", "rust-axum"}, true},
+	{"enterprise API/C#", []string{"enterprise", "c#", ".net", "azure"}, []string{"dotnet-api"}, false},
+	{"enterprise API/Java/JVM", []string{"enterprise", "java", "jvm", "kotlin"}, []string{"java-spring"}, false},
+	{"Python API/ML/data", []string{"python", "ml", "machine learning", "llm", "ai", "model", "data pipeline"}, []string{"python-fastapi"}, false},
+	{"Python full-stack/admin/CMS", []string{"python", "cms", "admin"}, []string{"python-django"}, false},
+	{"native mobile", []string{"mobile", "ios", "android", "app store", "phone"}, []string{"dart-flutter"}, false},
+	{"iOS only/native SwiftUI", []string{"ios", "iphone", "ipad", "swiftui", "apple"}, []string{"ios-swiftui"}, false},
+	{"Android only/native Kotlin UI", []string{"android", "kotlin", "jetpack", "compose", "google play"}, []string{"android-compose"}, false},
+	{"browser extension", []string{"browser extension", "chrome extension", "firefox add-on", "manifest v3", "mv3"}, []string{"browser-extension"}, false},
+	{"desktop app", []string{"desktop", "electron", "windows", "macos", "linux", "tray", "offline-first"}, []string{"electron"}, false},
+	{"C++ systems team", []string{"c++", "cpp", "cmake", "native library"}, []string{"cpp-service"}, false},
+	{"infrastructure/platform team", []string{"terraform", "kubernetes", "k8s", "infrastructure", "platform team", "gitops", "helm"}, []string{"platform-infra"}, false},
+	{"ML research/notebooks", []string{"notebook", "notebooks", "jupyter", "research", "experiments", "pytorch", "training", "kaggle"}, []string{"python-ml"}, false},
+	{"game development", []string{"game", "godot", "gdscript", "gamedev", "indie game", "platformer"}, []string{"godot"}, false},
+	{"data pipelines/analytics engineering", []string{"dbt", "airflow", "dagster", "etl", "elt", "warehouse", "data pipeline", "analytics engineering"}, []string{"data-dbt"}, false},
+	{"lightweight TypeScript API on Bun/edge", []string{"bun", "hono", "edge", "lightweight", "bff"}, []string{"bun-hono"}, false},
+	{"Deno runtime", []string{"deno", "fresh", "islands"}, []string{"deno-fresh"}, false},
+	{"mobile with a React team", []string{"react native", "react-native", "expo"}, []string{"react-native-expo"}, false},
+	{"perf-critical systems", []string{"performance", "perf-critical", "systems", "low-latency", "embedded"}, []string{"rust-axum", "go-service"}, true},
+	{"PHP", []string{"php", "laravel"}, []string{"laravel"}, false},
+	{"Swift server/shared with iOS app", []string{"swift", "vapor", "ios"}, []string{"swift-vapor"}, false},
+}
+
+// DecisionMap is the use-case table stack recommendations follow: the
+// built-in rules plus any organization overrides and profile weights.
+type DecisionMap struct {
+	rules   []decisionRule
+	org     map[int]bool // indexes of rules an override added or replaced
+	weights map[string]float64
+}
+
+// DefaultDecisionMap returns the built-in decision map.
+func DefaultDecisionMap() *DecisionMap {
+	return &DecisionMap{rules: append([]decisionRule(nil), decisionMap...), org: map[int]bool{}}
+}
+
+// Override sets the profiles, best first, for a use case. A use case that
+// matches a built-in one (ignoring case) replaces its picks; any other is
+// added. Keywords, when given, replace the use case's offline keywords.
+func (m *DecisionMap) Override(useCase string, profiles, keywords []string) error {
+	if strings.TrimSpace(useCase) == "" || len(profiles) == 0 {
+		return fmt.Errorf("decision override needs a use case and at least one profile")
+	}
+	for _, id := range profiles {
+		if scaffold.FindProfile(id) == nil {
+			return fmt.Errorf("decision override %q: unknown profile %q", useCase, id)
+		}
+	}
+	rule := decisionRule{UseCase: useCase, Keywords: lowerAll(keywords), Profiles: profiles, Starred: len(profiles) > 1}
+	for i, r := range m.rules {
+		if strings.EqualFold(r.UseCase, useCase) {
+			if len(rule.Keywords) == 0 {
+				rule.Keywords = r.Keywords
+			}
+			rule.UseCase = r.UseCase
+			m.rules[i], m.org[i] = rule, true
+			return nil
+		}
+	}
+	if len(rule.Keywords) == 0 {
+		rule.Keywords = lowerAll(strings.Split(useCase, "/"))
+	}
+	m.rules = append(m.rules, rule)
+	m.org[len(m.rules)-1] = true
+	return nil
+}
+
+// Weigh scales how strongly a profile is recommended: above 1 favours it,
+// below 1 disfavours it, and 0 rules it out.
+func (m *DecisionMap) Weigh(profileID string, weight float64) error {
+	if scaffold.FindProfile(profileID) == nil {
+		return fmt.Errorf("decision weight: unknown profile %q", profileID)
+	}
+	if weight < 0 {
+		return fmt.Errorf("decision weight for %s must not be negative", profileID)
+	}
+	if m.weights == nil {
+		m.weights = make(map[string]float64)
+	}
+	m.weights[profileID] = weight
+	return nil
+}
+
+func (m *DecisionMap) weight(profileID string) float64 {
+	if w, ok := m.weights[profileID]; ok {
+		return w
+	}
+	return 1
+}
+
+// promptSection renders the map, and any organization preferences, for the
+// conversation prompt.
+func (m *DecisionMap) promptSection() string {
+	var sb strings.Builder
+	sb.WriteString("DECISION MAP (★ = your top pick for that use case):\n")
+	for i, r := range m.rules {
+		picks := strings.Join(r.Profiles, " | ")
+		if r.Starred {
+			picks = "★ " + picks
+		}
+		sb.WriteString(r.UseCase + " -> " + picks)
+		if m.org[i] {
+			sb.WriteString(" (org standard)")
+		}
+		sb.WriteByte('\n')
+	}
+
+	ids := make([]string, 0, len(m.weights))
+	for id := range m.weights {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var prefs []string
+	for _, id := range ids {
+		switch w := m.weights[id]; {
+		case w == 0:
+			prefs = append(prefs, "- never recommend "+id)
+		case w > 1:
+			prefs = append(prefs, fmt.Sprintf("- prefer %s (weight %.1f)", id, w))
+		case w < 1:
+			prefs = append(prefs, fmt.Sprintf("- recommend %s only when it clearly fits better (weight %.1f)", id, w))
+		}
+	}
+	if len(prefs) > 0 || len(m.org) > 0 {
+		sb.WriteString("\nORG PREFERENCES — this organization's standards; follow them over the defaults above:\n")
+		if len(m.org) > 0 {
+			sb.WriteString("- use-case lines marked (org standard) are the org's chosen stacks\n")
+		}
+		sb.WriteString(strings.Join(prefs, "\n"))
+		if len(prefs) > 0 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+func lowerAll(words []string) []string {
+	var out []string
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// Recommendation is one ranked profile suggestion.
+type Recommendation struct {
+	ProfileID string  `json:"profile_id"`
+	Score     float64 `json:"score"` // 0–1, relative fit
+	Rationale string  `json:"rationale"`
+}
+
+// Recommend ranks profiles for a project description with one model call.
+// Unknown profile IDs in the reply are dropped.
+func (e *Engine) Recommend(ctx context.Context, description string) ([]Recommendation, error) {
+	if strings.TrimSpace(description) == "" {
+		return nil, fmt.Errorf("empty description")
+	}
+	prompt := "Rank the catalog stacks for this project:\n\n" + strings.TrimSpace(description) + "\n\n" +
+		"Use the decision map and layer taxonomy. Return ONLY valid JSON — no markdown, no prose:\n" +
+		"{\"recommendations\": [{\"profile_id\": \"<catalog id>\", \"score\": 0.0, \"rationale\": \"one sentence\"}]}\n\n" +
+		"List 1-3 profiles, best first. score is fit from 0 to 1."
+	raw, err := e.provider.Send(ctx, prompt, conversationSystemPrompt(e.decisions, e.tone))
+	if err != nil {
+		return nil, err
+	}
+	var reply struct {
+		Recommendations []Recommendation `json:"recommendations"`
+	}
+	if err := json.Unmarshal([]byte(jsonObject(raw)), &reply); err != nil {
+		return nil, fmt.Errorf("parse recommendations: %w", err)
+	}
+	var recs []Recommendation
+	for _, r := range reply.Recommendations {
+		r.ProfileID = strings.TrimPrefix(strings.TrimSpace(r.ProfileID), "profile.")
+		if scaffold.FindProfile(r.ProfileID) == nil || e.decisions.weight(r.ProfileID) == 0 {
+			continue
+		}
+		recs = append(recs, r)
+	}
+	if len(recs) == 0 {
+		return nil, fmt.Errorf("no catalog profiles in the reply")
+	}
+	sort.SliceStable(recs, func(i, j int) bool { return recs[i].Score > recs[j].Score })
+	return recs, nil
+}
+
+// RecommendOffline ranks profiles by matching the description against the
+// map's keywords, scaled by profile weights. No model is involved, so it
+// only knows what the keywords say; nil means nothing matched.
+func (m *DecisionMap) RecommendOffline(description string) []Recommendation {
+	text := " " + strings.ToLower(description) + " "
+	scores := make(map[string]float64)
+	reasons := make(map[string][]string)
+	for _, r := range m.rules {
+		var hits []string
+		for _, kw := range r.Keywords {
+			if containsWord(text, kw) {
+				hits = append(hits, kw)
+			}
+		}
+		if len(hits) == 0 {
+			continue
+		}
+		for i, id := range r.Profiles {
+			weight := 1.0
+			if i > 0 {
+				weight = 0.6 // runner-up for this use case
+			}
+			scores[id] += float64(len(hits)) * weight * m.weight(id)
+			reasons[id] = append(reasons[id], fmt.Sprintf("%s (%s)", r.UseCase, strings.Join(hits, ", ")))
+		}
+	}
+	var best float64
+	for _, s := range scores {
+		best = max(best, s)
+	}
+	if best == 0 {
+		return nil
+	}
+	recs := make([]Recommendation, 0, len(scores))
+	for _, p := range scaffold.Profiles {
+		s, ok := scores[p.ID]
+		if !ok || s == 0 {
+			continue
+		}
+		recs = append(recs, Recommendation{
+			ProfileID: p.ID,
+			Score:     s / best,
+			Rationale: "matches " + strings.Join(reasons[p.ID], "; "),
+		})
+	}
+	// Profiles are listed by recommendation strength, so ties keep that order.
+	sort.SliceStable(recs, func(i, j int) bool { return recs[i].Score > recs[j].Score })
+	return recs
+}
+
+// containsWord reports whether kw appears in text, which must be padded
+// with spaces, without being part of a longer word.
+func containsWord(text, kw string) bool {
+	for i := 0; ; {
+		j := strings.Index(text[i:], kw)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(kw)
+		if !isWordByte(text[start-1]) && !isWordByte(text[end]) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9'
+}
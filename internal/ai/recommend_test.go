@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/scaffold"
+)
+
+func TestDecisionMapProfilesExist(t *testing.T) {
+	for _, r := range decisionMap {
+		for _, id := range r.Profiles {
+			if scaffold.FindProfile(id) == nil {
+				t.Errorf("%q maps to unknown profile %q", r.UseCase, id)
+			}
+		}
+	}
+}
+
+func TestRecommendOffline(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"a collaborative whiteboard", "elixir-phoenix"},
+		{"A CLI for our infra team", "go-service"},
+		{"Vue storefront", "typescript-nuxt"},
+		{"an email digest", ""}, // "ai" inside a word must not match
+	}
+	for _, tt := range tests {
+		recs := DefaultDecisionMap().RecommendOffline(tt.description)
+		got := ""
+		if len(recs) > 0 {
+			got = recs[0].ProfileID
+			if recs[0].Score != 1 {
+				t.Errorf("%q: top score = %v, want 1", tt.description, recs[0].Score)
+			}
+		}
+		if got != tt.want {
+			t.Errorf("RecommendOffline(%q) top = %q, want %q", tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestDecisionMapOverrides(t *testing.T) {
+	m := DefaultDecisionMap()
+	if err := m.Override("Enterprise API/C#", []string{"java-spring", "dotnet-api"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Override("internal tools", []string{"ruby-rails"}, []string{"Backoffice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Weigh("laravel", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Override("x", []string{"cobol-cics"}, nil); err == nil {
+		t.Error("unknown profile should be rejected")
+	}
+
+	prompt := m.promptSection()
+	for _, want := range []string{
+		"enterprise API/C# -> ★ java-spring | dotnet-api (org standard)",
+		"internal tools -> ruby-rails (org standard)",
+		"- never recommend laravel",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(DefaultDecisionMap().promptSection(), "ORG PREFERENCES") {
+		t.Error("default map should carry no org preferences")
+	}
+
+	if recs := m.RecommendOffline("an enterprise API for billing"); len(recs) == 0 || recs[0].ProfileID != "java-spring" {
+		t.Errorf("enterprise recs = %+v, want java-spring first", recs)
+	}
+	if recs := m.RecommendOffline("a backoffice tool"); len(recs) == 0 || recs[0].ProfileID != "ruby-rails" {
+		t.Errorf("backoffice recs = %+v, want ruby-rails first", recs)
+	}
+	for _, r := range m.RecommendOffline("a PHP shop") {
+		if r.ProfileID == "laravel" {
+			t.Error("laravel recommended despite weight 0")
+		}
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	p := &scriptedProvider{replies: []string{"```json\n" + `{"recommendations":[
+		{"profile_id":"typescript-sveltekit","score":0.6,"rationale":"JS"},
+		{"profile_id":"profile.elixir-phoenix","score":0.9,"rationale":"live"},
+		{"profile_id":"react-native","score":0.5,"rationale":"not in catalog"}]}` + "\n```"}}
+	recs, err := NewEngine(p).Recommend(context.Background(), "a collaborative whiteboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 || recs[0].ProfileID != "elixir-phoenix" || recs[1].ProfileID != "typescript-sveltekit" {
+		t.Errorf("recs = %+v", recs)
+	}
+}
@@ -0,0 +1,106 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/validate"
+)
+
+// maxRepairAttempts bounds how many corrective follow-ups are sent when the
+// generated files fail validation. Each attempt is a full model round trip,
+// so this stays small; in practice one correction fixes nearly everything.
+const maxRepairAttempts = 2
+
+// validateFiles runs every generated file through the instruction validator
+// and returns the errors. Warnings don't justify another model round trip.
+func validateFiles(files []FileOutput) []validate.Diagnostic {
+	var diags []validate.Diagnostic
+	for _, f := range files {
+		diags = append(diags, validate.Errors(validate.File(f.Path, f.Content))...)
+	}
+	return diags
+}
+
+// repairFiles asks the model to fix files that fail validation, replacing
+// them with the corrected versions it returns. It gives up after
+// maxRepairAttempts and reports whatever is still broken, so malformed
+// frontmatter or globs never reach the disk.
+func (e *Engine) repairFiles(ctx context.Context, files []FileOutput) ([]FileOutput, error) {
+	diags := validateFiles(files)
+	for attempt := 1; len(diags) > 0 && attempt <= maxRepairAttempts; attempt++ {
+		e.warn(fmt.Sprintf("generated files failed validation (%d problem(s)) — asking for a correction (attempt %d/%d)",
+			len(diags), attempt, maxRepairAttempts))
+
+		raw, err := e.sendFiles(ctx, e.fork(), repairPrompt(diags, files))
+		if err != nil {
+			return nil, err
+		}
+		fixed, err := sanitizeFiles(parseFileOutput(raw))
+		if err != nil {
+			return nil, err
+		}
+		files = replaceFiles(files, fixed)
+		diags = validateFiles(files)
+	}
+	if len(diags) > 0 {
+		lines := make([]string, len(diags))
+		for i, d := range diags {
+			lines[i] = d.String()
+		}
+		return nil, fmt.Errorf("generated files are still invalid after %d correction(s):\n  %s",
+			maxRepairAttempts, strings.Join(lines, "\n  "))
+	}
+	return files, nil
+}
+
+// repairPrompt lists the validation problems and asks for only the affected
+// files back, in the same block format as the original generation. The
+// broken files are quoted in full because each file may have been generated
+// on a separate thread that this one never saw.
+func repairPrompt(diags []validate.Diagnostic, files []FileOutput) string {
+	var sb strings.Builder
+	sb.WriteString("Some of the generated files are malformed:\n\n")
+	seen := make(map[string]bool)
+	var paths []string
+	for _, d := range diags {
+		fmt.Fprintf(&sb, "- %s\n", d)
+		if !seen[d.File] {
+			seen[d.File] = true
+			paths = append(paths, d.File)
+		}
+	}
+	sb.WriteString("\nCurrent content:\n\n")
+	for _, f := range files {
+		if seen[f.Path] {
+			fmt.Fprintf(&sb, "===FILE: %s===\n%s\n===END_FILE===\n\n", f.Path, f.Content)
+		}
+	}
+	sb.WriteString("Re-emit ONLY these files, complete and corrected: ")
+	sb.WriteString(strings.Join(paths, ", "))
+	sb.WriteString(".\n")
+	sb.WriteString("Keep the content otherwise unchanged. Frontmatter must be valid YAML between\n")
+	sb.WriteString("--- lines, applyTo must be a quoted glob, and prompt tools may only be: ")
+	sb.WriteString(strings.Join(validate.AllowedPromptTools, ", "))
+	sb.WriteString(".\n\nOutput ONLY file blocks — no prose before or after:\n")
+	sb.WriteString("===FILE: relative/path===\n(content)\n===END_FILE===\n")
+	return sb.String()
+}
+
+// replaceFiles swaps in corrected files by path. Corrections for paths that
+// weren't generated originally are ignored rather than added.
+func replaceFiles(files, fixed []FileOutput) []FileOutput {
+	byPath := make(map[string]string, len(fixed))
+	for _, f := range fixed {
+		byPath[f.Path] = f.Content
+	}
+	out := make([]FileOutput, len(files))
+	for i, f := range files {
+		if content, ok := byPath[f.Path]; ok {
+			f.Content = content
+		}
+		out[i] = f
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// scriptedProvider replays canned replies in order and records each message.
+// errs, when set, is returned alongside the reply at the same index.
+type scriptedProvider struct {
+	replies  []string
+	errs     []error
+	messages []string
+}
+
+func (p *scriptedProvider) Send(_ context.Context, message, _ string) (string, error) {
+	i := len(p.messages)
+	p.messages = append(p.messages, message)
+	var err error
+	if i < len(p.errs) {
+		err = p.errs[i]
+	}
+	if len(p.replies) == 0 {
+		return "", err
+	}
+	reply := p.replies[0]
+	p.replies = p.replies[1:]
+	return reply, err
+}
+
+const brokenScoped = "---\napplyTo: \"**/*.{go\"\n---\n# Go"
+const fixedScoped = "---\napplyTo: \"**/*.go\"\n---\n# Go"
+
+func TestRepairFiles_FixesInvalidFile(t *testing.T) {
+	p := &scriptedProvider{replies: []string{
+		"===FILE: .github/instructions/go.instructions.md===\n" + fixedScoped + "\n===END_FILE===",
+	}}
+	e := NewEngine(p)
+	files := []FileOutput{
+		{Path: "AGENTS.md", Content: "# Agents"},
+		{Path: ".github/instructions/go.instructions.md", Content: brokenScoped},
+	}
+
+	got, err := e.repairFiles(context.Background(), files)
+	if err != nil {
+		t.Fatalf("repairFiles: %v", err)
+	}
+	if len(p.messages) != 1 {
+		t.Fatalf("expected 1 corrective prompt, got %d", len(p.messages))
+	}
+	if !strings.Contains(p.messages[0], ".github/instructions/go.instructions.md") {
+		t.Errorf("corrective prompt should name the broken file:\n%s", p.messages[0])
+	}
+	if got[1].Content != fixedScoped {
+		t.Errorf("file not replaced: %q", got[1].Content)
+	}
+	if got[0].Content != "# Agents" {
+		t.Errorf("valid file changed: %q", got[0].Content)
+	}
+}
+
+func TestRepairFiles_GivesUpAfterMaxAttempts(t *testing.T) {
+	block := "===FILE: .github/instructions/go.instructions.md===\n" + brokenScoped + "\n===END_FILE==="
+	p := &scriptedProvider{replies: []string{block, block, block}}
+	e := NewEngine(p)
+	files := []FileOutput{{Path: ".github/instructions/go.instructions.md", Content: brokenScoped}}
+
+	if _, err := e.repairFiles(context.Background(), files); err == nil {
+		t.Fatal("expected error when corrections keep failing")
+	}
+	if len(p.messages) != maxRepairAttempts {
+		t.Errorf("expected %d attempts, got %d", maxRepairAttempts, len(p.messages))
+	}
+}
+
+func TestRepairFiles_ValidFilesSkipFollowUp(t *testing.T) {
+	p := &scriptedProvider{}
+	e := NewEngine(p)
+	files := []FileOutput{{Path: ".github/instructions/go.instructions.md", Content: fixedScoped}}
+
+	if _, err := e.repairFiles(context.Background(), files); err != nil {
+		t.Fatalf("repairFiles: %v", err)
+	}
+	if len(p.messages) != 0 {
+		t.Errorf("no follow-up expected, got %d", len(p.messages))
+	}
+}
@@ -0,0 +1,139 @@
+package ai
+
+import (
+	"slices"
+	"strings"
+)
+
+// WithScope limits generation to the files covering the named concerns,
+// such as "design-system" or "testing", and puts only the assets those
+// files draw on in the prompt. A concern is what Concern returns for a
+// file. The default is every file.
+func WithScope(concerns ...string) EngineOption {
+	return func(e *Engine) {
+		for _, c := range concerns {
+			if c = strings.TrimSpace(c); c != "" {
+				e.scope = append(e.scope, c)
+			}
+		}
+	}
+}
+
+// Concern names what a generated file covers, for scoping regeneration:
+// the stem of an instruction or prompt file ("testing" for
+// .github/instructions/testing.instructions.md, "start" for
+// .github/prompts/start.prompt.md), "copilot" for copilot-instructions.md,
+// and "agents" for AGENTS.md. Files other targets render from one of those
+// share its concern, e.g. .cursor/rules/testing.mdc and
+// .claude/commands/start.md. Other files, including the ones that combine
+// every concern such as CLAUDE.md, have no concern.
+func Concern(path string) string {
+	switch {
+	case path == ".github/copilot-instructions.md", path == ".cursor/rules/project.mdc":
+		return "copilot"
+	case path == "AGENTS.md":
+		return "agents"
+	}
+	for _, f := range concernFiles {
+		if strings.HasPrefix(path, f.dir) && strings.HasSuffix(path, f.ext) && len(path) > len(f.dir)+len(f.ext) {
+			return strings.TrimSuffix(strings.TrimPrefix(path, f.dir), f.ext)
+		}
+	}
+	return ""
+}
+
+// concernFiles are the per-concern files of each target, named for the
+// concern.
+var concernFiles = []struct{ dir, ext string }{
+	{".github/instructions/", ".instructions.md"},
+	{".github/prompts/", ".prompt.md"},
+	{".cursor/rules/", ".mdc"},
+	{".cursor/commands/", ".md"},
+	{".claude/commands/", ".md"},
+}
+
+// combinedOutput reports whether path is a file one of targets renders by
+// merging every concern, which a scoped run can't regenerate faithfully.
+func combinedOutput(path string, targets []string) bool {
+	for _, id := range targets {
+		if t := FindTarget(id); t != nil && slices.Contains(t.Combined, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// concernCategories are the extra asset categories a concern's file is
+// synthesized from, beyond assets named for it.
+var concernCategories = map[string][]string{
+	"design-system": {"design", "palette", "fonts"},
+}
+
+// scopeAssets keeps the assets a scoped concern draws on: those whose ID
+// ends in the concern or whose category is the concern. The profile asset
+// is always kept so the files still follow the framework's idioms.
+func scopeAssets(assets []ContextAsset, profileID string, scope []string) []ContextAsset {
+	if len(scope) == 0 {
+		return assets
+	}
+	var kept []ContextAsset
+	for _, a := range assets {
+		if a.ID == "profile."+profileID || assetServes(a, scope) {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
+
+func assetServes(a ContextAsset, scope []string) bool {
+	name := a.ID[strings.LastIndex(a.ID, ".")+1:]
+	for _, c := range scope {
+		if name == c || a.Category == c {
+			return true
+		}
+		for _, cat := range concernCategories[c] {
+			if a.Category == cat {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inScope reports whether a file belongs in a scoped run. Files without a
+// concern, such as a deploy config, only come from scoped assets' guidance
+// and are kept.
+func inScope(path string, scope []string) bool {
+	c := Concern(path)
+	if len(scope) == 0 || c == "" {
+		return true
+	}
+	for _, s := range scope {
+		if s == c {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeSpecs keeps the core files a scoped run covers.
+func scopeSpecs(specs []fileSpec, scope []string) []fileSpec {
+	var kept []fileSpec
+	for _, s := range specs {
+		if inScope(s.Path, scope) {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// scopeFiles drops generated files outside the scope.
+func scopeFiles(files []FileOutput, scope []string) []FileOutput {
+	var kept []FileOutput
+	for _, f := range files {
+		if inScope(f.Path, scope) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestConcern(t *testing.T) {
+	tests := map[string]string{
+		".github/instructions/design-system.instructions.md": "design-system",
+		".github/prompts/start.prompt.md":                    "start",
+		".github/copilot-instructions.md":                    "copilot",
+		"AGENTS.md":                                          "agents",
+		"fly.toml":                                           "",
+		".cursor/rules/testing.mdc":                          "testing",
+		".cursor/rules/project.mdc":                          "copilot",
+		".cursor/commands/start.md":                          "start",
+		".claude/commands/start.md":                          "start",
+		"CLAUDE.md":                                          "",
+		".cursor/rules/.mdc":                                 "",
+	}
+	for path, want := range tests {
+		if got := Concern(path); got != want {
+			t.Errorf("Concern(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestGenerateFiles_Scope(t *testing.T) {
+	p := &scriptedProvider{replies: []string{
+		"===FILE: .github/instructions/testing.instructions.md===\n---\napplyTo: \"**/*_test.go\"\n---\n# Testing\n===END_FILE===\n" +
+			"===FILE: .github/copilot-instructions.md===\n# Standards\n===END_FILE===\n",
+	}}
+	e := NewEngine(p, WithScope("testing"))
+	sel := &Selection{ProfileID: "go-service", AssetIDs: []string{"asset.testing.pragmatic", "asset.lint.strict"}, Confidence: 1}
+
+	files, err := e.GenerateFiles(context.Background(), "app", sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.messages) != 1 {
+		t.Fatalf("sent %d prompts, want only the one for the scoped files", len(p.messages))
+	}
+	for _, want := range []string{"===ASSET: asset.testing.pragmatic===", "===ASSET: profile.go-service==="} {
+		if !strings.Contains(p.messages[0], want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"===ASSET: asset.lint.strict===", "===ASSET: core.copilot==="} {
+		if strings.Contains(p.messages[0], unwanted) {
+			t.Errorf("prompt has out-of-scope %q", unwanted)
+		}
+	}
+	if len(files) != 1 || files[0].Path != ".github/instructions/testing.instructions.md" {
+		t.Errorf("files = %v, want only testing.instructions.md", files)
+	}
+}
+
+func TestGenerateFiles_ScopeLeavesCombinedFiles(t *testing.T) {
+	p := &scriptedProvider{replies: []string{
+		"===FILE: .github/instructions/testing.instructions.md===\n---\napplyTo: \"**/*_test.go\"\n---\n# Testing\n===END_FILE===\n",
+	}}
+	var warnings []string
+	e := NewEngine(p, WithScope("testing"), WithTargets("cursor", "claude"),
+		WithWarningHandler(func(msg string) { warnings = append(warnings, msg) }))
+	sel := &Selection{ProfileID: "go-service", AssetIDs: []string{"asset.testing.pragmatic"}, Confidence: 1}
+
+	files, err := e.GenerateFiles(context.Background(), "app", sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != ".cursor/rules/testing.mdc" {
+		t.Errorf("files = %v, want only the cursor rule", files)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "CLAUDE.md") {
+		t.Errorf("warnings = %v, want one about CLAUDE.md", warnings)
+	}
+}
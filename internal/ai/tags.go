@@ -0,0 +1,59 @@
+package ai
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxTags caps how many project tags a selection carries.
+const maxTags = 8
+
+// MergeTags returns the normalized tags of original followed by any new
+// ones from extra, capped at maxTags. Earlier tags win, so the intent a
+// project was first generated with survives later runs.
+func MergeTags(original, extra []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, list := range [][]string{original, extra} {
+		for _, t := range list {
+			t = normalizeTag(t)
+			if t == "" || seen[t] || len(out) == maxTags {
+				continue
+			}
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// normalizeTag lowercases t and reduces it to letters, digits, and single
+// hyphens: "Real Time!" becomes "real-time".
+func normalizeTag(t string) string {
+	var sb strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(strings.TrimSpace(t)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if hyphen && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			hyphen = false
+			sb.WriteRune(r)
+		default:
+			hyphen = true
+		}
+	}
+	return sb.String()
+}
+
+// tagGuidance tells generation what the project was scoped around, or ""
+// when the selection has no tags.
+func tagGuidance(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "PROJECT INTENT:\n" +
+		"The project was scoped around: " + strings.Join(tags, ", ") + ".\n" +
+		"Keep examples, priorities, and wording consistent with these traits.\n\n"
+}
@@ -0,0 +1,40 @@
+package ai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		name            string
+		original, extra []string
+		want            []string
+	}{
+		{"normalized", nil, []string{" Real Time! ", "PAYMENTS", "", "--"}, []string{"real-time", "payments"}},
+		{"original first", []string{"realtime", "mobile"}, []string{"payments", "Realtime"}, []string{"realtime", "mobile", "payments"}},
+		{"capped", nil, strings.Fields("a b c d e f g h i j"), strings.Fields("a b c d e f g h")},
+		{"empty", nil, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeTags(tt.original, tt.extra); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeTags = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelection_Tags(t *testing.T) {
+	sel, err := parseSelection(`{"profile_id":"elixir-phoenix","confidence":0.9,"tags":["Realtime","multiplayer","realtime"]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"realtime", "multiplayer"}; !reflect.DeepEqual(sel.Tags, want) {
+		t.Errorf("tags = %q, want %q", sel.Tags, want)
+	}
+	if got := tagGuidance(sel.Tags); !strings.Contains(got, "realtime, multiplayer") {
+		t.Errorf("tagGuidance = %q", got)
+	}
+}
@@ -0,0 +1,373 @@
+package ai
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Target is an AI tool whose instruction format Launchpad can emit. The
+// model always synthesizes the Copilot layout; every other target is
+// rendered from it locally, so all formats carry the same content.
+type Target struct {
+	ID    string
+	Label string
+	// Tools maps Copilot prompt tools to this target's identifiers. Nil for
+	// targets without prompt files of their own.
+	Tools ToolSet
+	// Render derives this target's files from the generated Copilot layout.
+	// Nil for copilot itself, whose files are the layout.
+	Render func(projectName string, files []FileOutput) []FileOutput
+	// Standalone targets are the entire output: nothing else is written
+	// alongside them, so they can't be combined with other targets.
+	Standalone bool
+	// Outputs are the paths the target writes, known before generation. A
+	// trailing slash covers everything beneath a directory.
+	Outputs []string
+	// Combined are the outputs that merge every concern into one file.
+	Combined []string
+}
+
+// Targets lists the output formats, in the order they are rendered.
+var Targets = []Target{
+	{ID: "copilot", Label: "GitHub Copilot (.github/)", Tools: CopilotTools,
+		Outputs: []string{".github/copilot-instructions.md", ".github/instructions/", ".github/prompts/"}},
+	{ID: "cursor", Label: "Cursor (.cursor/rules)", Render: renderCursor,
+		Outputs: []string{".cursor/rules/", ".cursor/commands/"}},
+	{ID: "claude", Label: "Claude Code (CLAUDE.md)", Tools: claudeTools, Render: renderClaude,
+		Outputs: []string{"CLAUDE.md", ".claude/commands/"}, Combined: []string{"CLAUDE.md"}},
+	{ID: "zed", Label: "Zed (.rules)", Render: renderZed, Outputs: []string{".rules"}, Combined: []string{".rules"}},
+	{ID: "gemini", Label: "Gemini CLI (GEMINI.md)", Render: renderGemini, Outputs: []string{"GEMINI.md"}, Combined: []string{"GEMINI.md"}},
+	{ID: "agents", Label: "AGENTS.md only", Render: renderAgentsOnly, Standalone: true, Outputs: []string{"AGENTS.md"},
+		Combined: []string{"AGENTS.md"}},
+}
+
+// ExpectedOutputs lists the paths the given targets write, deduplicated.
+// An empty list means the Copilot layout. AGENTS.md comes with every
+// layout. Files that depend on the selection, such as asset outputs, aren't
+// included.
+func ExpectedOutputs(ids []string) []string {
+	if len(ids) == 0 {
+		ids = []string{"copilot"}
+	}
+	out := []string{"AGENTS.md"}
+	seen := map[string]bool{"AGENTS.md": true}
+	for _, id := range ids {
+		t := FindTarget(id)
+		if t == nil {
+			continue
+		}
+		for _, p := range t.Outputs {
+			if !seen[p] {
+				seen[p] = true
+				out = append(out, p)
+			}
+		}
+	}
+	return out
+}
+
+// claudeTools names Claude Code's built-in tools for allowed-tools:.
+var claudeTools = ToolSet{
+	"terminal":  "Bash",
+	"editFiles": "Edit",
+	"codebase":  "Read",
+	"fetch":     "WebFetch",
+}
+
+// FindTarget returns the target with id, or nil.
+func FindTarget(id string) *Target {
+	for i := range Targets {
+		if Targets[i].ID == id {
+			return &Targets[i]
+		}
+	}
+	return nil
+}
+
+// ParseTargets splits a comma-separated target list, normalizes IDs, and
+// rejects unknown targets.
+func ParseTargets(list string) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(list, ",") {
+		id := strings.ToLower(strings.TrimSpace(part))
+		if id == "" || seen[id] {
+			continue
+		}
+		if FindTarget(id) == nil {
+			return nil, fmt.Errorf("unknown target %q (known: %s)", id, strings.Join(targetIDs(), ", "))
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func targetIDs() []string {
+	ids := make([]string, len(Targets))
+	for i, t := range Targets {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// renderTargets renders each requested target from the Copilot layout. No
+// targets means Copilot alone. When copilot isn't requested its
+// instruction and prompt files are left out, but AGENTS.md and any
+// tool configuration the assets produced are kept.
+func renderTargets(projectName string, files []FileOutput, targetIDs []string) ([]FileOutput, error) {
+	if len(targetIDs) == 0 {
+		return files, nil
+	}
+	var rendered []FileOutput
+	withCopilot := false
+	for _, id := range targetIDs {
+		t := FindTarget(id)
+		if t == nil {
+			return nil, fmt.Errorf("unknown output target %q", id)
+		}
+		if t.Standalone {
+			if len(targetIDs) > 1 {
+				return nil, fmt.Errorf("target %q can't be combined with other targets", id)
+			}
+			return t.Render(projectName, files), nil
+		}
+		if t.Render == nil {
+			withCopilot = true
+			continue
+		}
+		rendered = append(rendered, t.Render(projectName, files)...)
+	}
+	var out []FileOutput
+	for _, f := range files {
+		if withCopilot || !isCopilotFile(f.Path) {
+			out = append(out, f)
+		}
+	}
+	return append(out, rendered...), nil
+}
+
+// isCopilotFile reports whether path only means something to Copilot.
+func isCopilotFile(p string) bool {
+	return p == ".github/copilot-instructions.md" || isScopedFile(p) || isPromptFile(p)
+}
+
+func isScopedFile(p string) bool {
+	return strings.HasPrefix(p, ".github/instructions/") && strings.HasSuffix(p, ".instructions.md")
+}
+
+func isPromptFile(p string) bool {
+	return strings.HasPrefix(p, ".github/prompts/") && strings.HasSuffix(p, ".prompt.md")
+}
+
+// renderCursor writes one project rule per Copilot instruction file. Cursor
+// rules carry their own scoping, so scoped files keep their globs rather
+// than being merged. Prompts become Cursor commands.
+func renderCursor(projectName string, files []FileOutput) []FileOutput {
+	var out []FileOutput
+	for _, f := range files {
+		fields, body := splitFrontmatter(f.Content)
+		switch {
+		case f.Path == ".github/copilot-instructions.md":
+			out = append(out, FileOutput{
+				Path:    ".cursor/rules/project.mdc",
+				Content: cursorRule(projectName+" project standards", "", body),
+			})
+		case isScopedFile(f.Path):
+			name := strings.TrimSuffix(path.Base(f.Path), ".instructions.md")
+			desc := fields["description"]
+			if desc == "" {
+				desc = fields["name"]
+			}
+			if desc == "" {
+				desc = name + " conventions"
+			}
+			out = append(out, FileOutput{
+				Path:    ".cursor/rules/" + name + ".mdc",
+				Content: cursorRule(desc, fields["applyTo"], body),
+			})
+		case isPromptFile(f.Path):
+			name := strings.TrimSuffix(path.Base(f.Path), ".prompt.md")
+			out = append(out, FileOutput{Path: ".cursor/commands/" + name + ".md", Content: body + "\n"})
+		}
+	}
+	return out
+}
+
+// cursorRule renders an .mdc rule. An empty or match-everything glob makes
+// the rule always apply. The description is quoted, since model-written
+// text may hold a colon or a leading character YAML would misread.
+func cursorRule(description, glob, body string) string {
+	always := glob == "" || glob == "**" || glob == "**/*"
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	fmt.Fprintf(&sb, "description: %s\n", strconv.Quote(description))
+	if always {
+		sb.WriteString("globs:\n")
+	} else {
+		fmt.Fprintf(&sb, "globs: %s\n", glob)
+	}
+	fmt.Fprintf(&sb, "alwaysApply: %t\n", always)
+	sb.WriteString("---\n\n")
+	sb.WriteString(body + "\n")
+	return sb.String()
+}
+
+// renderClaude writes CLAUDE.md, which Claude Code loads into every session,
+// and turns each prompt into a slash command with its tools translated.
+func renderClaude(projectName string, files []FileOutput) []FileOutput {
+	out := []FileOutput{{
+		Path:    "CLAUDE.md",
+		Content: consolidate(fmt.Sprintf("# %s — project memory", projectName), files),
+	}}
+	for _, f := range files {
+		if !isPromptFile(f.Path) {
+			continue
+		}
+		fields, body := splitFrontmatter(f.Content)
+		tools, _, _, _ := frontmatterList(f.Content, "tools")
+		var sb strings.Builder
+		sb.WriteString("---\n")
+		if d := fields["description"]; d != "" {
+			fmt.Fprintf(&sb, "description: %s\n", d)
+		}
+		if allowed := mapTools(tools, claudeTools); len(allowed) > 0 {
+			fmt.Fprintf(&sb, "allowed-tools: %s\n", strings.Join(allowed, ", "))
+		}
+		sb.WriteString("---\n\n" + body + "\n")
+		name := strings.TrimSuffix(path.Base(f.Path), ".prompt.md")
+		out = append(out, FileOutput{Path: ".claude/commands/" + name + ".md", Content: sb.String()})
+	}
+	return out
+}
+
+// renderZed consolidates everything into the single .rules file Zed's
+// assistant loads for the project. Scoped files become sections that state
+// which paths they govern, since Zed has no per-glob rules.
+func renderZed(projectName string, files []FileOutput) []FileOutput {
+	return []FileOutput{{
+		Path:    ".rules",
+		Content: consolidate(fmt.Sprintf("# %s — project rules", projectName), files),
+	}}
+}
+
+// renderAgentsOnly folds everything into one AGENTS.md, per the agents.md
+// convention, for projects that don't want a .github/instructions tree. The
+// kickoff prompt becomes a closing section so the scaffold step isn't lost.
+func renderAgentsOnly(projectName string, files []FileOutput) []FileOutput {
+	var sb strings.Builder
+	sb.WriteString(consolidate(fmt.Sprintf("# %s — agent instructions", projectName), files))
+	for _, f := range files {
+		if f.Path == ".github/prompts/start.prompt.md" {
+			_, body := splitFrontmatter(f.Content)
+			sb.WriteString("\n\n## Getting started\n\n" + demoteHeadings(stripTitle(body)))
+		}
+	}
+	return []FileOutput{{Path: "AGENTS.md", Content: sb.String() + "\n"}}
+}
+
+// renderGemini writes the GEMINI.md context file Gemini CLI and Code Assist
+// load from the project root. Like Zed, Gemini reads one file, so scoped
+// rules are folded in as sections.
+func renderGemini(projectName string, files []FileOutput) []FileOutput {
+	return []FileOutput{{
+		Path:    "GEMINI.md",
+		Content: consolidate(fmt.Sprintf("# %s — context for Gemini", projectName), files),
+	}}
+}
+
+// consolidate merges the Copilot layout into one markdown document: the
+// always-on standards, then each scoped file as a section, then the agent
+// rules. Frontmatter is dropped and headings are demoted one level so the
+// document keeps a single title.
+func consolidate(title string, files []FileOutput) string {
+	var always, agents *FileOutput
+	var scoped []FileOutput
+	for i, f := range files {
+		switch {
+		case f.Path == ".github/copilot-instructions.md":
+			always = &files[i]
+		case f.Path == "AGENTS.md":
+			agents = &files[i]
+		case isScopedFile(f.Path):
+			scoped = append(scoped, f)
+		}
+	}
+	sort.Slice(scoped, func(i, j int) bool { return scoped[i].Path < scoped[j].Path })
+
+	var sb strings.Builder
+	sb.WriteString(title + "\n")
+	if always != nil {
+		_, body := splitFrontmatter(always.Content)
+		sb.WriteString("\n" + demoteHeadings(body) + "\n")
+	}
+	for _, f := range scoped {
+		fields, body := splitFrontmatter(f.Content)
+		name := strings.TrimSuffix(path.Base(f.Path), ".instructions.md")
+		fmt.Fprintf(&sb, "\n## %s\n\n", name)
+		if glob := fields["applyTo"]; glob != "" && glob != "**" {
+			fmt.Fprintf(&sb, "_Applies to files matching `%s`._\n\n", glob)
+		}
+		sb.WriteString(demoteHeadings(stripTitle(body)) + "\n")
+	}
+	if agents != nil {
+		sb.WriteString("\n## Agent workflow\n\n")
+		sb.WriteString(demoteHeadings(stripTitle(agents.Content)) + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// splitFrontmatter separates a leading ---…--- block from the body and
+// returns its top-level scalar fields, unquoted.
+func splitFrontmatter(content string) (map[string]string, string) {
+	fields := make(map[string]string)
+	trimmed := strings.TrimLeft(content, "\n")
+	if !strings.HasPrefix(trimmed, "---\n") {
+		return fields, strings.TrimSpace(content)
+	}
+	rest := trimmed[len("---\n"):]
+	end := strings.Index(rest, "\n---")
+	if end == -1 {
+		return fields, strings.TrimSpace(content)
+	}
+	for _, line := range strings.Split(rest[:end], "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.HasPrefix(line, " ") {
+			continue
+		}
+		fields[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	body := rest[end+len("\n---"):]
+	return fields, strings.TrimSpace(body)
+}
+
+// stripTitle drops a leading H1, which the section heading replaces.
+func stripTitle(body string) string {
+	if strings.HasPrefix(body, "# ") {
+		if i := strings.Index(body, "\n"); i != -1 {
+			return strings.TrimSpace(body[i+1:])
+		}
+		return ""
+	}
+	return body
+}
+
+// demoteHeadings pushes every markdown heading down one level, leaving
+// fenced code blocks alone.
+func demoteHeadings(body string) string {
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence && strings.HasPrefix(line, "#") && strings.Contains(line, " ") && strings.Trim(line[:strings.Index(line, " ")], "#") == "" {
+			lines[i] = "#" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
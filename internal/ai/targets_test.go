@@ -0,0 +1,169 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+)
+
+var copilotLayout = []FileOutput{
+	{Path: ".github/copilot-instructions.md", Content: "# Standards\n\n## Naming\nUse clear names."},
+	{Path: ".github/instructions/testing.instructions.md", Content: "---\napplyTo: \"**/*_test.go\"\n---\n# Testing\n\n## Table tests\n```go\n# not a heading\n```"},
+	{Path: ".github/instructions/architecture.instructions.md", Content: "---\napplyTo: \"**\"\n---\n# Architecture\nLayers."},
+	{Path: "AGENTS.md", Content: "# Agents\n\n## Change discipline\nOne concern per change."},
+	{Path: ".github/prompts/start.prompt.md", Content: "---\ndescription: \"Start\"\nmode: agent\ntools: [\"terminal\", \"editFiles\", \"codebase\"]\n---\nRun the scaffold."},
+}
+
+func TestRenderZed(t *testing.T) {
+	out, err := renderTargets("demo", copilotLayout, []string{"copilot", "zed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != len(copilotLayout)+1 {
+		t.Fatalf("got %d files, want Copilot layout plus .rules", len(out))
+	}
+	rules := out[len(out)-1]
+	if rules.Path != ".rules" {
+		t.Fatalf("path = %s", rules.Path)
+	}
+	for _, want := range []string{
+		"# demo — project rules",
+		"## Standards",
+		"### Naming",
+		"## architecture\n\nLayers.",
+		"## testing\n\n_Applies to files matching `**/*_test.go`._",
+		"### Table tests",
+		"# not a heading",
+		"## Agent workflow",
+		"### Change discipline",
+	} {
+		if !strings.Contains(rules.Content, want) {
+			t.Errorf(".rules missing %q:\n%s", want, rules.Content)
+		}
+	}
+	if strings.Contains(rules.Content, "applyTo") || strings.Contains(rules.Content, "Run the scaffold") {
+		t.Errorf(".rules should drop frontmatter and prompts:\n%s", rules.Content)
+	}
+}
+
+func TestRenderGemini(t *testing.T) {
+	out, err := renderTargets("demo", copilotLayout, []string{"zed", "gemini"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gemini := out[len(out)-1]
+	if gemini.Path != "GEMINI.md" || !strings.HasPrefix(gemini.Content, "# demo — context for Gemini\n") {
+		t.Fatalf("got %s:\n%s", gemini.Path, gemini.Content)
+	}
+	if !strings.Contains(gemini.Content, "## testing\n\n_Applies to files matching `**/*_test.go`._") {
+		t.Errorf("GEMINI.md missing scoped section:\n%s", gemini.Content)
+	}
+}
+
+func TestRenderTargets_Unknown(t *testing.T) {
+	if _, err := renderTargets("demo", copilotLayout, []string{"emacs"}); err == nil {
+		t.Error("expected error for unknown target")
+	}
+}
+
+func TestRenderTargets_WithoutCopilot(t *testing.T) {
+	out, err := renderTargets("demo", copilotLayout, []string{"zed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, f := range out {
+		paths = append(paths, f.Path)
+	}
+	if strings.Join(paths, " ") != "AGENTS.md .rules" {
+		t.Errorf("paths = %v, want AGENTS.md and .rules only", paths)
+	}
+}
+
+func TestRenderCursor(t *testing.T) {
+	files := renderCursor("demo", copilotLayout)
+	byPath := make(map[string]string)
+	for _, f := range files {
+		byPath[f.Path] = f.Content
+	}
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{".cursor/rules/project.mdc", []string{"description: \"demo project standards\"\n", "globs:\nalwaysApply: true\n", "## Naming"}},
+		{".cursor/rules/testing.mdc", []string{"globs: **/*_test.go\nalwaysApply: false\n", "# Testing"}},
+		{".cursor/rules/architecture.mdc", []string{"alwaysApply: true\n"}},
+		{".cursor/commands/start.md", []string{"Run the scaffold."}},
+	}
+	if len(files) != len(tests) {
+		t.Errorf("got %d files, want %d", len(files), len(tests))
+	}
+	for _, tt := range tests {
+		content, ok := byPath[tt.path]
+		if !ok {
+			t.Errorf("missing %s", tt.path)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q:\n%s", tt.path, want, content)
+			}
+		}
+	}
+}
+
+func TestRenderClaude(t *testing.T) {
+	files := renderClaude("demo", copilotLayout)
+	if len(files) != 2 || files[0].Path != "CLAUDE.md" || files[1].Path != ".claude/commands/start.md" {
+		t.Fatalf("got %v", files)
+	}
+	want := "---\ndescription: Start\nallowed-tools: Bash, Edit, Read\n---\n\nRun the scaffold.\n"
+	if files[1].Content != want {
+		t.Errorf("command = %q, want %q", files[1].Content, want)
+	}
+}
+
+func TestParseTargets(t *testing.T) {
+	got, err := ParseTargets(" Copilot, cursor,,copilot ")
+	if err != nil || strings.Join(got, ",") != "copilot,cursor" {
+		t.Errorf("ParseTargets = %v, %v", got, err)
+	}
+	if _, err := ParseTargets("copilot,emacs"); err == nil {
+		t.Error("expected error for unknown target")
+	}
+}
+
+func TestRenderAgentsOnly(t *testing.T) {
+	out, err := renderTargets("demo", copilotLayout, []string{"agents"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0].Path != "AGENTS.md" {
+		t.Fatalf("got %v, want a single AGENTS.md", out)
+	}
+	for _, want := range []string{"# demo — agent instructions\n", "## testing", "## Agent workflow", "## Getting started\n\nRun the scaffold."} {
+		if !strings.Contains(out[0].Content, want) {
+			t.Errorf("AGENTS.md missing %q:\n%s", want, out[0].Content)
+		}
+	}
+	if _, err := renderTargets("demo", copilotLayout, []string{"copilot", "agents"}); err == nil {
+		t.Error("expected error combining a standalone target")
+	}
+}
+
+func TestExpectedOutputs(t *testing.T) {
+	got := ExpectedOutputs([]string{"claude", "agents"})
+	want := []string{"AGENTS.md", "CLAUDE.md", ".claude/commands/"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ExpectedOutputs = %v, want %v", got, want)
+	}
+	if got := ExpectedOutputs(nil); len(got) != 4 || got[1] != ".github/copilot-instructions.md" {
+		t.Errorf("default outputs = %v", got)
+	}
+}
+
+func TestCursorRule_QuotesDescription(t *testing.T) {
+	got := cursorRule(`Testing: "table" tests`, "", "body")
+	if want := "description: \"Testing: \\\"table\\\" tests\"\n"; !strings.Contains(got, want) {
+		t.Errorf("rule = %q, want it to contain %q", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package ai
+
+import "strings"
+
+// Tone adjusts how the advisor talks during the conversation. The zero
+// value keeps the default persona: brief, neutral, emoji left to the model.
+type Tone struct {
+	Verbosity string // "concise" or "chatty"; "" for the default
+	Formality string // "formal" or "casual"; "" for the default
+	Emoji     *bool  // nil leaves it to the model
+}
+
+// Verbosity and formality settings.
+const (
+	VerbosityConcise = "concise"
+	VerbosityChatty  = "chatty"
+	FormalityFormal  = "formal"
+	FormalityCasual  = "casual"
+)
+
+// WithTone sets the advisor's conversational style.
+func WithTone(t Tone) EngineOption {
+	return func(e *Engine) {
+		e.tone = t
+	}
+}
+
+// maxSentences caps each conversation reply.
+func (t Tone) maxSentences() int {
+	switch t.Verbosity {
+	case VerbosityConcise:
+		return 3
+	case VerbosityChatty:
+		return 10
+	}
+	return 6
+}
+
+// promptSection describes the style for the conversation system prompt,
+// or returns "" for the default persona.
+func (t Tone) promptSection() string {
+	var lines []string
+	switch t.Verbosity {
+	case VerbosityConcise:
+		lines = append(lines, "Be terse: no pleasantries, no restating what the user said, questions as a short list.")
+	case VerbosityChatty:
+		lines = append(lines, "Be warm and conversational: acknowledge what the user shared and explain your reasoning briefly.")
+	}
+	switch t.Formality {
+	case FormalityFormal:
+		lines = append(lines, "Use a formal, professional register. No slang, no exclamation marks.")
+	case FormalityCasual:
+		lines = append(lines, "Use a relaxed, casual register, like a colleague at the next desk.")
+	}
+	if t.Emoji != nil {
+		if *t.Emoji {
+			lines = append(lines, "An occasional emoji is welcome where it adds warmth.")
+		} else {
+			lines = append(lines, "Never use emoji. The ★ marking your top pick is the only symbol allowed.")
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "STYLE:\n" + strings.Join(lines, "\n") + "\n\n"
+}
@@ -0,0 +1,49 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestConversationSystemPrompt_Tone(t *testing.T) {
+	def := conversationSystemPrompt(DefaultDecisionMap(), Tone{})
+	if strings.Contains(def, "STYLE:") || !strings.Contains(def, "Maximum 6 sentences per reply.") {
+		t.Error("the default tone should leave the persona unchanged")
+	}
+
+	no := false
+	styled := conversationSystemPrompt(DefaultDecisionMap(), Tone{Verbosity: VerbosityConcise, Formality: FormalityFormal, Emoji: &no})
+	for _, want := range []string{"Maximum 3 sentences per reply.", "STYLE:", "Be terse", "formal, professional register", "Never use emoji"} {
+		if !strings.Contains(styled, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+
+	chatty := conversationSystemPrompt(DefaultDecisionMap(), Tone{Verbosity: VerbosityChatty})
+	if !strings.Contains(chatty, "Maximum 10 sentences per reply.") || strings.Contains(chatty, "emoji") {
+		t.Error("chatty tone should allow longer replies and say nothing about emoji")
+	}
+}
+
+func TestChat_SendsTone(t *testing.T) {
+	p := &recordingProvider{}
+	e := NewEngine(p, WithTone(Tone{Formality: FormalityCasual}))
+
+	if _, err := e.Chat(context.Background(), "a todo app"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(p.instructions, "casual register") {
+		t.Error("chat turns should carry the configured tone")
+	}
+}
+
+// recordingProvider keeps the system instructions of the last call.
+type recordingProvider struct {
+	instructions string
+}
+
+func (p *recordingProvider) Send(_ context.Context, _, instructions string) (string, error) {
+	p.instructions = instructions
+	return "ok", nil
+}
@@ -0,0 +1,161 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/validate"
+)
+
+// ToolSet maps each Copilot prompt tool to the identifier a target uses for
+// the same capability. Copilot's identifiers are canonical: the model writes
+// them, and other targets translate them when rendering their own prompts.
+// A tool with no entry has no equivalent and is dropped for that target.
+type ToolSet map[string]string
+
+// CopilotTools is the identity mapping over the tools Copilot accepts.
+var CopilotTools = func() ToolSet {
+	set := make(ToolSet, len(validate.AllowedPromptTools))
+	for _, t := range validate.AllowedPromptTools {
+		set[t] = t
+	}
+	return set
+}()
+
+// toolAliases maps names models tend to invent, or borrow from other
+// agents, onto the canonical Copilot tool.
+var toolAliases = map[string]string{
+	"runCommands":   "terminal",
+	"runInTerminal": "terminal",
+	"runTasks":      "terminal",
+	"shell":         "terminal",
+	"bash":          "terminal",
+	"edit":          "editFiles",
+	"editFile":      "editFiles",
+	"writeFile":     "editFiles",
+	"search":        "codebase",
+	"readFile":      "codebase",
+	"read":          "codebase",
+	"fetchWebpage":  "fetch",
+	"webfetch":      "fetch",
+}
+
+// canonicalTool resolves a generated tool name to a Copilot tool, or "".
+func canonicalTool(name string) string {
+	if _, ok := CopilotTools[name]; ok {
+		return name
+	}
+	if t, ok := toolAliases[name]; ok {
+		return t
+	}
+	if t, ok := toolAliases[strings.ToLower(name)]; ok {
+		return t
+	}
+	return ""
+}
+
+// mapTools translates canonical tools into set's identifiers, keeping order
+// and dropping tools the set has no equivalent for.
+func mapTools(tools []string, set ToolSet) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, t := range tools {
+		mapped, ok := set[t]
+		if !ok || seen[mapped] {
+			continue
+		}
+		seen[mapped] = true
+		out = append(out, mapped)
+	}
+	return out
+}
+
+// normalizePromptTools rewrites the tools: list of every generated prompt
+// file to canonical Copilot identifiers. Known aliases are mapped; anything
+// else is removed and reported, so an invented tool never reaches disk.
+func normalizePromptTools(files []FileOutput) ([]FileOutput, []string) {
+	var warnings []string
+	out := make([]FileOutput, len(files))
+	for i, f := range files {
+		out[i] = f
+		if !strings.HasPrefix(f.Path, ".github/prompts/") || !strings.HasSuffix(f.Path, ".prompt.md") {
+			continue
+		}
+		tools, start, end, ok := frontmatterList(f.Content, "tools")
+		if !ok {
+			continue
+		}
+		var canonical []string
+		seen := make(map[string]bool)
+		for _, t := range tools {
+			c := canonicalTool(t)
+			if c == "" {
+				warnings = append(warnings, fmt.Sprintf("removed unknown tool %q from %s", t, f.Path))
+				continue
+			}
+			if !seen[c] {
+				seen[c] = true
+				canonical = append(canonical, c)
+			}
+		}
+		out[i].Content = replaceLines(f.Content, start, end, "tools: "+inlineList(canonical))
+	}
+	return out, warnings
+}
+
+// frontmatterList finds key in the leading frontmatter and returns its list
+// items along with the [start, end) line range the field occupies. Both
+// inline ([a, b]) and block (- a) lists are understood.
+func frontmatterList(content, key string) ([]string, int, int, bool) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, 0, 0, false
+	}
+	for i := 1; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(name) != key || strings.HasPrefix(line, " ") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value != "" {
+			inner := strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			var items []string
+			for _, item := range strings.Split(inner, ",") {
+				if item = strings.Trim(strings.TrimSpace(item), `"'`); item != "" {
+					items = append(items, item)
+				}
+			}
+			return items, i, i + 1, true
+		}
+		end := i + 1
+		var items []string
+		for ; end < len(lines); end++ {
+			trimmed := strings.TrimSpace(lines[end])
+			if !strings.HasPrefix(trimmed, "- ") {
+				break
+			}
+			items = append(items, strings.Trim(strings.TrimSpace(trimmed[2:]), `"'`))
+		}
+		return items, i, end, true
+	}
+	return nil, 0, 0, false
+}
+
+// replaceLines swaps lines [start, end) of content for a single line.
+func replaceLines(content string, start, end int, line string) string {
+	lines := strings.Split(content, "\n")
+	out := append(append(lines[:start:start], line), lines[end:]...)
+	return strings.Join(out, "\n")
+}
+
+func inlineList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
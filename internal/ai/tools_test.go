@@ -0,0 +1,64 @@
+package ai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizePromptTools(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantTools    string
+		wantWarnings int
+	}{
+		{
+			name:      "canonical inline list untouched",
+			content:   "---\nmode: agent\ntools: [\"terminal\", \"editFiles\"]\n---\nGo.",
+			wantTools: `tools: ["terminal", "editFiles"]`,
+		},
+		{
+			name:      "aliases mapped and deduplicated",
+			content:   "---\nmode: agent\ntools: [runCommands, Bash, edit, search]\n---\nGo.",
+			wantTools: `tools: ["terminal", "editFiles", "codebase"]`,
+		},
+		{
+			name:         "block list with invented tool",
+			content:      "---\nmode: agent\ntools:\n  - terminal\n  - runTests\n---\nGo.",
+			wantTools:    `tools: ["terminal"]`,
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := []FileOutput{{Path: ".github/prompts/start.prompt.md", Content: tt.content}}
+			out, warnings := normalizePromptTools(files)
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d", warnings, tt.wantWarnings)
+			}
+			if !strings.Contains(out[0].Content, tt.wantTools+"\n---\nGo.") {
+				t.Errorf("content = %q, want %s", out[0].Content, tt.wantTools)
+			}
+		})
+	}
+}
+
+func TestNormalizePromptTools_IgnoresOtherFiles(t *testing.T) {
+	files := []FileOutput{{Path: "AGENTS.md", Content: "---\ntools: [runTests]\n---\n"}}
+	out, warnings := normalizePromptTools(files)
+	if out[0].Content != files[0].Content || len(warnings) != 0 {
+		t.Errorf("non-prompt file was rewritten: %q %v", out[0].Content, warnings)
+	}
+}
+
+func TestMapTools(t *testing.T) {
+	set := ToolSet{"terminal": "Bash", "editFiles": "Edit", "codebase": "Read"}
+	got := mapTools([]string{"terminal", "fetch", "editFiles", "codebase"}, set)
+	if want := []string{"Bash", "Edit", "Read"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("mapTools = %v, want %v", got, want)
+	}
+	if got := mapTools([]string{"terminal", "fetch"}, CopilotTools); !reflect.DeepEqual(got, []string{"terminal", "fetch"}) {
+		t.Errorf("Copilot mapping = %v", got)
+	}
+}
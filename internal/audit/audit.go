@@ -0,0 +1,272 @@
+// Package audit keeps an append-only JSONL record of every prompt sent to
+// the model provider and every reply, for organizations that must be able
+// to show exactly what left the machine.
+//
+// Text is redacted before it is written, but each record also carries the
+// SHA-256 of the exact text exchanged, so an auditor holding the originals
+// can match them. Records are chained: each one stores the hash of the line
+// before it, and Verify reports the first line that breaks the chain.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/sandbox"
+)
+
+// PathEnv overrides the configured audit log location.
+const PathEnv = "LAUNCHPAD_AUDIT_LOG"
+
+// Record is one prompt/response exchange.
+type Record struct {
+	Seq                int       `json:"seq"`
+	At                 time.Time `json:"at"`
+	Model              string    `json:"model"`
+	Instructions       string    `json:"instructions,omitempty"`
+	Prompt             string    `json:"prompt"`
+	Response           string    `json:"response"`
+	Error              string    `json:"error,omitempty"`
+	InstructionsSHA256 string    `json:"instructions_sha256,omitempty"`
+	PromptSHA256       string    `json:"prompt_sha256"`
+	ResponseSHA256     string    `json:"response_sha256"`
+	PrevSHA256         string    `json:"prev_sha256"` // hash of the previous line; empty for the first
+}
+
+// Log appends records to a file. It is safe for concurrent use.
+type Log struct {
+	path string
+	mu   sync.Mutex
+	seq  int
+	prev string
+}
+
+// Open prepares the log at path, continuing the chain of any records it
+// already holds.
+func Open(path string) (*Log, error) {
+	l := &Log{path: path}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(line, &r); err != nil {
+			return nil, fmt.Errorf("audit log %s: line %d: %w", path, l.seq+1, err)
+		}
+		l.seq, l.prev = r.Seq, hash(string(line))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return l, nil
+}
+
+// Path returns the file the log appends to.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append redacts and writes one exchange.
+func (l *Log) Append(model, instructions, prompt, response string, sendErr error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r := Record{
+		Seq:            l.seq + 1,
+		At:             time.Now().UTC(),
+		Model:          model,
+		Instructions:   Redact(instructions),
+		Prompt:         Redact(prompt),
+		Response:       Redact(response),
+		PromptSHA256:   hash(prompt),
+		ResponseSHA256: hash(response),
+		PrevSHA256:     l.prev,
+	}
+	if instructions != "" {
+		r.InstructionsSHA256 = hash(instructions)
+	}
+	if sendErr != nil {
+		r.Error = Redact(sendErr.Error())
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("marshal audit record: %w", err)
+	}
+
+	if err := sandbox.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return fmt.Errorf("create audit dir: %w", err)
+	}
+	f, err := sandbox.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	l.seq, l.prev = r.Seq, hash(string(line))
+	return nil
+}
+
+// Verify checks the chain in the log at path and returns the number of
+// records. A record that was edited, removed, or reordered breaks the chain
+// at the line after it.
+func Verify(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	n, prev := 0, ""
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		n++
+		var r Record
+		if err := json.Unmarshal(line, &r); err != nil {
+			return n - 1, fmt.Errorf("line %d: %w", n, err)
+		}
+		if r.PrevSHA256 != prev || r.Seq != n {
+			return n - 1, fmt.Errorf("line %d: chain broken (seq %d)", n, r.Seq)
+		}
+		prev = hash(string(line))
+	}
+	if err := sc.Err(); err != nil {
+		return n, fmt.Errorf("read audit log: %w", err)
+	}
+	return n, nil
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// redactions are applied in order; each match is replaced by its label.
+var redactions = []struct {
+	re    *regexp.Regexp
+	label string
+}{
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`), "[REDACTED:private-key]"},
+	{regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}`), "[REDACTED:api-key]"},
+	{regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{30,}`), "[REDACTED:github-token]"},
+	{regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`), "[REDACTED:aws-key]"},
+	{regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]{16,}=*`), "[REDACTED:bearer-token]"},
+	{regexp.MustCompile(`(?i)\b(password|passwd|secret)(\s*[:=]\s*)("[^"]*"|'[^']*'|\S+)`), "$1$2[REDACTED]"},
+	// "Token: 4k context" is prose; only quoted or key-shaped values are masked.
+	{regexp.MustCompile(`(?i)\b(token|api[_-]?key)(\s*[:=]\s*)("[^"]*"|'[^']*'|[A-Za-z0-9._~+/=-]{16,})`), "$1$2[REDACTED]"},
+	{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "[REDACTED:email]"},
+}
+
+// Redact masks credentials and email addresses in s.
+func Redact(s string) string {
+	for _, r := range redactions {
+		s = r.re.ReplaceAllString(s, r.label)
+	}
+	return s
+}
+
+// Provider wraps an ai.Provider and logs every exchange.
+type Provider struct {
+	inner ai.Provider
+	log   *Log
+	model string
+	// OnError receives failures to write the log. The exchange itself
+	// still goes through; nil ignores them.
+	OnError func(error)
+}
+
+// Wrap returns p with every Send recorded in log under model's name. The
+// result implements ai.Forker only when p does; forks log to the same file.
+// onError may be nil.
+func Wrap(p ai.Provider, log *Log, model string, onError func(error)) ai.Provider {
+	w := &Provider{inner: p, log: log, model: model, OnError: onError}
+	if _, ok := p.(ai.Forker); ok {
+		return forking{w}
+	}
+	return w
+}
+
+// Send implements ai.Provider.
+func (p *Provider) Send(ctx context.Context, message, systemPrompt string) (string, error) {
+	reply, err := p.inner.Send(ctx, message, systemPrompt)
+	if logErr := p.log.Append(p.model, systemPrompt, message, reply, err); logErr != nil && p.OnError != nil {
+		p.OnError(logErr)
+	}
+	return reply, err
+}
+
+// SendStructured implements ai.StructuredSender when the wrapped provider
+// does; Capabilities reports no structured output otherwise, so the engine
+// never calls it then.
+func (p *Provider) SendStructured(ctx context.Context, message, systemPrompt, name string, schema map[string]any) (string, error) {
+	s, ok := p.inner.(ai.StructuredSender)
+	if !ok {
+		return p.Send(ctx, message, systemPrompt)
+	}
+	reply, err := s.SendStructured(ctx, message, systemPrompt, name, schema)
+	if logErr := p.log.Append(p.model, systemPrompt, message, reply, err); logErr != nil && p.OnError != nil {
+		p.OnError(logErr)
+	}
+	return reply, err
+}
+
+// Capabilities implements ai.CapabilityProber by asking the wrapped
+// provider. Probe requests carry no user content and aren't logged.
+func (p *Provider) Capabilities(ctx context.Context) ai.Capabilities {
+	c, ok := p.inner.(ai.CapabilityProber)
+	if !ok {
+		return ai.Capabilities{}
+	}
+	caps := c.Capabilities(ctx)
+	if _, ok := p.inner.(ai.StructuredSender); !ok {
+		caps.StructuredOutput = false
+	}
+	return caps
+}
+
+// Usage reports the wrapped provider's billed tokens, when it counts them.
+func (p *Provider) Usage() (input, output int) {
+	if m, ok := p.inner.(interface{ Usage() (int, int) }); ok {
+		return m.Usage()
+	}
+	return 0, 0
+}
+
+// forking is a Provider whose wrapped provider can fork.
+type forking struct{ *Provider }
+
+// Fork implements ai.Forker.
+func (p forking) Fork() ai.Provider {
+	clone := *p.Provider
+	clone.inner = p.inner.(ai.Forker).Fork()
+	return forking{&clone}
+}
@@ -0,0 +1,415 @@
+// Package browse is an interactive terminal browser over the catalog:
+// profiles, add-ons, and assets with fuzzy search, template previews, and a
+// selection that can be handed to init.
+package browse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/palette"
+	"github.com/ecoker/launchpad/internal/scaffold"
+	"github.com/ecoker/launchpad/internal/ui"
+)
+
+// Kind groups catalog items by how they enter a selection.
+type Kind string
+
+const (
+	KindProfile Kind = "profile"
+	KindAddon   Kind = "addon"
+	KindAsset   Kind = "asset"
+)
+
+// Item is one selectable catalog entry.
+type Item struct {
+	ID       string // selection ID: bare for profiles and add-ons, full for assets
+	Kind     Kind
+	Label    string
+	Category string
+	Summary  string
+	Template string // path inside templates.FS, or absolute for imported assets
+	Scaffold string // profiles only
+}
+
+// Items lists every selectable catalog entry: profiles first, then add-ons,
+// then assets. Assets included in every generation are left out.
+func Items() []Item {
+	var items []Item
+	for _, a := range ai.Catalog() {
+		if ai.AlwaysIncluded(a.ID) {
+			continue
+		}
+		it := Item{ID: a.ID, Kind: KindAsset, Label: a.Label, Category: a.Category, Summary: a.Summary, Template: a.TemplatePath}
+		switch {
+		case strings.HasPrefix(a.ID, "profile."):
+			it.ID, it.Kind = strings.TrimPrefix(a.ID, "profile."), KindProfile
+			if p := scaffold.FindProfile(it.ID); p != nil {
+				it.Scaffold = p.ScaffoldCmd
+			}
+		case strings.HasPrefix(a.ID, "addon."):
+			it.ID, it.Kind = strings.TrimPrefix(a.ID, "addon."), KindAddon
+		}
+		items = append(items, it)
+	}
+	order := map[Kind]int{KindProfile: 0, KindAddon: 1, KindAsset: 2}
+	sort.SliceStable(items, func(i, j int) bool { return order[items[i].Kind] < order[items[j].Kind] })
+	return items
+}
+
+// Filter returns the indexes of items matching query, best match first.
+// An empty query keeps catalog order.
+func Filter(items []Item, query string) []int {
+	idx := make([]int, 0, len(items))
+	if strings.TrimSpace(query) == "" {
+		for i := range items {
+			idx = append(idx, i)
+		}
+		return idx
+	}
+	scores := make(map[int]int, len(items))
+	for i, it := range items {
+		best, ok := 0, false
+		for _, field := range []string{it.ID, it.Label, it.Category + " " + it.Summary} {
+			if s, matched := fuzzyScore(query, field); matched && (!ok || s > best) {
+				best, ok = s, true
+			}
+		}
+		if ok {
+			idx = append(idx, i)
+			scores[i] = best
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return scores[idx[a]] > scores[idx[b]] })
+	return idx
+}
+
+// fuzzyScore matches query as a case-insensitive subsequence of text. Runs
+// of consecutive characters and matches at word starts score higher.
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(text))
+	score, qi, run := 0, 0, 0
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			run = 0
+			continue
+		}
+		run++
+		score += run
+		if ti == 0 || strings.ContainsRune(" .-_+/", t[ti-1]) {
+			score += 3
+		}
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// Picks is the selection being built. One profile, any add-ons and assets.
+type Picks struct {
+	Profile string
+	Addons  []string
+	Assets  []string
+}
+
+// Toggle adds or removes it. Picking a profile replaces the current one.
+func (p *Picks) Toggle(it Item) {
+	switch it.Kind {
+	case KindProfile:
+		if p.Profile == it.ID {
+			p.Profile = ""
+		} else {
+			p.Profile = it.ID
+		}
+	case KindAddon:
+		p.Addons = toggle(p.Addons, it.ID)
+	case KindAsset:
+		p.Assets = toggle(p.Assets, it.ID)
+	}
+}
+
+// Has reports whether it is picked.
+func (p Picks) Has(it Item) bool {
+	switch it.Kind {
+	case KindProfile:
+		return p.Profile == it.ID
+	case KindAddon:
+		return contains(p.Addons, it.ID)
+	}
+	return contains(p.Assets, it.ID)
+}
+
+// Selection converts the picks into the selection init generates from. The
+// user chose every entry, so confidence is full. With two palettes picked,
+// the first one picked is primary and the second secondary.
+func (p Picks) Selection() *ai.Selection {
+	sel := &ai.Selection{
+		ProfileID:  p.Profile,
+		AddonIDs:   append([]string(nil), p.Addons...),
+		AssetIDs:   append([]string(nil), p.Assets...),
+		Confidence: 1,
+		Rationale:  "Chosen in launchpad browse",
+	}
+	var palettes []string
+	for _, id := range p.Assets {
+		if strings.HasPrefix(id, "asset.palette.") {
+			palettes = append(palettes, id)
+		}
+	}
+	if len(palettes) == 2 {
+		sel.PaletteRoles = []ai.PaletteRole{
+			{AssetID: palettes[0], Role: ai.PalettePrimary},
+			{AssetID: palettes[1], Role: ai.PaletteSecondary},
+		}
+	}
+	return sel
+}
+
+// Issues lists what stops the picks from generating.
+func (p Picks) Issues() []string {
+	return ai.ValidateSelectionCompatibility(*p.Selection())
+}
+
+func toggle(list []string, id string) []string {
+	for i, v := range list {
+		if v == id {
+			return append(list[:i:i], list[i+1:]...)
+		}
+	}
+	return append(list, id)
+}
+
+func contains(list []string, id string) bool {
+	for _, v := range list {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Run opens the browser. It returns the finished selection, or nil when
+// the user quit without confirming one.
+func Run() (*ai.Selection, error) {
+	m := newModel(Items())
+	final, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, err
+	}
+	if fm := final.(model); fm.done {
+		return fm.picks.Selection(), nil
+	}
+	return nil, nil
+}
+
+type model struct {
+	items    []Item
+	visible  []int
+	cursor   int
+	picks    Picks
+	search   textinput.Model
+	preview  viewport.Model
+	width    int
+	height   int
+	done     bool
+	complain string
+}
+
+const listWidth = 44
+
+var (
+	paneStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(ui.Dim)
+	cursorStyle = lipgloss.NewStyle().Bold(true).Foreground(ui.Magenta)
+)
+
+func newModel(items []Item) model {
+	search := textinput.New()
+	search.Placeholder = "type to filter"
+	search.Prompt = "/ "
+	search.Focus()
+	m := model{items: items, search: search, preview: viewport.New(0, 0)}
+	m.visible = Filter(items, "")
+	return m
+}
+
+func (m model) Init() tea.Cmd { return textinput.Blink }
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.preview.Width = max(msg.Width-listWidth-6, 20)
+		m.preview.Height = max(msg.Height-8, 5)
+		m.refreshPreview()
+		return m, nil
+	case tea.KeyMsg:
+		m.complain = ""
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		case "up", "ctrl+p":
+			if m.cursor > 0 {
+				m.cursor--
+				m.refreshPreview()
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.cursor < len(m.visible)-1 {
+				m.cursor++
+				m.refreshPreview()
+			}
+			return m, nil
+		case "pgup", "pgdown":
+			var cmd tea.Cmd
+			m.preview, cmd = m.preview.Update(msg)
+			return m, cmd
+		case "enter", "tab":
+			if it, ok := m.current(); ok {
+				m.picks.Toggle(it)
+			}
+			return m, nil
+		case "ctrl+s":
+			if issues := m.picks.Issues(); len(issues) > 0 {
+				m.complain = issues[0]
+				return m, nil
+			}
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	before := m.search.Value()
+	m.search, cmd = m.search.Update(msg)
+	if m.search.Value() != before {
+		m.visible = Filter(m.items, m.search.Value())
+		m.cursor = 0
+		m.refreshPreview()
+	}
+	return m, cmd
+}
+
+func (m model) current() (Item, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return Item{}, false
+	}
+	return m.items[m.visible[m.cursor]], true
+}
+
+func (m *model) refreshPreview() {
+	it, ok := m.current()
+	if !ok {
+		m.preview.SetContent(ui.DimStyle.Render("No matches."))
+		return
+	}
+	m.preview.SetContent(lipgloss.NewStyle().Width(m.preview.Width).Render(Preview(it)))
+	m.preview.GotoTop()
+}
+
+// Preview renders it for reading: a header, the summary, the scaffold
+// command for profiles, color swatches or font samples for design assets,
+// then the template itself.
+func Preview(it Item) string {
+	var sb strings.Builder
+	sb.WriteString(ui.Heading.Render(it.Label) + "\n")
+	sb.WriteString(ui.DimStyle.Render(fmt.Sprintf("%s · %s · %s", it.Kind, it.ID, it.Category)) + "\n\n")
+	sb.WriteString(it.Summary + "\n")
+	if it.Scaffold != "" {
+		sb.WriteString("\n" + ui.DimStyle.Render("scaffold: ") + ui.Accent.Render(it.Scaffold) + "\n")
+	}
+	data, err := ai.ReadTemplate(it.Template)
+	if err != nil {
+		return sb.String()
+	}
+	if swatches := palette.Tokens(string(data)); len(swatches) > 0 {
+		sb.WriteString("\n" + RenderSwatches(swatches))
+		for _, issue := range palette.Check(swatches) {
+			sb.WriteString(ui.Warning.Render("  ! ") + issue.String() + "\n")
+		}
+	} else if fonts := FontPairing(string(data)); len(fonts) > 0 {
+		sb.WriteString("\n" + RenderFontPairing(fonts))
+	}
+	sb.WriteString("\n" + ui.DimStyle.Render("── "+it.Template+" ──") + "\n\n")
+	sb.WriteString(string(data))
+	return sb.String()
+}
+
+// Find returns the item whose ID is id, or, failing that, the only item
+// whose ID ends in "."+id, so "obsidian-indigo" finds
+// asset.palette.obsidian-indigo.
+func Find(items []Item, id string) (Item, bool) {
+	var found []Item
+	for _, it := range items {
+		if it.ID == id {
+			return it, true
+		}
+		if strings.HasSuffix(it.ID, "."+id) {
+			found = append(found, it)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], true
+	}
+	return Item{}, false
+}
+
+func (m model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	rows := max(m.height-8, 5)
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	var list strings.Builder
+	for i := start; i < len(m.visible) && i < start+rows; i++ {
+		it := m.items[m.visible[i]]
+		mark := "  "
+		if m.picks.Has(it) {
+			mark = ui.Success.Render("✔ ")
+		}
+		line := fmt.Sprintf("%-8s %s", it.Kind, it.ID)
+		if len(line) > listWidth-4 {
+			line = line[:listWidth-5] + "…"
+		}
+		if i == m.cursor {
+			line = cursorStyle.Render(line)
+		}
+		list.WriteString(mark + line + "\n")
+	}
+
+	panes := lipgloss.JoinHorizontal(lipgloss.Top,
+		paneStyle.Width(listWidth).Height(rows).Render(strings.TrimRight(list.String(), "\n")),
+		paneStyle.Render(m.preview.View()),
+	)
+
+	status := ui.DimStyle.Render("enter: pick · ↑/↓: move · pgup/pgdn: scroll · ctrl+s: done · esc: quit")
+	picked := m.summary()
+	if m.complain != "" {
+		picked = ui.Warning.Render("! " + m.complain)
+	}
+	return m.search.View() + "\n" + panes + "\n" + picked + "\n" + status
+}
+
+func (m model) summary() string {
+	if m.picks.Profile == "" && len(m.picks.Addons)+len(m.picks.Assets) == 0 {
+		return ui.DimStyle.Render("Nothing picked yet — choose a profile to start.")
+	}
+	parts := []string{ui.ProfileID.Render(orDash(m.picks.Profile))}
+	parts = append(parts, m.picks.Addons...)
+	parts = append(parts, m.picks.Assets...)
+	return strings.Join(parts, " · ")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "(no profile)"
+	}
+	return s
+}
@@ -0,0 +1,146 @@
+package browse
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/palette"
+)
+
+func TestItems(t *testing.T) {
+	items := Items()
+	seen := map[Kind]bool{}
+	last := KindProfile
+	for _, it := range items {
+		if it.ID == "core.copilot" || it.ID == "core.agents" {
+			t.Errorf("always-included asset %s listed", it.ID)
+		}
+		if last == KindAsset && it.Kind != KindAsset || last == KindAddon && it.Kind == KindProfile {
+			t.Errorf("%s out of order after %s items", it.ID, last)
+		}
+		last = it.Kind
+		seen[it.Kind] = true
+	}
+	for _, k := range []Kind{KindProfile, KindAddon, KindAsset} {
+		if !seen[k] {
+			t.Errorf("no %s items", k)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	items := []Item{
+		{ID: "go-service", Kind: KindProfile, Label: "Go Service"},
+		{ID: "python-django", Kind: KindProfile, Label: "Python + Django"},
+		{ID: "asset.lint.golangci", Kind: KindAsset, Label: "golangci-lint"},
+	}
+	if got := Filter(items, ""); len(got) != 3 {
+		t.Errorf("empty query = %v", got)
+	}
+	got := Filter(items, "go")
+	if len(got) != 3 || got[0] != 0 {
+		t.Errorf("Filter(go) = %v, want go-service first", got)
+	}
+	if got := Filter(items, "djng"); len(got) != 1 || got[0] != 1 {
+		t.Errorf("Filter(djng) = %v", got)
+	}
+	if got := Filter(items, "zzz"); len(got) != 0 {
+		t.Errorf("Filter(zzz) = %v", got)
+	}
+}
+
+func TestPicks(t *testing.T) {
+	var p Picks
+	p.Toggle(Item{ID: "go-service", Kind: KindProfile})
+	p.Toggle(Item{ID: "rust-axum", Kind: KindProfile})
+	p.Toggle(Item{ID: "data-intensive", Kind: KindAddon})
+	p.Toggle(Item{ID: "asset.app.cli", Kind: KindAsset})
+	p.Toggle(Item{ID: "asset.ci.github", Kind: KindAsset})
+	p.Toggle(Item{ID: "asset.ci.github", Kind: KindAsset})
+
+	sel := p.Selection()
+	if sel.ProfileID != "rust-axum" || len(sel.AddonIDs) != 1 || len(sel.AssetIDs) != 1 || sel.Confidence != 1 {
+		t.Errorf("selection = %+v", sel)
+	}
+	if issues := p.Issues(); len(issues) != 0 {
+		t.Errorf("issues = %v", issues)
+	}
+
+	p.Toggle(Item{ID: "frontend-craft", Kind: KindAddon})
+	if len(p.Issues()) == 0 {
+		t.Error("frontend-craft on rust-axum should be reported")
+	}
+}
+
+func TestPicks_TwoPalettes(t *testing.T) {
+	p := Picks{Profile: "typescript-sveltekit"}
+	p.Toggle(Item{ID: "asset.palette.obsidian-indigo", Kind: KindAsset})
+	p.Toggle(Item{ID: "asset.palette.heroui-blue", Kind: KindAsset})
+
+	sel := p.Selection()
+	if sel.PaletteRole("asset.palette.obsidian-indigo") != ai.PalettePrimary ||
+		sel.PaletteRole("asset.palette.heroui-blue") != ai.PaletteSecondary {
+		t.Errorf("palette roles = %+v, want the first pick primary", sel.PaletteRoles)
+	}
+	if issues := p.Issues(); len(issues) != 0 {
+		t.Errorf("issues = %v", issues)
+	}
+}
+
+func TestModel(t *testing.T) {
+	var m tea.Model = newModel(Items())
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	for _, r := range "rust" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "rust-axum") {
+		t.Fatalf("view missing rust-axum:\n%s", view)
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	got := m.(model)
+	if !got.done || cmd == nil || got.picks.Profile != "rust-axum" {
+		t.Errorf("done = %v, profile = %q", got.done, got.picks.Profile)
+	}
+}
+
+func TestRenderSwatches(t *testing.T) {
+	out := RenderSwatches([]palette.Token{
+		{Label: "Accent", Colors: []string{"#6366f1"}},
+		{Label: "Gradient", Colors: []string{"#6366f1", "#8b5cf6"}},
+	})
+	if !strings.Contains(out, "#6366f1 → #8b5cf6") {
+		t.Errorf("rendered swatches missing gradient stops:\n%s", out)
+	}
+}
+
+func TestFontPairing(t *testing.T) {
+	got := FontPairing("---\nname: x\n---\n\n# Font Pairing: Inter + JetBrains Mono\n")
+	if strings.Join(got, "|") != "Inter|JetBrains Mono" {
+		t.Errorf("FontPairing = %q", got)
+	}
+	if FontPairing("# Palette: Obsidian") != nil {
+		t.Error("non-font template parsed as a pairing")
+	}
+}
+
+func TestPreviewDesignAssets(t *testing.T) {
+	items := Items()
+	for id, want := range map[string]string{
+		"obsidian-indigo": "→",
+		"inter-jetbrains": "JetBrains Mono",
+	} {
+		it, ok := Find(items, id)
+		if !ok {
+			t.Fatalf("Find(%q) found nothing", id)
+		}
+		if out := Preview(it); !strings.Contains(out, want) {
+			t.Errorf("preview of %s missing %q", it.ID, want)
+		}
+	}
+	if _, ok := Find(items, "no-such-item"); ok {
+		t.Error("Find matched an unknown id")
+	}
+}
@@ -0,0 +1,79 @@
+package browse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ecoker/launchpad/internal/palette"
+	"github.com/ecoker/launchpad/internal/ui"
+)
+
+// pairingTitle names the fonts in a font template's heading.
+var pairingTitle = regexp.MustCompile(`(?m)^# Font Pairing:\s*(.+?)\s*$`)
+
+// RenderSwatches draws each swatch as a block of its color beside its label
+// and value. lipgloss picks the closest color the terminal can show.
+func RenderSwatches(swatches []palette.Token) string {
+	const block = "      "
+	width, stops := 0, 0
+	for _, s := range swatches {
+		width = max(width, lipgloss.Width(s.Label))
+		stops = max(stops, len(s.Colors))
+	}
+	var sb strings.Builder
+	for _, s := range swatches {
+		var blocks []string
+		for _, c := range s.Colors {
+			blocks = append(blocks, lipgloss.NewStyle().Background(lipgloss.Color(c)).Render(block))
+		}
+		pad := strings.Repeat(block, stops-len(s.Colors))
+		fmt.Fprintf(&sb, "  %s%s  %-*s  %s\n", strings.Join(blocks, ""), pad, width, s.Label,
+			ui.DimStyle.Render(strings.Join(s.Colors, " → ")))
+	}
+	return sb.String()
+}
+
+// FontPairing returns the families a font template pairs, in order: the UI
+// face first, then the monospace.
+func FontPairing(template string) []string {
+	m := pairingTitle.FindStringSubmatch(template)
+	if m == nil {
+		return nil
+	}
+	var fonts []string
+	for _, f := range strings.Split(m[1], "+") {
+		if f = strings.TrimSpace(f); f != "" {
+			fonts = append(fonts, f)
+		}
+	}
+	return fonts
+}
+
+// fontSamples are the specimen lines shown for each family of a pairing.
+var fontSamples = []string{
+	"Aa Bb Cc — The quick brown fox jumps over the lazy dog",
+	"0O 1lI {} => != const id = 0x1f;",
+}
+
+// RenderFontPairing shows each family with the kind of text it is for. A
+// terminal draws everything in its own font, so this names the roles rather
+// than the typefaces' shapes.
+func RenderFontPairing(fonts []string) string {
+	width := 0
+	for _, f := range fonts {
+		width = max(width, lipgloss.Width(f))
+	}
+	var sb strings.Builder
+	for i, f := range fonts {
+		sample := fontSamples[min(i, len(fontSamples)-1)]
+		style := lipgloss.NewStyle()
+		if i == 0 {
+			style = style.Bold(true)
+		}
+		fmt.Fprintf(&sb, "  %-*s  %s\n", width, f, style.Render(sample))
+	}
+	sb.WriteString(ui.DimStyle.Render("  (shown in your terminal's font)") + "\n")
+	return sb.String()
+}
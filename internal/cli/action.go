@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ghaction"
+	"github.com/ecoker/launchpad/internal/manifest"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/ecoker/launchpad/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var actionCmd = &cobra.Command{
+	Use:   "action",
+	Short: "Run as a GitHub Action step",
+	Long: `Run launchpad inside a GitHub Actions workflow.
+
+Inputs are read from INPUT_* variables (set by action.yml):
+  command     what to run: validate (default), update, or regen
+  path        repository directory to work on (default ".")
+  only        update/regen: comma-separated concerns, as in regen --only
+  targets     update/regen: comma-separated AI tools, as in regen --targets
+  pr_comment  post a summary comment on the pull request (default true)
+  token       token for the comment, usually ${{ github.token }}
+
+validate checks the instruction files. Problems are reported as inline
+annotations, counts are written to the step outputs (checked, errors,
+warnings), and a summary is added to the job page.
+
+update regenerates the instruction files only when launchpad hook check
+would suggest it: updated templates, claims the code has outgrown, or a
+changed stack. regen regenerates them unconditionally. Both need
+OPENAI_API_KEY, write the regenerated file count to the regenerated output
+(update also sets outdated), and leave committing the result to later
+steps. Edited generated files are overwritten with a backup; files
+launchpad didn't write are left alone.
+
+The pull request comment is edited in place on later runs rather than
+posted again.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runAction,
+}
+
+func runAction(cmd *cobra.Command, args []string) error {
+	gh := ghaction.New(os.Getenv)
+	switch command := gh.Input("command", "validate"); command {
+	case "validate":
+		return runActionValidate(cmd.Context(), gh)
+	case "update":
+		return runActionRegen(cmd.Context(), gh, command, true)
+	case "regen":
+		return runActionRegen(cmd.Context(), gh, command, false)
+	default:
+		return fmt.Errorf("unsupported action command %q (supported: validate, update, regen)", command)
+	}
+}
+
+// workspacePrefix returns root relative to the workspace, with a trailing
+// slash, for annotation and summary paths; empty when root is the
+// workspace or lies outside it.
+func workspacePrefix(root string) string {
+	ws := os.Getenv("GITHUB_WORKSPACE")
+	if ws == "" {
+		return ""
+	}
+	if rel, err := filepath.Rel(ws, root); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel) + "/"
+	}
+	return ""
+}
+
+func runActionValidate(ctx context.Context, gh *ghaction.Runner) error {
+	root, err := filepath.Abs(gh.Input("path", "."))
+	if err != nil {
+		return fmt.Errorf("resolving path: %w", err)
+	}
+	diags, checked, err := validate.Dir(root)
+	if err != nil {
+		return err
+	}
+	errs := validate.Errors(diags)
+
+	// Annotation paths are relative to the workspace root, which may differ
+	// from the directory being checked.
+	prefix := workspacePrefix(root)
+	for _, d := range diags {
+		fmt.Println(ghaction.Annotation(string(d.Severity), prefix+d.File, d.Line, d.Rule, d.Message))
+	}
+
+	outputs := []struct {
+		name  string
+		value int
+	}{
+		{"checked", checked},
+		{"errors", len(errs)},
+		{"warnings", len(diags) - len(errs)},
+	}
+	for _, o := range outputs {
+		if err := gh.SetOutput(o.name, fmt.Sprint(o.value)); err != nil {
+			return err
+		}
+	}
+
+	summary := validateSummary(prefix, checked, diags, len(errs))
+	if err := gh.AddSummary(summary); err != nil {
+		return err
+	}
+	if gh.BoolInput("pr_comment", true) {
+		// A clean run only refreshes an earlier comment, so fixed problems
+		// don't linger on the pull request.
+		if err := commentOnPullRequest(ctx, gh, "validate", summary, len(diags) > 0); err != nil {
+			// A missing comment shouldn't fail a check that already annotated.
+			fmt.Println(ghaction.Message("warning", err.Error()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d error(s) in %d instruction file(s)", len(errs), checked)
+	}
+	return nil
+}
+
+// validateSummary renders the validation result as markdown for the job
+// summary and pull request comment.
+func validateSummary(prefix string, checked int, diags []validate.Diagnostic, errCount int) string {
+	var sb strings.Builder
+	sb.WriteString("### Launchpad instruction check\n\n")
+	if len(diags) == 0 {
+		fmt.Fprintf(&sb, "✅ %d instruction file(s) are valid.\n", checked)
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "%d error(s), %d warning(s) in %d instruction file(s).\n\n",
+		errCount, len(diags)-errCount, checked)
+	sb.WriteString("| Severity | Location | Rule | Problem |\n|---|---|---|---|\n")
+	for _, d := range diags {
+		fmt.Fprintf(&sb, "| %s | `%s%s:%d` | `%s` | %s |\n",
+			d.Severity, prefix, d.File, d.Line, d.Rule, strings.ReplaceAll(d.Message, "|", `\|`))
+	}
+	return sb.String()
+}
+
+// runActionRegen regenerates the instruction files under the path input.
+// With ifOutdated set it first asks refreshReasons, and does nothing when
+// there are none.
+func runActionRegen(ctx context.Context, gh *ghaction.Runner, command string, ifOutdated bool) error {
+	root, err := filepath.Abs(gh.Input("path", "."))
+	if err != nil {
+		return fmt.Errorf("resolving path: %w", err)
+	}
+	if err := pullState(root); err != nil {
+		return err
+	}
+	m, err := manifest.Load(root)
+	if err != nil {
+		return err
+	}
+	if m == nil || m.ProfileID == "" {
+		return fmt.Errorf("%s has no recorded selection — run launchpad init first", ui.DisplayPath(root))
+	}
+
+	var reasons []string
+	if ifOutdated {
+		if reasons, err = refreshReasons(root, m); err != nil {
+			return err
+		}
+		if err := gh.SetOutput("outdated", fmt.Sprint(len(reasons) > 0)); err != nil {
+			return err
+		}
+	}
+
+	var created []string
+	if !ifOutdated || len(reasons) > 0 {
+		flagRegenOnly = gh.Input("only", "")
+		flagRegenTargets = gh.Input("targets", "")
+		// No one can answer a conflict prompt in a workflow.
+		flagForce = forceInstructions
+		if created, err = regenerate(root); err != nil {
+			return err
+		}
+	}
+	if err := gh.SetOutput("regenerated", fmt.Sprint(len(created))); err != nil {
+		return err
+	}
+
+	summary := regenSummary(workspacePrefix(root), root, ifOutdated, reasons, created)
+	if err := gh.AddSummary(summary); err != nil {
+		return err
+	}
+	if gh.BoolInput("pr_comment", true) {
+		if err := commentOnPullRequest(ctx, gh, command, summary, len(created) > 0); err != nil {
+			fmt.Println(ghaction.Message("warning", err.Error()))
+		}
+	}
+	return nil
+}
+
+// regenSummary renders an update or regen result as markdown for the job
+// summary and pull request comment.
+func regenSummary(prefix, root string, ifOutdated bool, reasons, created []string) string {
+	var sb strings.Builder
+	sb.WriteString("### Launchpad instruction refresh\n\n")
+	if ifOutdated && len(reasons) == 0 {
+		sb.WriteString("✅ The instruction files are up to date.\n")
+		return sb.String()
+	}
+	if len(reasons) > 0 {
+		sb.WriteString("The instruction files were out of date:\n\n")
+		for _, r := range reasons {
+			fmt.Fprintf(&sb, "- %s\n", r)
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "Regenerated %d file(s):\n\n", len(created))
+	for _, path := range created {
+		if rel, err := filepath.Rel(root, path); err == nil {
+			path = filepath.ToSlash(rel)
+		}
+		fmt.Fprintf(&sb, "- `%s%s`\n", prefix, path)
+	}
+	return sb.String()
+}
+
+// commentMarker tags the pull request comment a command leaves, so later
+// runs of the same command find and edit it instead of adding another.
+func commentMarker(command string) string {
+	return "<!-- launchpad-action:" + command + " -->"
+}
+
+// commentOnPullRequest puts body in command's comment on the triggering
+// pull request, editing the comment an earlier run left. Without one, a new
+// comment is posted only when create is set. Runs that weren't triggered by
+// a pull request, or have no token, skip the comment.
+func commentOnPullRequest(ctx context.Context, gh *ghaction.Runner, command, body string, create bool) error {
+	number, err := gh.PullRequestNumber()
+	if err != nil || number == 0 {
+		return err
+	}
+	token := gh.Input("token", os.Getenv("GITHUB_TOKEN"))
+	if token == "" {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	marker := commentMarker(command)
+	id, err := gh.FindComment(ctx, client, token, number, marker)
+	if err != nil {
+		return err
+	}
+	body = marker + "\n" + body
+	if id != 0 {
+		return gh.EditComment(ctx, client, token, id, body)
+	}
+	if !create {
+		return nil
+	}
+	return gh.Comment(ctx, client, token, number, body)
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/ghaction"
+)
+
+func TestCommentOnPullRequest_EditsMarkedComment(t *testing.T) {
+	event := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(event, []byte(`{"pull_request":{"number":7}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var existing []map[string]any
+	var posts, patches []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, _ := io.ReadAll(req.Body)
+		var payload map[string]string
+		json.Unmarshal(data, &payload)
+		switch req.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(existing)
+		case http.MethodPost:
+			posts = append(posts, payload["body"])
+			existing = append(existing, map[string]any{"id": 11, "body": payload["body"]})
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPatch:
+			patches = append(patches, req.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	gh := ghaction.New(func(k string) string {
+		return map[string]string{
+			"GITHUB_EVENT_PATH": event,
+			"GITHUB_API_URL":    srv.URL,
+			"GITHUB_REPOSITORY": "acme/app",
+			"INPUT_TOKEN":       "tok",
+		}[k]
+	})
+	ctx := context.Background()
+
+	if err := commentOnPullRequest(ctx, gh, "validate", "clean", false); err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 0 {
+		t.Fatalf("a clean first run posted %q", posts)
+	}
+	if err := commentOnPullRequest(ctx, gh, "validate", "2 errors", true); err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 1 || !strings.HasPrefix(posts[0], commentMarker("validate")) {
+		t.Fatalf("posts = %q, want one marked comment", posts)
+	}
+	if err := commentOnPullRequest(ctx, gh, "validate", "1 error", true); err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 1 || len(patches) != 1 || patches[0] != "/repos/acme/app/issues/comments/11" {
+		t.Errorf("second run posted %q and patched %q, want the first comment edited", posts, patches)
+	}
+	if err := commentOnPullRequest(ctx, gh, "update", "refreshed", true); err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 2 {
+		t.Errorf("update reused validate's comment; posts = %q", posts)
+	}
+}
+
+func TestRegenSummary(t *testing.T) {
+	root := filepath.FromSlash("/work/app")
+	got := regenSummary("app/", root, true, nil, nil)
+	if !strings.Contains(got, "up to date") {
+		t.Errorf("no-op update summary = %q", got)
+	}
+	got = regenSummary("app/", root, true, []string{"updated templates: go-service"},
+		[]string{filepath.Join(root, "AGENTS.md")})
+	for _, want := range []string{"- updated templates: go-service", "Regenerated 1 file(s)", "- `app/AGENTS.md`"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ecoker/launchpad/internal/audit"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit <log.jsonl>",
+	Short: "Verify the hash chain of an audit log",
+	Long: `Check that an audit log written by launchpad is intact: every record
+must carry the hash of the line before it, with sequence numbers unbroken.
+
+Logging is enabled with "audit_log" in launchpad's config, or by setting
+` + audit.PathEnv + ` to a file path. Prompts and responses are redacted
+before they are written; each record also stores the SHA-256 of the exact
+text sent and received.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runAudit,
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	n, err := audit.Verify(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w (%d record(s) intact before it)", args[0], err, n)
+	}
+	fmt.Println(ui.Success.Render("✔"), fmt.Sprintf("%d record(s), chain intact", n))
+	return nil
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/browse"
+	"github.com/ecoker/launchpad/internal/sandbox"
+	"github.com/ecoker/launchpad/internal/scaffold"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var flagBrowseOut string
+
+var browseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "Browse the catalog and build a selection interactively",
+	Long: `Search profiles, add-ons, and assets, preview the templates behind them,
+and pick a stack without a conversation. The finished selection is saved
+so init can generate from it:
+
+  launchpad browse
+  launchpad init ./my-app --selection .launchpad/selection.json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runBrowse,
+}
+
+func init() {
+	browseCmd.Flags().StringVarP(&flagBrowseOut, "out", "o", ".launchpad/selection.json", "Where to save the selection")
+}
+
+func runBrowse(cmd *cobra.Command, args []string) error {
+	sel, err := browse.Run()
+	if err != nil {
+		return err
+	}
+	if sel == nil {
+		fmt.Println(ui.DimStyle.Render("No selection saved."))
+		return nil
+	}
+
+	data, err := json.MarshalIndent(sel, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := sandbox.MkdirAll(filepath.Dir(flagBrowseOut), 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(flagBrowseOut), err)
+	}
+	if err := sandbox.WriteFile(flagBrowseOut, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("saving selection: %w", err)
+	}
+
+	printSelectionSummary(sel)
+	fmt.Printf("%s Saved to %s\n\n", ui.Success.Render("✔"), ui.FileStyle.Render(flagBrowseOut))
+	fmt.Println(ui.Heading.Render("Next steps:"))
+	step := 1
+	if p := scaffold.FindProfile(sel.ProfileID); p != nil && p.ScaffoldCmd != "" {
+		scaffoldDisplay := strings.ReplaceAll(p.ScaffoldCmd, "{{name}}", "my-app")
+		scaffoldDisplay = strings.ReplaceAll(scaffoldDisplay, "{{module}}", "my-app")
+		fmt.Printf("  %s Scaffold the project: %s\n", ui.DimStyle.Render(fmt.Sprintf("%d.", step)), ui.Accent.Render(scaffoldDisplay))
+		step++
+	}
+	fmt.Printf("  %s Generate instructions: %s\n", ui.DimStyle.Render(fmt.Sprintf("%d.", step)),
+		ui.Accent.Render("launchpad init ./my-app --selection "+flagBrowseOut))
+	fmt.Println()
+	return nil
+}
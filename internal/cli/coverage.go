@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/ecoker/launchpad/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var flagCoverageJSON bool
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage [directory]",
+	Short: "Show which parts of the codebase no scoped instruction file covers",
+	Long: `Map the repository's source files against the applyTo globs of its
+.github/instructions files and report, per directory and file type, how many
+files have scoped guidance. Directories are grouped two levels deep.
+
+Instruction files scoped to "**" apply everywhere and don't count. Gaps list
+the catalog entry that would cover them, where one clearly does — add it with
+launchpad init.
+
+With --json, prints {"files": N, "covered": N, "areas": [...]}.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runCoverage,
+}
+
+func init() {
+	coverageCmd.Flags().BoolVar(&flagCoverageJSON, "json", false, "Print the report as JSON")
+}
+
+// coverageReport is the --json output.
+type coverageReport struct {
+	Files   int             `json:"files"`
+	Covered int             `json:"covered"`
+	Areas   []validate.Area `json:"areas"`
+}
+
+func runCoverage(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("resolving path: %w", err)
+	}
+	areas, err := validate.Coverage(root)
+	if err != nil {
+		return err
+	}
+	report := coverageReport{Areas: areas}
+	for _, a := range areas {
+		report.Files += a.Files
+		report.Covered += a.Covered
+	}
+
+	if flagCoverageJSON {
+		if report.Areas == nil {
+			report.Areas = []validate.Area{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	if report.Files == 0 {
+		fmt.Println(ui.Warning.Render("No source files found in " + ui.DisplayPath(root)))
+		return nil
+	}
+	fmt.Println(ui.Heading.Render(fmt.Sprintf("Scoped guidance covers %d of %d source file(s) (%.0f%%)",
+		report.Covered, report.Files, 100*float64(report.Covered)/float64(report.Files))))
+	fmt.Println()
+	for _, a := range areas {
+		where := fmt.Sprintf("%-28s %-7s %4d file(s)", a.Dir, a.Ext, a.Files)
+		switch {
+		case a.Gap():
+			fmt.Printf("  %s %s  %s\n", ui.Warning.Render("✗"), where, ui.DimStyle.Render("no scoped guidance"))
+		case a.Covered < a.Files:
+			fmt.Printf("  %s %s  %s\n", ui.Warning.Render("~"), where,
+				ui.DimStyle.Render(fmt.Sprintf("%d covered by %s", a.Covered, joinBase(a.Instructions))))
+		default:
+			fmt.Printf("  %s %s  %s\n", ui.Success.Render("✔"), where, ui.DimStyle.Render(joinBase(a.Instructions)))
+		}
+	}
+
+	suggestions := coverageSuggestions(areas)
+	if len(suggestions) > 0 {
+		fmt.Println()
+		fmt.Println(ui.Heading.Render("To fill the gaps:"))
+		labels := map[string]string{}
+		for _, c := range ai.Catalog() {
+			labels[c.ID] = c.Label
+		}
+		for _, id := range suggestions {
+			fmt.Printf("  %s %s\n", ui.ProfileID.Render(id), ui.DimStyle.Render(labels[id]))
+		}
+	}
+	return nil
+}
+
+// coverageSuggestions returns the distinct suggestions of the gaps, in the
+// order they were found.
+func coverageSuggestions(areas []validate.Area) []string {
+	var out []string
+	for _, a := range areas {
+		if a.Suggest != "" && !containsID(out, a.Suggest) {
+			out = append(out, a.Suggest)
+		}
+	}
+	return out
+}
+
+func joinBase(paths []string) string {
+	s := ""
+	for i, p := range paths {
+		if i > 0 {
+			s += ", "
+		}
+		s += filepath.Base(p)
+	}
+	return s
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/validate"
+)
+
+func TestCoverageSuggestionsAreInCatalog(t *testing.T) {
+	root := t.TempDir()
+	for _, f := range []string{"main.go", "src/lib.rs", "lib/app.ex", "app/models/user.rb", "app/Http/Kernel.php",
+		"Api/Program.cs", "src/Main.java", "lib/main.dart", "scenes/player.gd", "infra/main.tf",
+		"db/schema.sql", "styles/app.css", "web/App.vue", "web/Page.svelte", "spec/user_test.py"} {
+		full := filepath.Join(root, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	areas, err := validate.Coverage(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := map[string]bool{}
+	for _, c := range ai.Catalog() {
+		ids[c.ID] = true
+	}
+	suggestions := coverageSuggestions(areas)
+	if len(suggestions) < 10 {
+		t.Fatalf("suggestions = %v, want one per stack", suggestions)
+	}
+	for _, id := range suggestions {
+		if !ids[id] {
+			t.Errorf("suggestion %q is not in the catalog", id)
+		}
+	}
+}
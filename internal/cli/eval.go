@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/eval"
+	"github.com/ecoker/launchpad/internal/sandbox"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagEvalCases   string
+	flagEvalRuns    int
+	flagEvalRecords string
+	flagEvalTarget  float64
+	flagEvalReport  bool
+	flagEvalWorkers int
+	flagEvalRetries int
+	flagEvalJUnit   string
+)
+
+var evalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Replay scripted conversations and report confidence calibration",
+	Long: `Run each eval case through a fresh conversation against the live model,
+score the extracted selection against the case rubric, and append the
+results to a records file. Then print a calibration report comparing
+self-reported confidence with actual pass rates per model, including the
+lowest threshold that meets the target pass rate.
+
+Cases run --parallel at a time. A run that fails or errors is retried up
+to --retries times; one that then passes is marked flaky, and only the
+final attempt is recorded. --junit writes the runs as JUnit XML with one
+suite per model, for nightly CI dashboards.
+
+Uses OPENAI_API_KEY and LAUNCHPAD_MODEL like init. Every run costs API calls.`,
+	Hidden:       true,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runEval,
+}
+
+func init() {
+	evalCmd.Flags().StringVar(&flagEvalCases, "cases", "", "JSON file of eval cases (default: built-in cases)")
+	evalCmd.Flags().IntVar(&flagEvalRuns, "runs", 1, "How many times to run each case")
+	evalCmd.Flags().StringVar(&flagEvalRecords, "records", ".launchpad/eval/records.jsonl", "Where run records accumulate")
+	evalCmd.Flags().Float64Var(&flagEvalTarget, "target", 0.9, "Pass rate a suggested threshold must reach")
+	evalCmd.Flags().BoolVar(&flagEvalReport, "report-only", false, "Skip running cases; report on existing records")
+	evalCmd.Flags().IntVar(&flagEvalWorkers, "parallel", 4, "How many cases run at once")
+	evalCmd.Flags().IntVar(&flagEvalRetries, "retries", 0, "Extra attempts for a case that fails or errors")
+	evalCmd.Flags().StringVar(&flagEvalJUnit, "junit", "", "Also write results as JUnit XML to this file")
+}
+
+func runEval(cmd *cobra.Command, args []string) error {
+	var ran []eval.Record
+	if !flagEvalReport {
+		var err error
+		if ran, err = runEvalCases(); err != nil {
+			return err
+		}
+	}
+	recs, err := eval.LoadRecords(flagEvalRecords)
+	if err != nil {
+		return err
+	}
+	if flagEvalJUnit != "" {
+		// A report-only run exports every stored record.
+		if flagEvalReport {
+			ran = recs
+		}
+		if err := writeJUnit(flagEvalJUnit, ran); err != nil {
+			return err
+		}
+	}
+	fmt.Println()
+	fmt.Print(eval.Calibrate(recs, ai.ConfidenceThreshold, flagEvalTarget))
+	return nil
+}
+
+func writeJUnit(path string, recs []eval.Record) error {
+	var buf bytes.Buffer
+	if err := eval.WriteJUnit(&buf, recs); err != nil {
+		return err
+	}
+	if err := sandbox.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create junit dir: %w", err)
+	}
+	return sandbox.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+func runEvalCases() ([]eval.Record, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		apiKey = loadKeyFromDotEnv()
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("eval needs OPENAI_API_KEY")
+	}
+
+	cases := eval.DefaultCases
+	if flagEvalCases != "" {
+		var err error
+		if cases, err = eval.LoadCases(flagEvalCases); err != nil {
+			return nil, err
+		}
+	}
+
+	providerOpts := providerOptions(os.Getenv("LAUNCHPAD_MODEL"))
+
+	runner := eval.Runner{
+		// Each attempt needs its own thread; providers carry conversation state.
+		NewConversation: func() (eval.Conversation, error) {
+			provider, err := audited(ai.NewOpenAIProvider(apiKey, providerOpts...), ".")
+			if err != nil {
+				return nil, err
+			}
+			return ai.NewEngine(provider), nil
+		},
+		Model:   ai.NewOpenAIProvider(apiKey, providerOpts...).Model(),
+		Workers: flagEvalWorkers,
+		Retries: flagEvalRetries,
+		OnDone:  printEvalRecord,
+	}
+
+	fmt.Println(ui.DimStyle.Render(fmt.Sprintf("Running %d case(s) × %d run(s), %d at a time...", len(cases), flagEvalRuns, max(flagEvalWorkers, 1))))
+	recs := runner.Run(context.Background(), cases, flagEvalRuns)
+	return recs, eval.AppendRecords(flagEvalRecords, recs)
+}
+
+func printEvalRecord(rec eval.Record) {
+	name := rec.Case
+	if rec.Run > 1 {
+		name = fmt.Sprintf("%s (run %d)", rec.Case, rec.Run)
+	}
+	var note string
+	if rec.Attempts > 1 {
+		note = fmt.Sprintf(", %d attempts", rec.Attempts)
+	}
+	switch {
+	case rec.Error != "":
+		fmt.Printf("%s %s  %s\n", ui.Warning.Render("!"), name, ui.DimStyle.Render(rec.Error+note))
+	case rec.Passed:
+		label := "✔"
+		if rec.Flaky {
+			label = "~"
+		}
+		fmt.Printf("%s %s  %s\n", ui.Success.Render(label), name, ui.DimStyle.Render(fmt.Sprintf("confidence %.2f%s", rec.Confidence, note)))
+	default:
+		fmt.Printf("%s %s  %s\n", ui.Error.Render("✘"), name,
+			ui.DimStyle.Render(fmt.Sprintf("confidence %.2f — %v%s", rec.Confidence, rec.Failures, note)))
+	}
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/spf13/cobra"
+)
+
+var flagExtractTranscript string
+
+var extractCmd = &cobra.Command{
+	Use:   "extract",
+	Short: "Classify a conversation and print the selection as JSON",
+	Long: `Run only the decision-extraction step over a conversation held
+somewhere else, and print the resulting selection JSON on stdout.
+
+The transcript is read from --transcript, or from stdin when the flag is
+omitted or "-". It may be plain text, a JSON array of {"role", "content"}
+messages, an object with a "messages" array, or a session.json recorded by
+launchpad init --debug.
+
+Compatibility problems with the extracted selection are reported on
+stderr; stdout only ever carries the JSON. Uses OPENAI_API_KEY and
+LAUNCHPAD_MODEL like init.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runExtract,
+}
+
+func init() {
+	extractCmd.Flags().StringVar(&flagExtractTranscript, "transcript", "", `Transcript file (default: stdin, or "-")`)
+}
+
+func runExtract(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if flagExtractTranscript == "" || flagExtractTranscript == "-" {
+		if info, statErr := os.Stdin.Stat(); statErr == nil && info.Mode()&os.ModeCharDevice != 0 {
+			return fmt.Errorf("no transcript: pass --transcript or pipe one on stdin")
+		}
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(flagExtractTranscript)
+	}
+	if err != nil {
+		return fmt.Errorf("reading transcript: %w", err)
+	}
+
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		apiKey = loadKeyFromDotEnv()
+	}
+	if apiKey == "" {
+		return fmt.Errorf("extract needs OPENAI_API_KEY")
+	}
+	providerOpts := providerOptions(os.Getenv("LAUNCHPAD_MODEL"))
+	decisions, err := decisionMap(".")
+	if err != nil {
+		return err
+	}
+	provider, err := audited(ai.NewOpenAIProvider(apiKey, providerOpts...), ".")
+	if err != nil {
+		return err
+	}
+	engine := ai.NewEngine(provider, ai.WithDecisionMap(decisions))
+
+	sel, err := engine.ExtractFromTranscript(context.Background(), transcriptText(data))
+	if err != nil {
+		return fmt.Errorf("extracting decision: %w", err)
+	}
+	for _, issue := range ai.ValidateSelectionCompatibility(*sel) {
+		fmt.Fprintln(os.Stderr, "! "+issue)
+	}
+
+	out, err := json.MarshalIndent(sel, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// transcriptMessage is one chat message in the common role/content shape.
+type transcriptMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// transcriptText flattens a transcript into "role: content" lines. Input
+// that isn't one of the recognized JSON shapes is returned as-is.
+func transcriptText(data []byte) string {
+	var messages []transcriptMessage
+	if err := json.Unmarshal(data, &messages); err == nil && len(messages) > 0 {
+		return formatMessages(messages)
+	}
+	var doc struct {
+		Messages []transcriptMessage `json:"messages"`
+		Turns    []string            `json:"turns"`
+		Replies  []string            `json:"replies"`
+	}
+	if err := json.Unmarshal(data, &doc); err == nil {
+		if len(doc.Messages) > 0 {
+			return formatMessages(doc.Messages)
+		}
+		if len(doc.Turns) > 0 {
+			for i, turn := range doc.Turns {
+				messages = append(messages, transcriptMessage{Role: "user", Content: turn})
+				if i < len(doc.Replies) {
+					messages = append(messages, transcriptMessage{Role: "assistant", Content: doc.Replies[i]})
+				}
+			}
+			return formatMessages(messages)
+		}
+	}
+	return string(data)
+}
+
+func formatMessages(messages []transcriptMessage) string {
+	var sb strings.Builder
+	for _, m := range messages {
+		if strings.TrimSpace(m.Content) == "" {
+			continue
+		}
+		role := m.Role
+		if role == "" {
+			role = "user"
+		}
+		fmt.Fprintf(&sb, "%s: %s\n\n", role, strings.TrimSpace(m.Content))
+	}
+	return sb.String()
+}
@@ -0,0 +1,27 @@
+package cli
+
+import "testing"
+
+func TestTranscriptText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "we need a chat app", "we need a chat app"},
+		{"message array", `[{"role":"user","content":"a chat app"},{"role":"assistant","content":"Phoenix."}]`,
+			"user: a chat app\n\nassistant: Phoenix.\n\n"},
+		{"messages object", `{"messages":[{"role":"system","content":""},{"role":"user","content":"a CLI"}]}`,
+			"user: a CLI\n\n"},
+		{"session", `{"turns":["a CLI","yes"],"replies":["Go?"]}`,
+			"user: a CLI\n\nassistant: Go?\n\nuser: yes\n\n"},
+		{"unrelated json", `{"name":"x"}`, `{"name":"x"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transcriptText([]byte(tt.in)); got != tt.want {
+				t.Errorf("transcriptText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/detect"
+	"github.com/ecoker/launchpad/internal/ui"
+)
+
+// healthLabels name the scorecard checks for display.
+var healthLabels = map[string]string{
+	"tests": "Tests",
+	"lint":  "Lint config",
+	"ci":    "CI",
+	"docs":  "Docs",
+}
+
+// printHealth shows the scorecard for existing code, with what each gap
+// will add to the selection.
+func printHealth(checks []detect.Check) {
+	passed := 0
+	for _, c := range checks {
+		if c.OK() {
+			passed++
+		}
+	}
+	fmt.Printf("%s %s\n", ui.DimStyle.Render("Project health:"), ui.Accent.Render(fmt.Sprintf("%d/%d", passed, len(checks))))
+	for _, c := range checks {
+		switch {
+		case c.OK():
+			fmt.Printf("  %s %-12s %s\n", ui.Success.Render("✔"), healthLabels[c.Name], ui.DimStyle.Render(c.Found))
+		case c.Suggest != "":
+			fmt.Printf("  %s %-12s %s\n", ui.Warning.Render("✗"), healthLabels[c.Name], ui.DimStyle.Render("none found — will include "+c.Suggest))
+		default:
+			fmt.Printf("  %s %-12s %s\n", ui.Warning.Render("✗"), healthLabels[c.Name], ui.DimStyle.Render("none found"))
+		}
+	}
+}
+
+// applyHealth adds the suggestion of every failed check to sel, unless sel
+// has it already or it doesn't fit the selected profile, and returns what
+// was added.
+func applyHealth(sel *ai.Selection, checks []detect.Check) []string {
+	var added []string
+	for _, c := range checks {
+		if c.OK() || c.Suggest == "" {
+			continue
+		}
+		next := *sel
+		if addon, ok := strings.CutPrefix(c.Suggest, "addon."); ok {
+			if containsID(sel.AddonIDs, addon) {
+				continue
+			}
+			next.AddonIDs = append(append([]string(nil), sel.AddonIDs...), addon)
+		} else {
+			if containsID(sel.AssetIDs, c.Suggest) {
+				continue
+			}
+			next.AssetIDs = append(append([]string(nil), sel.AssetIDs...), c.Suggest)
+		}
+		if len(ai.ValidateSelectionCompatibility(next)) > len(ai.ValidateSelectionCompatibility(*sel)) {
+			continue
+		}
+		*sel = next
+		added = append(added, c.Suggest)
+	}
+	return added
+}
+
+func containsID(list []string, id string) bool {
+	for _, v := range list {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/detect"
+)
+
+func TestApplyHealth(t *testing.T) {
+	checks := []detect.Check{
+		{Name: "tests", Found: "main_test.go", Suggest: "asset.testing.pragmatic"},
+		{Name: "lint", Suggest: "asset.lint.strict"},
+		{Name: "ci", Suggest: "addon.ci-cd"},
+		{Name: "docs"},
+	}
+	sel := &ai.Selection{ProfileID: "go-service", AssetIDs: []string{"asset.lint.strict"}}
+	added := applyHealth(sel, checks)
+	if !reflect.DeepEqual(added, []string{"addon.ci-cd"}) {
+		t.Errorf("added = %v, want only the CI add-on", added)
+	}
+	if !reflect.DeepEqual(sel.AddonIDs, []string{"ci-cd"}) || len(sel.AssetIDs) != 1 {
+		t.Errorf("selection = %+v", sel)
+	}
+	if again := applyHealth(sel, checks); len(again) != 0 {
+		t.Errorf("second pass added %v", again)
+	}
+}
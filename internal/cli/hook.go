@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/detect"
+	"github.com/ecoker/launchpad/internal/manifest"
+	"github.com/ecoker/launchpad/internal/sandbox"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/ecoker/launchpad/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagHookName  string
+	flagHookEvery time.Duration
+)
+
+// hookMarker brackets the lines launchpad adds to a git hook, so install
+// can leave the rest of an existing hook alone and uninstall can find them.
+const (
+	hookBegin = "# >>> launchpad refresh reminder"
+	hookEnd   = "# <<< launchpad refresh reminder"
+)
+
+// checkedFile records when hook check last looked, relative to the
+// project root.
+const checkedFile = ".launchpad/refresh-checked"
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Install a git hook that reminds you when instructions need a refresh",
+}
+
+var hookInstallCmd = &cobra.Command{
+	Use:   "install [directory]",
+	Short: "Add a refresh reminder to a git hook",
+	Long: `Add a few lines to a git hook (post-merge by default) that run
+launchpad hook check. At most once per --every interval, the check looks for
+updated templates, instruction claims the code has outgrown, and a stack
+that no longer matches the one generated for, and prints how to regenerate
+if it finds any. It never blocks the git command.
+
+An existing hook keeps its content; the reminder is appended.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runHookInstall,
+}
+
+var hookUninstallCmd = &cobra.Command{
+	Use:          "uninstall [directory]",
+	Short:        "Remove the refresh reminder from a git hook",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runHookUninstall,
+}
+
+var hookCheckCmd = &cobra.Command{
+	Use:   "check [directory]",
+	Short: "Say whether the generated instructions are due for a refresh",
+	Long: `Look for reasons to regenerate: templates that changed since the last
+run, instruction claims that no longer match the codebase, and a detected
+stack that differs from the generated one. Prints nothing when there are
+none, or when it already ran within --every.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runHookCheck,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{hookInstallCmd, hookUninstallCmd} {
+		c.Flags().StringVar(&flagHookName, "hook", "post-merge", "Git hook to use (post-merge, post-checkout, pre-push, ...)")
+	}
+	for _, c := range []*cobra.Command{hookInstallCmd, hookCheckCmd} {
+		c.Flags().DurationVar(&flagHookEvery, "every", 7*24*time.Hour, "Check at most this often")
+	}
+	hookCmd.AddCommand(hookInstallCmd, hookUninstallCmd, hookCheckCmd)
+}
+
+// hookPath returns the path of the named hook in the repository holding
+// root.
+func hookPath(root, name string) (string, error) {
+	if strings.ContainsAny(name, `/\`) || name == "" {
+		return "", fmt.Errorf("invalid hook name %q", name)
+	}
+	repo, _, ok := detect.RepoRoot(root)
+	if !ok {
+		return "", fmt.Errorf("%s is not inside a git repository", root)
+	}
+	gitDir := filepath.Join(repo, ".git")
+	if info, err := os.Stat(gitDir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%s is a worktree or submodule; add the hook in the main repository", repo)
+	}
+	return filepath.Join(gitDir, "hooks", name), nil
+}
+
+// hookBlock is what install adds to the hook. The check runs from the
+// project directory, which may be below the repository root.
+func hookBlock(repo, root string, every time.Duration) string {
+	rel, err := filepath.Rel(repo, root)
+	if err != nil {
+		rel = "."
+	}
+	return fmt.Sprintf("%s\nif command -v launchpad >/dev/null 2>&1; then\n  launchpad hook check --every %s %q || true\nfi\n%s\n",
+		hookBegin, every, filepath.ToSlash(rel), hookEnd)
+}
+
+// withoutHookBlock removes the launchpad lines from a hook script.
+func withoutHookBlock(script string) string {
+	start := strings.Index(script, hookBegin)
+	if start < 0 {
+		return script
+	}
+	end := strings.Index(script[start:], hookEnd)
+	if end < 0 {
+		return script
+	}
+	end += start + len(hookEnd)
+	if end < len(script) && script[end] == '\n' {
+		end++
+	}
+	return script[:start] + script[end:]
+}
+
+func runHookInstall(cmd *cobra.Command, args []string) error {
+	root, err := projectDirArg(args)
+	if err != nil {
+		return err
+	}
+	path, err := hookPath(root, flagHookName)
+	if err != nil {
+		return err
+	}
+	repo := filepath.Dir(filepath.Dir(filepath.Dir(path)))
+
+	script := "#!/bin/sh\n"
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		script = withoutHookBlock(string(existing))
+		if !strings.HasSuffix(script, "\n") {
+			script += "\n"
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	script += hookBlock(repo, root, flagHookEvery)
+
+	if err := sandbox.Allow(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := sandbox.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating hooks directory: %w", err)
+	}
+	if err := sandbox.WriteFile(path, []byte(script), 0o755); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	fmt.Printf("%s Added a refresh reminder to %s (checks at most every %s)\n",
+		ui.Success.Render("✔"), ui.FileStyle.Render(ui.DisplayPath(path)), flagHookEvery)
+	return nil
+}
+
+func runHookUninstall(cmd *cobra.Command, args []string) error {
+	root, err := projectDirArg(args)
+	if err != nil {
+		return err
+	}
+	path, err := hookPath(root, flagHookName)
+	if err != nil {
+		return err
+	}
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) || err == nil && !strings.Contains(string(existing), hookBegin) {
+		fmt.Println(ui.DimStyle.Render("No launchpad reminder in " + ui.DisplayPath(path) + "."))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	if err := sandbox.Allow(filepath.Dir(path)); err != nil {
+		return err
+	}
+	script := withoutHookBlock(string(existing))
+	if strings.TrimSpace(script) == "#!/bin/sh" {
+		err = sandbox.Remove(path)
+	} else {
+		err = sandbox.WriteFile(path, []byte(script), 0o755)
+	}
+	if err != nil {
+		return fmt.Errorf("updating %s: %w", path, err)
+	}
+	fmt.Printf("%s Removed the refresh reminder from %s\n", ui.Success.Render("✔"), ui.FileStyle.Render(ui.DisplayPath(path)))
+	return nil
+}
+
+func runHookCheck(cmd *cobra.Command, args []string) error {
+	root, err := projectDirArg(args)
+	if err != nil {
+		return err
+	}
+	m, err := manifest.Load(root)
+	if err != nil || m == nil {
+		return err
+	}
+
+	stamp := filepath.Join(root, checkedFile)
+	if data, err := os.ReadFile(stamp); err == nil {
+		if last, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data))); err == nil && time.Since(last) < flagHookEvery {
+			return nil
+		}
+	}
+	if err := sandbox.WriteFile(stamp, []byte(time.Now().UTC().Format(time.RFC3339)+"\n"), 0o644); err != nil {
+		return err
+	}
+
+	reasons, err := refreshReasons(root, m)
+	if err != nil || len(reasons) == 0 {
+		return err
+	}
+	fmt.Printf("%s launchpad: the AI instructions may be out of date\n", ui.Warning.Render("!"))
+	for _, r := range reasons {
+		fmt.Printf("  %s %s\n", ui.DimStyle.Render("·"), r)
+	}
+	fmt.Printf("  %s launchpad init %s --force=paths\n", ui.DimStyle.Render("refresh with:"), ui.DisplayPath(root))
+	return nil
+}
+
+// refreshReasons lists what has changed since the files in m were
+// generated: the templates they drew on, the codebase's tools and versions,
+// or the codebase's stack.
+func refreshReasons(root string, m *manifest.Manifest) ([]string, error) {
+	var reasons []string
+
+	if len(m.Templates) > 0 {
+		current, err := ai.TemplateHashes(ai.Selection{ProfileID: m.ProfileID, AddonIDs: m.AddonIDs, AssetIDs: m.AssetIDs})
+		if err != nil {
+			return nil, err
+		}
+		var updated []string
+		for id, h := range current {
+			if old, ok := m.Templates[id]; ok && old != h {
+				updated = append(updated, id)
+			}
+		}
+		sort.Strings(updated)
+		if len(updated) > 0 {
+			reasons = append(reasons, "updated templates: "+strings.Join(updated, ", "))
+		}
+	}
+
+	drift, err := validate.Drift(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(drift) > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d instruction claim(s) no longer match the code (launchpad validate --drift)", len(drift)))
+	}
+
+	if id, marker := detect.Stack(root); id != "" && m.ProfileID != "" && id != m.ProfileID {
+		reasons = append(reasons, fmt.Sprintf("%s now points to %s; the instructions are for %s", marker, id, m.ProfileID))
+	}
+	return reasons, nil
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/manifest"
+)
+
+func TestHookBlock(t *testing.T) {
+	existing := "#!/bin/sh\nnpm run lint\n"
+	installed := existing + hookBlock("/repo", "/repo/services/api", 24*time.Hour)
+	if !strings.Contains(installed, `launchpad hook check --every 24h0m0s "services/api"`) {
+		t.Errorf("hook block doesn't check the project directory:\n%s", installed)
+	}
+	if got := withoutHookBlock(installed); got != existing {
+		t.Errorf("withoutHookBlock = %q, want the original hook %q", got, existing)
+	}
+	if got := withoutHookBlock(existing); got != existing {
+		t.Errorf("withoutHookBlock changed a hook without the block: %q", got)
+	}
+}
+
+func TestRefreshReasons(t *testing.T) {
+	root := t.TempDir()
+	sel := ai.Selection{ProfileID: "go-service"}
+	hashes, err := ai.TemplateHashes(sel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := manifest.New("demo")
+	m.ProfileID = sel.ProfileID
+	m.Templates = hashes
+
+	reasons, err := refreshReasons(root, m)
+	if err != nil || len(reasons) != 0 {
+		t.Fatalf("refreshReasons = (%v, %v), want none for current templates", reasons, err)
+	}
+
+	m.Templates = map[string]string{"profile.go-service": "stale"}
+	reasons, err = refreshReasons(root, m)
+	if err != nil || len(reasons) != 1 || !strings.Contains(reasons[0], "profile.go-service") {
+		t.Errorf("refreshReasons = (%v, %v), want the updated profile template", reasons, err)
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/ecoker/launchpad/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var flagImportAs string
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Add an existing instruction file to your local catalog",
+	Long: `Copy an instruction file from another repository into the local catalog,
+so guidance that has proven itself in one project can be selected for every
+future one. The label and summary come from the file's frontmatter name and
+description, or its first heading and paragraph; the category is the middle
+part of the ID.
+
+Imported assets live in the launchpad folder of your user config directory.
+Importing the same ID again replaces it.`,
+	Example:      "  launchpad import ../billing/.github/instructions/payments.instructions.md --as asset.org.payments",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&flagImportAs, "as", "", "Catalog ID for the asset, e.g. asset.org.payments")
+	_ = importCmd.MarkFlagRequired("as")
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	content, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	// Check it as the scoped instruction file it will be generated into.
+	if errs := validate.Errors(validate.File(".github/instructions/"+filepath.Base(args[0]), string(content))); len(errs) > 0 {
+		for _, d := range errs {
+			fmt.Println(ui.Warning.Render("✗ ") + d.Message)
+		}
+		return fmt.Errorf("%s is not a valid instruction file", args[0])
+	}
+	asset, replaced, err := ai.ImportAsset(flagImportAs, content)
+	if err != nil {
+		return err
+	}
+	verb := "Imported"
+	if replaced {
+		verb = "Replaced"
+	}
+	fmt.Printf("%s %s %s\n", ui.Success.Render("✔"), verb, ui.Accent.Render(asset.ID))
+	fmt.Printf("  %s %s\n", ui.DimStyle.Render("label:   "), asset.Label)
+	fmt.Printf("  %s %s\n", ui.DimStyle.Render("category:"), asset.Category)
+	fmt.Printf("  %s %s\n", ui.DimStyle.Render("summary: "), asset.Summary)
+	fmt.Println(ui.DimStyle.Render("Select it in launchpad browse, or name it in an init conversation."))
+	return nil
+}
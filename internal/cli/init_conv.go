@@ -6,17 +6,36 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/charmbracelet/huh"
 	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/audit"
+	"github.com/ecoker/launchpad/internal/config"
+	"github.com/ecoker/launchpad/internal/detect"
+	"github.com/ecoker/launchpad/internal/manifest"
+	"github.com/ecoker/launchpad/internal/postprocess"
+	"github.com/ecoker/launchpad/internal/sandbox"
 	"github.com/ecoker/launchpad/internal/scaffold"
+	"github.com/ecoker/launchpad/internal/session"
+	"github.com/ecoker/launchpad/internal/store"
 	"github.com/ecoker/launchpad/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	flagForce bool
+	flagForce     string
+	flagForceAll  bool
+	flagForceInst bool
+	flagAgents    string
+	flagTargets   string
+	flagDebug     bool
+	flagSelection string
+	flagMinimal   bool
+	flagLanguage  string
 )
 
 var initCmd = &cobra.Command{
@@ -31,12 +50,59 @@ Set OPENAI_API_KEY in your environment before running.`,
 }
 
 func init() {
-	initCmd.Flags().BoolVarP(&flagForce, "force", "f", false, "Overwrite files in non-empty target")
+	initCmd.Flags().StringVarP(&flagForce, "force", "f", "", "Overwrite existing files without asking: all, paths for files launchpad generated (asking about the rest), or instructions (skipping the rest); replaced files are backed up")
+	initCmd.Flags().Lookup("force").NoOptDefVal = forceAll
+	initCmd.Flags().BoolVar(&flagForceAll, "force-all", false, "Overwrite every existing file without asking (same as --force=all)")
+	initCmd.Flags().BoolVar(&flagForceInst, "force-instructions", false, "Overwrite files the manifest says launchpad generated, even if edited; never touch any other file")
+	initCmd.MarkFlagsMutuallyExclusive("force", "force-all", "force-instructions")
+	initCmd.Flags().StringVar(&flagTargets, "targets", "copilot", "Comma-separated AI tools to write instructions for (copilot, cursor, claude, zed, gemini)")
+	initCmd.Flags().BoolVar(&flagMinimal, "minimal", false, "Write a single consolidated AGENTS.md and nothing else")
+	initCmd.Flags().StringVar(&flagLanguage, "language", "en", "Language to write the instructions' prose in (en, de, es, fr, it, ja, ko, nl, pt, ru, zh)")
+	initCmd.Flags().StringVar(&flagSelection, "selection", "", "Generate from a saved selection (see launchpad browse) instead of picking a stack in conversation")
+	initCmd.Flags().BoolVar(&flagDebug, "debug", false, "Record the conversation and results under .launchpad/debug for launchpad replay")
+	initCmd.Flags().StringVar(&flagAgents, "agents", "", "Comma-separated AI agents the team uses (copilot, claude-code, aider, codex, ci-bot)")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Print(ui.Banner)
 
+	switch {
+	case flagForceAll:
+		flagForce = forceAll
+	case flagForceInst:
+		flagForce = forceInstructions
+	}
+	if flagForce != "" && flagForce != forceAll && flagForce != forcePaths && flagForce != forceInstructions {
+		return fmt.Errorf("--force must be %q, %q, or %q, got %q", forceAll, forcePaths, forceInstructions, flagForce)
+	}
+	agents, err := ai.ParseAgents(flagAgents)
+	if err != nil {
+		return err
+	}
+	var preset *ai.Selection
+	if flagSelection != "" {
+		if preset, err = loadSelection(flagSelection); err != nil {
+			return err
+		}
+	}
+	targets, err := ai.ParseTargets(flagTargets)
+	if err != nil {
+		return err
+	}
+	language, err := ai.ParseLanguage(flagLanguage)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("--targets needs at least one target")
+	}
+	if flagMinimal {
+		if cmd.Flags().Changed("targets") {
+			return fmt.Errorf("--minimal can't be combined with other targets")
+		}
+		targets = []string{"agents"}
+	}
+
 	// 1. Check for API key (env var, then .env file, then prompt)
 	apiKey := os.Getenv("OPENAI_API_KEY")
 	if apiKey == "" {
@@ -88,28 +154,53 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("resolving path: %w", err)
 	}
 	projectName := filepath.Base(outputPath)
+	if err := sandbox.Confine(outputPath); err != nil {
+		return err
+	}
+	if err := pullState(outputPath); err != nil {
+		return err
+	}
 
-	// 3. Safety check for non-empty directory
-	if !flagForce {
-		entries, _ := os.ReadDir(outputPath)
-		if len(entries) > 0 {
-			force := false
-			err := huh.NewForm(
-				huh.NewGroup(
-					huh.NewConfirm().
-						Title("Directory isn't empty. Overwrite existing files?").
-						Affirmative("Yes, overwrite").
-						Negative("No, abort").
-						Value(&force),
-				),
-			).Run()
-			if err != nil {
-				return err
-			}
-			if !force {
-				return fmt.Errorf("aborted — directory is not empty")
-			}
+	// 3. Non-empty directories are fine — conflicting files are confirmed
+	// one by one after generation, against what's on disk at that point, so
+	// anything that changes during the conversation is still caught.
+	if entries, _ := os.ReadDir(outputPath); len(entries) > 0 {
+		outputs, err := expectedOutputs(outputPath, targets)
+		if err != nil {
+			return err
 		}
+		if err := printExisting(outputPath, entries, outputs); err != nil {
+			return err
+		}
+	}
+
+	// Existing monorepos get one scoped instruction file per package, each
+	// following the stack detected on disk.
+	packages, err := detect.Workspace(outputPath)
+	if err != nil {
+		return fmt.Errorf("scanning workspace: %w", err)
+	}
+	var scopes []ai.PackageScope
+	if len(packages) > 0 {
+		fmt.Println(ui.DimStyle.Render(fmt.Sprintf("Found %d existing package(s) — each gets its own scoped instructions:", len(packages))))
+		for _, p := range packages {
+			fmt.Printf("  %s %s\n", ui.FileStyle.Render(p.Path), ui.DimStyle.Render("("+p.ProfileID+", from "+p.Marker+")"))
+			scopes = append(scopes, ai.PackageScope{Path: p.Path, ProfileID: p.ProfileID})
+		}
+	}
+
+	// Existing code gets a scorecard; its gaps are filled from the catalog.
+	var health []detect.Check
+	if id, _ := detect.Stack(outputPath); id != "" || len(packages) > 0 {
+		health = detect.Health(outputPath)
+		printHealth(health)
+	}
+
+	// Copilot resolves globs from the host repository's root, so a nested
+	// target gets root-relative globs and has to be registered there.
+	if root, rel, ok := detect.RepoRoot(outputPath); ok && rel != "." {
+		fmt.Println(ui.DimStyle.Render(fmt.Sprintf("Inside the repository at %s — applyTo globs will be relative to its root.", ui.DisplayPath(root))))
+		fmt.Println(ui.DimStyle.Render(fmt.Sprintf("Add %q to chat.instructionsFilesLocations in the host repo's VS Code settings.", rel+"/.github/instructions")))
 	}
 
 	// 4. Conversation — natural language with loading spinners
@@ -118,13 +209,44 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Println(ui.DimStyle.Render("Describe your project and I'll help you pick the right stack and standards."))
 	fmt.Println()
 
-	// Build LLM provider — model is configurable via LAUNCHPAD_MODEL env var.
-	var providerOpts []ai.OpenAIOption
-	if model := os.Getenv("LAUNCHPAD_MODEL"); model != "" {
-		providerOpts = append(providerOpts, ai.WithModel(model))
+	// Build LLM provider — model and server are configurable via
+	// LAUNCHPAD_MODEL and LAUNCHPAD_BASE_URL.
+	provider := ai.NewOpenAIProvider(apiKey, providerOptions(os.Getenv("LAUNCHPAD_MODEL"))...)
+	var warnings []string
+	engineOpts := []ai.EngineOption{ai.WithWarningHandler(func(msg string) {
+		warnings = append(warnings, msg)
+	})}
+	engineOpts = append(engineOpts, ai.WithTargets(targets...), ai.WithLanguage(language))
+	procs, err := postProcessors(outputPath, projectName)
+	if err != nil {
+		return err
+	}
+	decisions, err := decisionMap(outputPath)
+	if err != nil {
+		return err
+	}
+	tone, err := conversationTone(outputPath)
+	if err != nil {
+		return err
+	}
+	roots, err := allowedRoots(outputPath)
+	if err != nil {
+		return err
+	}
+	engineOpts = append(engineOpts, ai.WithPostProcessors(procs...), ai.WithDecisionMap(decisions), ai.WithTone(tone),
+		ai.WithAllowedRoots(roots...))
+	sender, err := audited(provider, outputPath)
+	if err != nil {
+		return err
+	}
+	engine := ai.NewEngine(sender, engineOpts...)
+
+	// With --debug every turn is kept so the run can be replayed later.
+	var rec *session.Session
+	if flagDebug {
+		rec = &session.Session{At: time.Now().UTC(), Model: provider.Model(), ProjectName: projectName,
+			Agents: agents, Targets: targets, Packages: scopes}
 	}
-	provider := ai.NewOpenAIProvider(apiKey, providerOpts...)
-	engine := ai.NewEngine(provider)
 
 	ctx := context.Background()
 	reader := bufio.NewReader(os.Stdin)
@@ -141,23 +263,35 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 	spin := ui.NewSpinner("Thinking...")
-	reply, err := engine.Chat(ctx, fmt.Sprintf(
-		"Project name: %q. What I'm building: %s", projectName, firstInput,
-	))
+	opening := fmt.Sprintf("Project name: %q. What I'm building: %s", projectName, firstInput)
+	if len(scopes) > 0 {
+		opening += "\n\nThis is an existing monorepo with these packages: " + describeScopes(scopes) +
+			". Pick the root profile that fits the repository as a whole."
+	}
+	if preset != nil {
+		opening += "\n\nThe stack is already chosen (" + preset.ProfileID + "); no questions needed."
+	}
+	reply, err := engine.Chat(ctx, opening)
 	spin.Stop()
 	if err != nil {
 		return fmt.Errorf("conversation error: %w", err)
 	}
-	printLaunchpadReply(reply)
+	rec.AddTurn(opening, reply)
+	if preset == nil {
+		printLaunchpadReply(reply, projectName)
+	}
 
-	for !ai.IsReady(reply) {
+	// A preset selection only needs the description as generation context.
+	// /generate (or /done, or an empty line) ends the conversation whenever
+	// the model is slow to call it.
+	for preset == nil && !engine.Ready(ctx, reply) {
 		fmt.Print(ui.Accent.Render("You: "))
 		userInput, readErr := reader.ReadString('\n')
 		if readErr != nil {
 			return fmt.Errorf("reading input: %w", readErr)
 		}
 		userInput = strings.TrimSpace(userInput)
-		if userInput == "" || strings.EqualFold(userInput, "/done") {
+		if userInput == "" || strings.EqualFold(userInput, "/done") || strings.EqualFold(userInput, "/generate") {
 			break
 		}
 
@@ -168,17 +302,44 @@ func runInit(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("conversation error: %w", err)
 		}
-		printLaunchpadReply(reply)
+		rec.AddTurn(userInput, reply)
+		printLaunchpadReply(reply, projectName)
 	}
 
 	// 5. Silent extraction — user never sees this
-	spin = ui.NewSpinner("Resolving selection...")
-	sel, err := engine.ExtractDecision(ctx)
-	spin.Stop()
-	if err != nil {
-		return fmt.Errorf("extracting decision: %w", err)
+	sel := preset
+	if sel == nil {
+		spin = ui.NewSpinner("Resolving selection...")
+		sel, err = engine.ExtractDecision(ctx)
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("extracting decision: %w", err)
+		}
+		if !engine.Capabilities(ctx).StructuredOutput {
+			fmt.Println(ui.DimStyle.Render(provider.Model() + " has no structured outputs — the selection was read from plain text."))
+		}
 	}
 
+	// Tags and constraints from earlier runs come first, so regenerating
+	// keeps the intent and the limits the project started with.
+	if prev, err := manifest.Load(outputPath); err == nil && prev != nil {
+		sel.Tags = ai.MergeTags(prev.Tags, sel.Tags)
+		sel.Constraints = ai.MergeConstraints(prev.Constraints, sel.Constraints)
+	}
+
+	// A preset selection is taken as given.
+	if preset == nil {
+		if added := applyHealth(sel, health); len(added) > 0 {
+			fmt.Println(ui.DimStyle.Render("Added for project health: " + strings.Join(added, ", ")))
+		}
+	}
+
+	// Agents named on the command line win over what the conversation implied.
+	if len(agents) > 0 {
+		sel.Agents = agents
+	}
+	sel.Packages = scopes
+
 	fmt.Println()
 	printSelectionSummary(sel)
 
@@ -188,6 +349,13 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	files, err := engine.GenerateFiles(ctx, projectName, sel)
 	spin.Stop()
+	for _, w := range warnings {
+		fmt.Println(ui.Warning.Render("! " + w))
+	}
+	recordRun(provider, sel, len(files), err)
+	if rec != nil {
+		saveSession(rec, outputPath, sel, files, err)
+	}
 	if err != nil {
 		return fmt.Errorf("generation error: %w", err)
 	}
@@ -197,20 +365,17 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// 6. Write files
-	if err := os.MkdirAll(outputPath, 0o755); err != nil {
+	if err := sandbox.MkdirAll(outputPath, 0o755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
-	var created []string
-	for _, f := range files {
-		fullPath := filepath.Join(outputPath, f.Path)
-		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
-			return fmt.Errorf("creating directory for %s: %w", f.Path, err)
-		}
-		if err := os.WriteFile(fullPath, []byte(f.Content+"\n"), 0o644); err != nil {
-			return fmt.Errorf("writing %s: %w", f.Path, err)
-		}
-		created = append(created, fullPath)
+	run := runSettings{model: provider.Model(), targets: targets, language: language, tone: tone}
+	created, err := writeGenerated(outputPath, projectName, run, sel, files, false)
+	if err != nil {
+		return err
+	}
+	if err := pushState(outputPath); err != nil {
+		return err
 	}
 
 	// 7. Print results
@@ -228,13 +393,14 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  %s Review the generated files — tweak anything that doesn't feel right\n", ui.DimStyle.Render("2."))
 
 	// Show scaffold command if available for the selected profile
+	hint := startHint(targets, files)
 	if profile := scaffold.FindProfile(sel.ProfileID); profile != nil && profile.ScaffoldCmd != "" {
 		scaffoldDisplay := strings.ReplaceAll(profile.ScaffoldCmd, "{{name}}", projectName)
 		scaffoldDisplay = strings.ReplaceAll(scaffoldDisplay, "{{module}}", projectName)
 		fmt.Printf("  %s Scaffold your project: %s\n", ui.DimStyle.Render("3."), ui.Accent.Render(scaffoldDisplay))
-		fmt.Printf("  %s Open Copilot Chat and type %s to start building\n", ui.DimStyle.Render("4."), ui.Accent.Render("/start"))
+		fmt.Printf("  %s %s to start building\n", ui.DimStyle.Render("4."), hint)
 	} else {
-		fmt.Printf("  %s Open Copilot Chat and type %s to bootstrap the project\n", ui.DimStyle.Render("3."), ui.Accent.Render("/start"))
+		fmt.Printf("  %s %s to bootstrap the project\n", ui.DimStyle.Render("3."), hint)
 	}
 
 	fmt.Println()
@@ -244,6 +410,258 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// startCommands maps the kickoff prompt each target writes to the chat it
+// runs in as /start.
+var startCommands = []struct{ path, chat string }{
+	{".github/prompts/start.prompt.md", "Copilot Chat"},
+	{".cursor/commands/start.md", "Cursor's chat"},
+	{".claude/commands/start.md", "Claude Code"},
+}
+
+// startHint says how to run the kickoff prompt, going by the files the run
+// generated. The AGENTS.md-only layout folds it into a Getting started
+// section; single-file targets such as Zed and Gemini drop it, so those
+// just get pointed at their instructions.
+func startHint(targets []string, files []ai.FileOutput) string {
+	written := make(map[string]bool, len(files))
+	for _, f := range files {
+		written[f.Path] = true
+	}
+	var chats []string
+	for _, c := range startCommands {
+		if written[c.path] {
+			chats = append(chats, c.chat)
+		}
+	}
+	switch {
+	case len(chats) > 0:
+		return fmt.Sprintf("Open %s and type %s", joinOr(chats), ui.Accent.Render("/start"))
+	case slices.Contains(targets, "agents"):
+		return "Ask your agent to follow the Getting started section of " + ui.FileStyle.Render("AGENTS.md")
+	}
+	return "Ask your AI assistant to follow the generated instructions"
+}
+
+// joinOr lists items as "a", "a or b", or "a, b, or c".
+func joinOr(items []string) string {
+	switch len(items) {
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " or " + items[1]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", or " + items[len(items)-1]
+}
+
+// loadSelection reads a selection saved by launchpad browse or written by
+// hand. The user chose it outright, so it carries full confidence.
+func loadSelection(path string) (*ai.Selection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading selection: %w", err)
+	}
+	sel, err := ai.ParseSelection(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if issues := ai.ValidateSelectionCompatibility(*sel); len(issues) > 0 {
+		return nil, fmt.Errorf("%s: %s", path, strings.Join(issues, "; "))
+	}
+	sel.Confidence = 1
+	return sel, nil
+}
+
+// postProcessors builds the built-in processors plus any external commands
+// from the user and project config. When the project was generated before,
+// new instruction files fold into the existing ones they overlap. A
+// configured layout moves the files last.
+func postProcessors(projectDir, projectName string) ([]ai.PostProcessor, error) {
+	cfg, err := config.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	vars := map[string]string{"name": projectName, "module": projectName, "project": projectName}
+	for k, v := range cfg.Placeholders {
+		vars[k] = v
+	}
+	procs, err := postprocess.Builtins(vars, cfg.DisableBuiltins, version)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := manifest.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil && len(prev.Files) > 0 {
+		// The manifest records where files were written; folding compares
+		// them with paths as generated.
+		layout := postprocess.Layout{Paths: cfg.Layout}
+		existing := make([]string, 0, len(prev.Files))
+		for p := range prev.Files {
+			existing = append(existing, layout.Original(p))
+		}
+		// Folding runs first, so the other processors see the combined file.
+		procs = append([]ai.PostProcessor{postprocess.Inherit{Existing: existing}}, procs...)
+	}
+	if _, rel, ok := detect.RepoRoot(projectDir); ok && rel != "." {
+		procs = append(procs, postprocess.Rebase{Prefix: rel})
+	}
+	for _, c := range cfg.PostProcessors {
+		procs = append(procs, postprocess.Command{Label: c.Name, Args: c.Command, Dir: projectDir})
+	}
+	if len(cfg.Layout) > 0 {
+		procs = append(procs, postprocess.Layout{Paths: cfg.Layout})
+	}
+	return procs, nil
+}
+
+// expectedOutputs is where the targets' files will land, after the
+// configured layout moves them.
+func expectedOutputs(projectDir string, targets []string) ([]string, error) {
+	cfg, err := config.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	layout := postprocess.Layout{Paths: cfg.Layout}
+	outputs := ai.ExpectedOutputs(targets)
+	for i, p := range outputs {
+		outputs[i] = layout.Path(p)
+	}
+	return outputs, nil
+}
+
+// decisionMap applies the configured org overrides to the built-in
+// decision map.
+func decisionMap(projectDir string) (*ai.DecisionMap, error) {
+	cfg, err := config.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	m := ai.DefaultDecisionMap()
+	for _, r := range cfg.Decisions.Rules {
+		if err := m.Override(r.UseCase, r.Profiles, r.Keywords); err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+	}
+	for id, w := range cfg.Decisions.Weights {
+		if err := m.Weigh(id, w); err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// allowedRoots reads the extra locations generated files may be written to.
+func allowedRoots(projectDir string) ([]string, error) {
+	cfg, err := config.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.AllowedRoots, nil
+}
+
+// conversationTone reads the advisor's configured style.
+func conversationTone(projectDir string) (ai.Tone, error) {
+	cfg, err := config.Load(projectDir)
+	if err != nil {
+		return ai.Tone{}, err
+	}
+	c := cfg.Conversation
+	return ai.Tone{Verbosity: c.Verbosity, Formality: c.Tone, Emoji: c.Emoji}, nil
+}
+
+// providerOptions configures the OpenAI provider for model, or the default
+// model when empty, and for the server in LAUNCHPAD_BASE_URL when set.
+func providerOptions(model string) []ai.OpenAIOption {
+	var opts []ai.OpenAIOption
+	if model != "" {
+		opts = append(opts, ai.WithModel(model))
+	}
+	if base := os.Getenv("LAUNCHPAD_BASE_URL"); base != "" {
+		opts = append(opts, ai.WithBaseURL(base))
+	}
+	return opts
+}
+
+// audited wraps p so every exchange is appended to the audit log, when one
+// is configured in projectDir's config or LAUNCHPAD_AUDIT_LOG. Otherwise p
+// is returned unchanged.
+func audited(p *ai.OpenAIProvider, projectDir string) (ai.Provider, error) {
+	path := os.Getenv(audit.PathEnv)
+	if path == "" {
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.AuditLog == "" {
+			return p, nil
+		}
+		path = cfg.AuditLog
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectDir, path)
+		}
+	}
+	log, err := openAuditLog(path)
+	if err != nil {
+		return nil, err
+	}
+	return audit.Wrap(p, log, p.Model(), func(err error) {
+		fmt.Fprintln(os.Stderr, ui.Warning.Render("! audit log: "+err.Error()))
+	}), nil
+}
+
+// auditLogs holds the logs opened so far, so providers created for
+// concurrent eval runs extend one hash chain instead of forking it.
+var (
+	auditMu   sync.Mutex
+	auditLogs = map[string]*audit.Log{}
+)
+
+func openAuditLog(path string) (*audit.Log, error) {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	if log, ok := auditLogs[path]; ok {
+		return log, nil
+	}
+	if err := sandbox.Allow(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	log, err := audit.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	auditLogs[path] = log
+	return log, nil
+}
+
+// saveSession writes the debug recording for this run. A failed save is
+// reported but never fails init.
+func saveSession(rec *session.Session, outputPath string, sel *ai.Selection, files []ai.FileOutput, genErr error) {
+	rec.Selection = sel
+	rec.Files = files
+	if genErr != nil {
+		rec.Error = genErr.Error()
+	}
+	dir := session.NewDir(outputPath, rec.At)
+	if err := rec.Save(dir); err != nil {
+		fmt.Println(ui.Warning.Render("! could not save debug session: " + err.Error()))
+		return
+	}
+	fmt.Println(ui.DimStyle.Render("Debug session saved to " + ui.DisplayPath(dir)))
+
+	// Hosted and CI runs lose the local copy, so it goes to the state store too.
+	st, loc, err := stateStore(outputPath)
+	if err != nil || st == nil {
+		return
+	}
+	rel := filepath.ToSlash(filepath.Join(session.Dir, filepath.Base(dir)))
+	if err := rec.SaveTo(context.Background(), store.Sub(st, rel)); err != nil {
+		fmt.Println(ui.Warning.Render("! could not save debug session to " + loc + ": " + err.Error()))
+		return
+	}
+	fmt.Println(ui.DimStyle.Render("Debug session saved to " + strings.TrimSuffix(loc, "/") + "/" + rel))
+}
+
 func printSelectionSummary(sel *ai.Selection) {
 	fmt.Printf("%s %s\n", ui.DimStyle.Render("Profile:"), ui.ProfileID.Render(sel.ProfileID))
 	if len(sel.AddonIDs) > 0 {
@@ -252,20 +670,53 @@ func printSelectionSummary(sel *ai.Selection) {
 	if len(sel.AssetIDs) > 0 {
 		fmt.Printf("%s %s\n", ui.DimStyle.Render("Assets:  "), strings.Join(sel.AssetIDs, ", "))
 	}
+	if len(sel.PaletteRoles) > 0 {
+		roles := make([]string, 0, len(sel.PaletteRoles))
+		for _, r := range sel.PaletteRoles {
+			roles = append(roles, r.Role+" "+strings.TrimPrefix(r.AssetID, "asset.palette."))
+		}
+		fmt.Printf("%s %s\n", ui.DimStyle.Render("Palettes:"), strings.Join(roles, ", "))
+	}
+	if len(sel.Agents) > 0 {
+		fmt.Printf("%s %s\n", ui.DimStyle.Render("Agents:  "), strings.Join(sel.Agents, ", "))
+	}
+	if len(sel.Packages) > 0 {
+		fmt.Printf("%s %s\n", ui.DimStyle.Render("Packages:"), describeScopes(sel.Packages))
+	}
+	if len(sel.Tags) > 0 {
+		fmt.Printf("%s %s\n", ui.DimStyle.Render("Tags:    "), strings.Join(sel.Tags, ", "))
+	}
+	if len(sel.Constraints) > 0 {
+		fmt.Printf("%s %s\n", ui.DimStyle.Render("Must:    "), strings.Join(sel.Constraints, "; "))
+	}
 	if sel.Rationale != "" {
 		fmt.Printf("%s %s\n", ui.DimStyle.Render("Why:     "), sel.Rationale)
 	}
 	fmt.Println()
 }
 
-// printLaunchpadReply displays the AI response, stripping the READY_TO_GENERATE token.
-func printLaunchpadReply(reply string) {
+// printLaunchpadReply displays the AI response, stripping the READY_TO_GENERATE
+// token, followed by catalog facts for any stack options it presents.
+func printLaunchpadReply(reply, projectName string) {
 	display := strings.ReplaceAll(reply, "READY_TO_GENERATE", "")
 	display = strings.ReplaceAll(display, "READY TO GENERATE", "")
 	display = strings.TrimSpace(display)
 	fmt.Print(ui.DimStyle.Render("Launchpad: "))
 	fmt.Println(display)
 	fmt.Println()
+
+	// Options replies get the catalog's own scaffold commands and summaries
+	// rather than whatever the model remembers.
+	if facts := ai.OptionFacts(reply, projectName); len(facts) > 0 {
+		fmt.Println(ui.DimStyle.Render("From the catalog:"))
+		for _, f := range facts {
+			fmt.Printf("  %s  %s\n", ui.ProfileID.Render(f.ID), ui.ProfileDesc.Render(f.Summary))
+			if f.Scaffold != "" {
+				fmt.Printf("  %s  %s\n", ui.DimStyle.Render("  scaffold:"), ui.Accent.Render(f.Scaffold))
+			}
+		}
+		fmt.Println()
+	}
 }
 
 // loadKeyFromDotEnv reads OPENAI_API_KEY or KEY from a .env file in the current directory.
@@ -305,3 +756,12 @@ func loadKeyFromDotEnv() string {
 	}
 	return ""
 }
+
+// describeScopes lists detected packages as "path (profile)".
+func describeScopes(scopes []ai.PackageScope) string {
+	parts := make([]string, len(scopes))
+	for i, p := range scopes {
+		parts[i] = fmt.Sprintf("%s (%s)", p.Path, p.ProfileID)
+	}
+	return strings.Join(parts, ", ")
+}
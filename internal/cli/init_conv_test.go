@@ -3,7 +3,10 @@ package cli
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/ecoker/launchpad/internal/ai"
 )
 
 func TestLoadKeyFromDotEnv(t *testing.T) {
@@ -95,3 +98,42 @@ func TestLoadKeyFromDotEnv_NoFile(t *testing.T) {
 		t.Errorf("expected empty string when no .env exists, got %q", got)
 	}
 }
+
+func TestStartHint(t *testing.T) {
+	files := func(paths ...string) []ai.FileOutput {
+		var out []ai.FileOutput
+		for _, p := range paths {
+			out = append(out, ai.FileOutput{Path: p})
+		}
+		return out
+	}
+	tests := []struct {
+		name    string
+		targets []string
+		files   []ai.FileOutput
+		want    []string
+		notWant string
+	}{
+		{"copilot", []string{"copilot"}, files("AGENTS.md", ".github/prompts/start.prompt.md"),
+			[]string{"Copilot Chat", "/start"}, "Cursor"},
+		{"cursor and claude", []string{"cursor", "claude"}, files("AGENTS.md", ".cursor/commands/start.md", "CLAUDE.md", ".claude/commands/start.md"),
+			[]string{"Cursor's chat or Claude Code", "/start"}, "Copilot"},
+		{"minimal", []string{"agents"}, files("AGENTS.md"),
+			[]string{"Getting started", "AGENTS.md"}, "/start"},
+		{"zed", []string{"zed"}, files("AGENTS.md", ".rules"),
+			[]string{"generated instructions"}, "/start"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := startHint(tt.targets, tt.files)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("startHint() = %q, want it to mention %q", got, w)
+				}
+			}
+			if strings.Contains(got, tt.notWant) {
+				t.Errorf("startHint() = %q, shouldn't mention %q", got, tt.notWant)
+			}
+		})
+	}
+}
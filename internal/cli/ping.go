@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagPingModels string
+	flagPingProbes int
+)
+
+var pingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Measure provider latency and availability",
+	Long: `Send a few tiny requests to the configured provider and report how many
+succeeded and the p50 and p95 round-trip times, so you can compare models
+or servers before a long session.
+
+Probes go to LAUNCHPAD_MODEL (or the built-in default) at LAUNCHPAD_BASE_URL.
+Pass --model with a comma-separated list to compare several models.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPing,
+}
+
+func init() {
+	pingCmd.Flags().StringVar(&flagPingModels, "model", "", "Comma-separated models to probe (default: LAUNCHPAD_MODEL, then the built-in default)")
+	pingCmd.Flags().IntVar(&flagPingProbes, "probes", 5, "Requests to send per model")
+}
+
+func runPing(cmd *cobra.Command, args []string) error {
+	if flagPingProbes < 1 {
+		return fmt.Errorf("--probes must be at least 1")
+	}
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		apiKey = loadKeyFromDotEnv()
+	}
+	if apiKey == "" {
+		return fmt.Errorf("ping needs OPENAI_API_KEY")
+	}
+	models := strings.Split(flagPingModels, ",")
+	if flagPingModels == "" {
+		models = []string{os.Getenv("LAUNCHPAD_MODEL")}
+	}
+
+	ctx := context.Background()
+	for _, model := range models {
+		provider := ai.NewOpenAIProvider(apiKey, providerOptions(strings.TrimSpace(model))...)
+		spin := ui.NewSpinner(fmt.Sprintf("Probing %s...", provider.Model()))
+		res := ai.Ping(ctx, provider, flagPingProbes)
+		spin.Stop()
+		printPing(provider.Model(), res)
+	}
+	return nil
+}
+
+// printPing shows one model's availability and latency, and the first
+// error when probes failed.
+func printPing(model string, res ai.PingResult) {
+	ok := len(res.Latencies)
+	mark := ui.Success.Render("✔")
+	if ok < res.Probes {
+		mark = ui.Warning.Render("!")
+	}
+	if ok == 0 {
+		fmt.Printf("%s %-24s %s\n", ui.Warning.Render("✗"), ui.Accent.Render(model),
+			ui.DimStyle.Render(fmt.Sprintf("0/%d ok", res.Probes)))
+	} else {
+		fmt.Printf("%s %-24s %d/%d ok  p50 %s  p95 %s\n", mark, ui.Accent.Render(model), ok, res.Probes,
+			res.Percentile(50).Round(time.Millisecond), res.Percentile(95).Round(time.Millisecond))
+	}
+	if len(res.Errors) > 0 {
+		fmt.Println("  " + ui.DimStyle.Render(res.Errors[0].Error()))
+	}
+}
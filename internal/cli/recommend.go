@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/scaffold"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagRecommendOffline bool
+	flagRecommendJSON    bool
+)
+
+var recommendCmd = &cobra.Command{
+	Use:   "recommend <description>",
+	Short: "Rank stacks for a project description without generating anything",
+	Long: `Recommend profiles for a one-line project description with a single
+classification call, and print them ranked with a rationale for each.
+
+With --offline, or when no OPENAI_API_KEY is available, the description is
+matched against the decision map's keywords instead. That needs no network
+but only knows the words it was given.
+
+Decision overrides and weights from launchpad's config apply either way.`,
+	Example:      `  launchpad recommend "a collaborative whiteboard"`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runRecommend,
+}
+
+func init() {
+	recommendCmd.Flags().BoolVar(&flagRecommendOffline, "offline", false, "Match keywords locally instead of calling the model")
+	recommendCmd.Flags().BoolVar(&flagRecommendJSON, "json", false, "Print recommendations as JSON")
+}
+
+func runRecommend(cmd *cobra.Command, args []string) error {
+	description := strings.Join(args, " ")
+
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		apiKey = loadKeyFromDotEnv()
+	}
+	offline := flagRecommendOffline || apiKey == ""
+	decisions, err := decisionMap(".")
+	if err != nil {
+		return err
+	}
+
+	var recs []ai.Recommendation
+	if offline {
+		if !flagRecommendOffline && !flagRecommendJSON {
+			fmt.Println(ui.DimStyle.Render("No OPENAI_API_KEY — matching keywords offline."))
+		}
+		recs = decisions.RecommendOffline(description)
+	} else {
+		provider, err := audited(ai.NewOpenAIProvider(apiKey, providerOptions(os.Getenv("LAUNCHPAD_MODEL"))...), ".")
+		if err != nil {
+			return err
+		}
+		engine := ai.NewEngine(provider, ai.WithDecisionMap(decisions))
+		spin := ui.NewSpinner("Classifying...")
+		recs, err = engine.Recommend(context.Background(), description)
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("recommending: %w", err)
+		}
+	}
+
+	if flagRecommendJSON {
+		if recs == nil {
+			recs = []ai.Recommendation{}
+		}
+		out, err := json.MarshalIndent(recs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	if len(recs) == 0 {
+		fmt.Println(ui.Warning.Render("No keywords matched — describe the project in more detail, or drop --offline."))
+		return nil
+	}
+	fmt.Println()
+	for i, r := range recs {
+		title := r.ProfileID
+		if p := scaffold.FindProfile(r.ProfileID); p != nil {
+			title = p.Title
+		}
+		fmt.Printf("%d. %s %s  %s\n", i+1, ui.ProfileID.Render(r.ProfileID),
+			ui.ProfileDesc.Render(title), ui.DimStyle.Render(fmt.Sprintf("%.0f%%", r.Score*100)))
+		fmt.Printf("   %s\n", r.Rationale)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/config"
+	"github.com/ecoker/launchpad/internal/manifest"
+	"github.com/ecoker/launchpad/internal/postprocess"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagRegenOnly    string
+	flagRegenTargets string
+)
+
+var regenCmd = &cobra.Command{
+	Use:   "regen [directory]",
+	Short: "Regenerate instruction files from the stored selection",
+	Long: `Regenerate a project's instruction files without another conversation,
+from the profile, add-ons, and assets recorded by the last run, for the
+same targets, language, tone, and monorepo packages.
+
+--only names the concerns to regenerate, e.g. --only design-system,testing
+for .github/instructions/design-system.instructions.md and
+testing.instructions.md. Only the assets those files draw on go into the
+prompt, so the run is cheaper and every other file is left as it is.
+"copilot", "agents", and "start" name copilot-instructions.md, AGENTS.md,
+and start.prompt.md. Other targets' files count under the concern they are
+rendered from, e.g. .cursor/rules/testing.mdc under testing. Files that
+combine every concern, such as CLAUDE.md, are only rewritten by a full run.
+
+Edited files are confirmed the same way as in launchpad init.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runRegen,
+}
+
+func init() {
+	regenCmd.Flags().StringVar(&flagRegenOnly, "only", "", "Comma-separated concerns to regenerate (default: every file)")
+	regenCmd.Flags().StringVar(&flagRegenTargets, "targets", "", "Comma-separated AI tools to write instructions for (default: the last run's)")
+}
+
+func runRegen(cmd *cobra.Command, args []string) error {
+	root, err := projectDirArg(args)
+	if err != nil {
+		return err
+	}
+	if err := pullState(root); err != nil {
+		return err
+	}
+	_, err = regenerate(root)
+	return err
+}
+
+// regenerate rewrites root's instruction files from the recorded selection,
+// honoring --only and --targets, and returns the paths it wrote. Callers
+// pull remote state first.
+func regenerate(root string) ([]string, error) {
+	m, err := manifest.Load(root)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil || m.ProfileID == "" {
+		return nil, fmt.Errorf("%s has no recorded selection — run launchpad init first", ui.DisplayPath(root))
+	}
+	cfg, err := config.Load(root)
+	if err != nil {
+		return nil, err
+	}
+	only, err := parseConcerns(flagRegenOnly, m, postprocess.Layout{Paths: cfg.Layout})
+	if err != nil {
+		return nil, err
+	}
+	targets := m.Targets
+	if flagRegenTargets != "" {
+		if targets, err = ai.ParseTargets(flagRegenTargets); err != nil {
+			return nil, err
+		}
+	}
+
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		apiKey = loadKeyFromDotEnv()
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("regen needs OPENAI_API_KEY")
+	}
+	provider := ai.NewOpenAIProvider(apiKey, providerOptions(os.Getenv("LAUNCHPAD_MODEL"))...)
+	procs, err := postProcessors(root, m.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	decisions, err := decisionMap(root)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := audited(provider, root)
+	if err != nil {
+		return nil, err
+	}
+	tone := ai.Tone{Verbosity: m.Tone.Verbosity, Formality: m.Tone.Formality, Emoji: m.Tone.Emoji}
+	var warnings []string
+	engine := ai.NewEngine(sender,
+		ai.WithTargets(targets...),
+		ai.WithLanguage(m.Language),
+		ai.WithPostProcessors(procs...),
+		ai.WithDecisionMap(decisions),
+		ai.WithTone(tone),
+		ai.WithAllowedRoots(cfg.AllowedRoots...),
+		ai.WithScope(only...),
+		ai.WithWarningHandler(func(msg string) { warnings = append(warnings, msg) }),
+	)
+
+	// The recorded selection was settled by an earlier run, so it carries
+	// full confidence.
+	sel := &ai.Selection{ProfileID: m.ProfileID, AddonIDs: m.AddonIDs, AssetIDs: m.AssetIDs,
+		Tags: m.Tags, Constraints: m.Constraints, Confidence: 1}
+	for _, p := range m.Packages {
+		sel.Packages = append(sel.Packages, ai.PackageScope{Path: p.Path, ProfileID: p.ProfileID})
+	}
+
+	label := "every file"
+	if len(only) > 0 {
+		label = strings.Join(only, ", ")
+	}
+	spin := ui.NewSpinner("Regenerating " + label + "...")
+	files, err := engine.GenerateFiles(context.Background(), m.ProjectName, sel)
+	spin.Stop()
+	for _, w := range warnings {
+		fmt.Println(ui.Warning.Render("! " + w))
+	}
+	recordRun(provider, sel, len(files), err)
+	if err != nil {
+		return nil, fmt.Errorf("generation error: %w", err)
+	}
+
+	run := runSettings{model: provider.Model(), targets: targets, language: m.Language, tone: tone}
+	created, err := writeGenerated(root, m.ProjectName, run, sel, files, len(only) > 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := pushState(root); err != nil {
+		return nil, err
+	}
+	ui.PrintFileTree(created, root)
+	fmt.Printf("%s Regenerated %s file(s)\n", ui.Success.Render("✔"), ui.Accent.Render(fmt.Sprintf("%d", len(created))))
+	return created, nil
+}
+
+// parseConcerns splits an --only list, refusing concerns that name no file
+// the last run generated. Files are looked up at the path they were
+// generated at, before layout moved them.
+func parseConcerns(list string, m *manifest.Manifest, layout postprocess.Layout) ([]string, error) {
+	known := map[string]bool{}
+	for path := range m.Files {
+		if c := ai.Concern(layout.Original(path)); c != "" {
+			known[c] = true
+		}
+	}
+	var concerns []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(list, ",") {
+		c := strings.ToLower(strings.TrimSpace(part))
+		if c == "" || seen[c] {
+			continue
+		}
+		if !known[c] {
+			names := make([]string, 0, len(known))
+			for k := range known {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("no generated file covers %q (have: %s)", c, strings.Join(names, ", "))
+		}
+		seen[c] = true
+		concerns = append(concerns, c)
+	}
+	return concerns, nil
+}
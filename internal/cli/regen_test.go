@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/manifest"
+	"github.com/ecoker/launchpad/internal/postprocess"
+)
+
+func TestParseConcerns(t *testing.T) {
+	m := manifest.New("demo")
+	for _, p := range []string{
+		".github/copilot-instructions.md",
+		".github/instructions/design-system.instructions.md",
+		".github/instructions/testing.instructions.md",
+	} {
+		m.Record(p, []byte("x\n"))
+	}
+
+	got, err := parseConcerns(" Testing, design-system,testing", m, postprocess.Layout{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"testing", "design-system"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("concerns = %q, want %q", got, want)
+	}
+	if got, err := parseConcerns("", m, postprocess.Layout{}); err != nil || got != nil {
+		t.Errorf("empty list = %q, %v; want every file", got, err)
+	}
+	if _, err := parseConcerns("observability", m, postprocess.Layout{}); err == nil {
+		t.Error("expected an error for a concern no file covers")
+	}
+}
+
+func TestParseConcerns_OtherTargetsAndLayout(t *testing.T) {
+	m := manifest.New("demo")
+	for _, p := range []string{"CLAUDE.md", ".cursor/rules/testing.mdc", "docs/ai/security.instructions.md"} {
+		m.Record(p, []byte("x\n"))
+	}
+	layout := postprocess.Layout{Paths: map[string]string{".github/instructions/": "docs/ai/"}}
+
+	got, err := parseConcerns("testing,security", m, layout)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"testing", "security"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("concerns = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/manifest"
+	"github.com/ecoker/launchpad/internal/sandbox"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <new-name> [directory]",
+	Short: "Rewrite the project name across the generated files",
+	Long: `Replace the project name recorded in the manifest with a new one in every
+file launchpad generated, including the scaffold commands and module paths
+they mention, and in the manifest itself. Only whole-name matches change, so
+my-app doesn't rewrite my-application. Files edited since generation keep
+their edits, and the run is snapshotted so launchpad undo reverts it.
+
+Renaming the directory itself is left to you.`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE:         runRename,
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if name == "" || strings.ContainsAny(name, " \t\n/\\") {
+		return fmt.Errorf("invalid project name %q", name)
+	}
+	root, err := projectDirArg(args[1:])
+	if err != nil {
+		return err
+	}
+	if err := pullState(root); err != nil {
+		return err
+	}
+	m, err := manifest.Load(root)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return fmt.Errorf("no %s in %s — run launchpad init first", manifest.Path, root)
+	}
+	old := m.ProjectName
+	if old == name {
+		fmt.Println(ui.DimStyle.Render("The project is already called " + name + "."))
+		return nil
+	}
+	if old == "" {
+		return fmt.Errorf("%s records no project name to replace", manifest.Path)
+	}
+
+	paths := make([]string, 0, len(m.Files))
+	for p := range m.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var total int
+	for _, p := range paths {
+		n, err := renameFile(root, p, old, name, m)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			fmt.Printf("%s %s %s\n", ui.Success.Render("✔"), ui.FileStyle.Render(p), ui.DimStyle.Render(fmt.Sprintf("(%d)", n)))
+		}
+		total += n
+	}
+
+	m.ProjectName = name
+	if err := m.Save(root); err != nil {
+		return err
+	}
+	if err := manifest.WriteCapabilities(root, m); err != nil {
+		return err
+	}
+	if _, err := manifest.Snapshot(root, m, "rename "+old+" → "+name); err != nil {
+		return err
+	}
+	if err := pushState(root); err != nil {
+		return err
+	}
+	fmt.Printf("%s Renamed %s to %s (%d occurrence(s))\n",
+		ui.Success.Render("✔"), ui.Accent.Render(old), ui.Accent.Render(name), total)
+	return nil
+}
+
+// renameFile replaces old with name in the generated file at path and in its
+// base copy, and updates the manifest entry so a file that was untouched
+// still reads as untouched and an edited one still reads as edited. It
+// returns the number of replacements made on disk.
+func renameFile(root, path, old, name string, m *manifest.Manifest) (int, error) {
+	status, err := m.Status(root, path)
+	if err != nil {
+		return 0, err
+	}
+	if status == manifest.Missing {
+		return 0, nil
+	}
+	full := filepath.Join(root, path)
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return 0, fmt.Errorf("reading %s: %w", path, err)
+	}
+	renamed, n := replaceName(string(data), old, name)
+	if n > 0 {
+		if err := sandbox.WriteFile(full, []byte(renamed), 0o644); err != nil {
+			return 0, fmt.Errorf("writing %s: %w", path, err)
+		}
+	}
+
+	base, err := manifest.ReadBase(root, path)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case status == manifest.Unchanged:
+		m.Record(path, []byte(renamed))
+		if base != nil {
+			err = manifest.WriteBase(root, path, []byte(renamed))
+		}
+	case base != nil:
+		renamedBase, _ := replaceName(string(base), old, name)
+		m.Record(path, []byte(renamedBase))
+		err = manifest.WriteBase(root, path, []byte(renamedBase))
+	}
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// replaceName replaces every whole-name occurrence of old in s. An
+// occurrence is whole when neither neighbour could continue a project name,
+// so "my-app" matches in "cd my-app/" but not in "my-apps" or "old-my-app".
+func replaceName(s, old, name string) (string, int) {
+	var sb strings.Builder
+	n, from := 0, 0
+	for {
+		i := strings.Index(s[from:], old)
+		if i < 0 {
+			sb.WriteString(s[from:])
+			return sb.String(), n
+		}
+		start, end := from+i, from+i+len(old)
+		sb.WriteString(s[from:start])
+		if (start == 0 || !nameByte(s[start-1])) && (end == len(s) || !nameByte(s[end])) {
+			sb.WriteString(name)
+			n++
+		} else {
+			sb.WriteString(old)
+		}
+		from = end
+	}
+}
+
+// nameByte reports whether c can be part of a project name.
+func nameByte(c byte) bool {
+	return c == '-' || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/manifest"
+)
+
+func TestReplaceName(t *testing.T) {
+	tests := []struct {
+		in, want string
+		n        int
+	}{
+		{"mix phx.new my-app", "mix phx.new ledger", 1},
+		{"cd my-app/ && go mod init github.com/acme/my-app", "cd ledger/ && go mod init github.com/acme/ledger", 2},
+		{"my-apps and old-my-app stay", "my-apps and old-my-app stay", 0},
+		{"`my-app`.", "`ledger`.", 1},
+		{"my-appmy-app", "my-appmy-app", 0},
+	}
+	for _, tt := range tests {
+		got, n := replaceName(tt.in, "my-app", "ledger")
+		if got != tt.want || n != tt.n {
+			t.Errorf("replaceName(%q) = (%q, %d), want (%q, %d)", tt.in, got, n, tt.want, tt.n)
+		}
+	}
+}
+
+func TestRenameFile(t *testing.T) {
+	root := t.TempDir()
+	generated := []byte("# my-app\n")
+	m := manifest.New("my-app")
+	for _, p := range []string{"AGENTS.md", "CLAUDE.md"} {
+		if err := manifest.WriteBase(root, p, generated); err != nil {
+			t.Fatal(err)
+		}
+		m.Record(p, generated)
+	}
+	if err := os.WriteFile(filepath.Join(root, "AGENTS.md"), generated, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "CLAUDE.md"), []byte("# my-app\n\nmine\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []string{"AGENTS.md", "CLAUDE.md", "missing.md"} {
+		if _, err := renameFile(root, p, "my-app", "ledger", m); err != nil {
+			t.Fatalf("renameFile(%s): %v", p, err)
+		}
+	}
+	for p, want := range map[string]manifest.FileStatus{"AGENTS.md": manifest.Unchanged, "CLAUDE.md": manifest.Edited} {
+		if got, _ := m.Status(root, p); got != want {
+			t.Errorf("%s status = %v, want %v", p, got, want)
+		}
+	}
+	got, _ := os.ReadFile(filepath.Join(root, "CLAUDE.md"))
+	if string(got) != "# ledger\n\nmine\n" {
+		t.Errorf("CLAUDE.md = %q, want renamed with edits kept", got)
+	}
+	if base, _ := manifest.ReadBase(root, "CLAUDE.md"); string(base) != "# ledger\n" {
+		t.Errorf("base copy = %q, want renamed", base)
+	}
+}
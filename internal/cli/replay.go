@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/session"
+	"github.com/ecoker/launchpad/internal/store"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagReplayModel       string
+	flagReplayExtractOnly bool
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <session-dir|s3://…|gs://…>",
+	Short: "Re-run a recorded conversation and diff the results",
+	Long: `Replay a session recorded with launchpad init --debug: send the same user
+turns to a fresh conversation, extract a selection, regenerate the files,
+and diff both against the recording. Nothing is written to the project.
+
+Useful before changing the default model: record a few sessions, then
+replay them with --model to see what moves.
+
+Sessions saved to a state store can be replayed straight from it, e.g.
+s3://bucket/app/.launchpad/debug/session-1760000000.
+
+The user's turns are replayed verbatim, so a reply that asks different
+questions than the original still gets the original answers.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runReplay,
+}
+
+func init() {
+	replayCmd.Flags().StringVar(&flagReplayModel, "model", "", "Model to replay against (default: LAUNCHPAD_MODEL, then the built-in default)")
+	replayCmd.Flags().BoolVar(&flagReplayExtractOnly, "extract-only", false, "Stop after comparing the extracted selection")
+}
+
+// loadSession reads a recorded session from a directory or, for sessions
+// saved to a state store, from s3:// or gs://.
+func loadSession(location string) (*session.Session, error) {
+	if !store.IsRemote(location) {
+		return session.Load(location)
+	}
+	st, err := store.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	return session.LoadFrom(context.Background(), st)
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	rec, err := loadSession(args[0])
+	if err != nil {
+		return err
+	}
+	if len(rec.Turns) == 0 {
+		return fmt.Errorf("session %s has no recorded turns", args[0])
+	}
+
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		apiKey = loadKeyFromDotEnv()
+	}
+	if apiKey == "" {
+		return fmt.Errorf("replay needs OPENAI_API_KEY")
+	}
+	model := flagReplayModel
+	if model == "" {
+		model = os.Getenv("LAUNCHPAD_MODEL")
+	}
+	provider := ai.NewOpenAIProvider(apiKey, providerOptions(model)...)
+	procs, err := postProcessors(session.ProjectDir(args[0]), rec.ProjectName)
+	if err != nil {
+		return err
+	}
+	decisions, err := decisionMap(session.ProjectDir(args[0]))
+	if err != nil {
+		return err
+	}
+	tone, err := conversationTone(session.ProjectDir(args[0]))
+	if err != nil {
+		return err
+	}
+	roots, err := allowedRoots(session.ProjectDir(args[0]))
+	if err != nil {
+		return err
+	}
+	sender, err := audited(provider, session.ProjectDir(args[0]))
+	if err != nil {
+		return err
+	}
+	var warnings []string
+	engine := ai.NewEngine(sender,
+		ai.WithTargets(rec.Targets...),
+		ai.WithPostProcessors(procs...),
+		ai.WithDecisionMap(decisions),
+		ai.WithTone(tone),
+		ai.WithAllowedRoots(roots...),
+		ai.WithWarningHandler(func(msg string) { warnings = append(warnings, msg) }),
+	)
+
+	fmt.Printf("%s %s → %s, %d turn(s)\n\n", ui.Heading.Render("Replaying"),
+		ui.DimStyle.Render(rec.Model), ui.Accent.Render(provider.Model()), len(rec.Turns))
+
+	ctx := context.Background()
+	spin := ui.NewSpinner("Replaying conversation...")
+	for i, turn := range rec.Turns {
+		if _, err := engine.Chat(ctx, turn); err != nil {
+			spin.Stop()
+			return fmt.Errorf("turn %d: %w", i+1, err)
+		}
+	}
+	sel, err := engine.ExtractDecision(ctx)
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("extracting decision: %w", err)
+	}
+	if len(rec.Agents) > 0 {
+		sel.Agents = rec.Agents
+	}
+	sel.Packages = rec.Packages
+
+	fmt.Println(ui.Heading.Render("Selection"))
+	if changes := session.SelectionChanges(rec.Selection, sel); len(changes) > 0 {
+		for _, c := range changes {
+			fmt.Println("  " + ui.Warning.Render(c))
+		}
+	} else {
+		fmt.Println("  " + ui.Success.Render("✔ unchanged"))
+	}
+	fmt.Println()
+	if flagReplayExtractOnly {
+		return nil
+	}
+
+	spin = ui.NewSpinner("Regenerating files...")
+	files, err := engine.GenerateFiles(ctx, rec.ProjectName, sel)
+	spin.Stop()
+	for _, w := range warnings {
+		fmt.Println(ui.Warning.Render("! " + w))
+	}
+	if err != nil {
+		return fmt.Errorf("generation error: %w", err)
+	}
+
+	fmt.Println(ui.Heading.Render("Files"))
+	changes := session.FileChanges(rec.Files, files)
+	if len(changes) == 0 {
+		fmt.Println("  " + ui.Success.Render("✔ unchanged"))
+	}
+	for _, c := range changes {
+		fmt.Printf("  %s %s\n", ui.Warning.Render(c.Status), ui.FileStyle.Render(c.Path))
+	}
+	for _, c := range changes {
+		if c.Diff != "" {
+			fmt.Println()
+			fmt.Print(c.Diff)
+		}
+	}
+	fmt.Println()
+	return nil
+}
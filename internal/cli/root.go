@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"github.com/ecoker/launchpad/internal/sandbox"
 	"github.com/spf13/cobra"
 )
 
@@ -18,11 +19,35 @@ files, and AGENTS.md — all tailored to your stack and style.
 
 Powered by OpenAI. Your copilot should write code the way you would.`,
 	Version: version,
+	// Writes are confined to the working directory unless a command
+	// re-confines to the project it was pointed at.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return sandbox.Confine(".")
+	},
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(regenCmd)
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(browseCmd)
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(coverageCmd)
+	rootCmd.AddCommand(actionCmd)
+	rootCmd.AddCommand(evalCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(pingCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(extractCmd)
+	rootCmd.AddCommand(recommendCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(renameCmd)
+	rootCmd.AddCommand(hookCmd)
+	rootCmd.AddCommand(serveCmd)
 }
 
 // Execute runs the root command.
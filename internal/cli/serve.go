@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/server"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagServeAddr     string
+	flagServeRate     int
+	flagServeIdle     time.Duration
+	flagServePriceIn  float64
+	flagServePriceOut float64
+	flagServeUser     string
+	flagServeVault    string
+	flagServeField    string
+	flagServeKeyCmd   string
+	flagServeKeyTTL   time.Duration
+	flagServeAdmins   []string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Host launchpad conversations over HTTP for many users",
+	Long: `Run launchpad as a long-lived HTTP service so a platform team can host one
+instance for everyone. Each session gets its own conversation thread with
+the model, its own --rate limit, and its own token and cost accounting;
+GET /usage totals them per user. Generated files are returned in the
+response, never written on the server.
+
+  POST   /sessions                 {"user": "ada"}
+  POST   /sessions/{id}/messages   {"message": "a realtime voting app"}
+  POST   /sessions/{id}/generate   {"project_name": "votes"}
+  GET    /sessions, /sessions/{id}, /usage
+  DELETE /sessions/{id}
+
+Put it behind your SSO proxy and pass --user-header with the header the
+proxy sets (e.g. X-Forwarded-Email): requests without it are refused and
+users only see their own sessions and usage. Users listed in --admin see
+every user's usage.
+
+By default every session uses OPENAI_API_KEY. To bill each user to their
+own key instead, fetch it per user from a secret store:
+
+  --key-vault secret/data/launchpad/{user}    (VAULT_ADDR, VAULT_TOKEN)
+  --key-command 'aws secretsmanager get-secret-value --secret-id launchpad/{user} --query SecretString --output text'
+
+Brokered keys need --user-header. LAUNCHPAD_MODEL and LAUNCHPAD_BASE_URL
+apply as for init.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&flagServeAddr, "addr", "127.0.0.1:8750", "Address to listen on")
+	serveCmd.Flags().IntVar(&flagServeRate, "rate", 20, "Requests per minute allowed per session (0 for no limit)")
+	serveCmd.Flags().DurationVar(&flagServeIdle, "idle", 30*time.Minute, "Close sessions unused for this long")
+	serveCmd.Flags().Float64Var(&flagServePriceIn, "price-in", 0, "USD per million input tokens, for cost estimates")
+	serveCmd.Flags().Float64Var(&flagServePriceOut, "price-out", 0, "USD per million output tokens, for cost estimates")
+	serveCmd.Flags().StringVar(&flagServeUser, "user-header", "", "Header carrying the user identity set by an SSO proxy")
+	serveCmd.Flags().StringSliceVar(&flagServeAdmins, "admin", nil, "Users allowed to see every user's usage (with --user-header)")
+	serveCmd.Flags().StringVar(&flagServeVault, "key-vault", "", "Vault KV path of each user's provider key; {user} is replaced")
+	serveCmd.Flags().StringVar(&flagServeField, "key-field", "openai_api_key", "Field of the Vault secret holding the key")
+	serveCmd.Flags().StringVar(&flagServeKeyCmd, "key-command", "", "Command printing a user's provider key; {user} is replaced")
+	serveCmd.Flags().DurationVar(&flagServeKeyTTL, "key-ttl", 5*time.Minute, "How long brokered keys are cached")
+	serveCmd.MarkFlagsMutuallyExclusive("key-vault", "key-command")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	keys, err := serveKeys()
+	if err != nil {
+		return err
+	}
+	model := os.Getenv("LAUNCHPAD_MODEL")
+	decisions, err := decisionMap(".")
+	if err != nil {
+		return err
+	}
+	tone, err := conversationTone(".")
+	if err != nil {
+		return err
+	}
+	roots, err := allowedRoots(".")
+	if err != nil {
+		return err
+	}
+	// Open the audit log up front so a bad path fails here rather than on
+	// the first session.
+	if _, err := audited(ai.NewOpenAIProvider(""), "."); err != nil {
+		return err
+	}
+	opts := []server.Option{
+		server.WithRateLimit(flagServeRate),
+		server.WithIdleTimeout(flagServeIdle),
+		server.WithPricing(flagServePriceIn, flagServePriceOut),
+		server.WithEngineOptions(ai.WithDecisionMap(decisions), ai.WithTone(tone), ai.WithAllowedRoots(roots...)),
+	}
+	if flagServeUser != "" {
+		opts = append(opts, server.WithUserHeader(flagServeUser), server.WithAdmins(flagServeAdmins...))
+	} else if len(flagServeAdmins) > 0 {
+		return fmt.Errorf("--admin needs --user-header, so admins are authenticated users")
+	}
+	srv := server.New(keys, func(apiKey string) ai.Provider { return sessionProvider(apiKey, model) }, opts...)
+
+	hs := &http.Server{Addr: flagServeAddr, Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = hs.Shutdown(shutdown)
+	}()
+
+	fmt.Printf("%s Serving on %s %s\n", ui.Success.Render("✔"), ui.Accent.Render("http://"+flagServeAddr),
+		ui.DimStyle.Render("(Ctrl+C to stop)"))
+	if err := hs.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// serveKeys picks where session keys come from: a secret store per user
+// when one is configured, otherwise the service's own OPENAI_API_KEY.
+func serveKeys() (server.KeyBroker, error) {
+	var broker server.KeyBroker
+	switch {
+	case flagServeVault != "":
+		addr, token := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN")
+		if addr == "" || token == "" {
+			return nil, fmt.Errorf("--key-vault needs VAULT_ADDR and VAULT_TOKEN")
+		}
+		broker = server.VaultBroker{Addr: addr, Token: token, Namespace: os.Getenv("VAULT_NAMESPACE"),
+			Path: flagServeVault, Field: flagServeField}
+	case flagServeKeyCmd != "":
+		broker = server.CommandBroker{Args: strings.Fields(flagServeKeyCmd)}
+	default:
+		apiKey := os.Getenv("OPENAI_API_KEY")
+		if apiKey == "" {
+			apiKey = loadKeyFromDotEnv()
+		}
+		if apiKey == "" {
+			return nil, fmt.Errorf("serve needs OPENAI_API_KEY, --key-vault, or --key-command")
+		}
+		return server.StaticKey(apiKey), nil
+	}
+	if flagServeUser == "" {
+		return nil, fmt.Errorf("per-user keys need --user-header, so each key goes to an authenticated user")
+	}
+	return server.Cached(broker, flagServeKeyTTL), nil
+}
+
+// sessionProvider is the provider behind one hosted session, wrapped for
+// the audit log when one is configured. Either way it keeps forking,
+// structured output, capability probing, and usage metering.
+func sessionProvider(apiKey, model string) ai.Provider {
+	p := ai.NewOpenAIProvider(apiKey, providerOptions(model)...)
+	wrapped, err := audited(p, ".")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.Warning.Render("! audit log: "+err.Error()))
+		return p
+	}
+	return wrapped
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/audit"
+	"github.com/ecoker/launchpad/internal/server"
+)
+
+func TestSessionProvider(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for name, logPath := range map[string]string{
+		"without audit log": "",
+		"with audit log":    filepath.Join(t.TempDir(), "audit.jsonl"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(audit.PathEnv, logPath)
+			p := sessionProvider("sk-test", "")
+			if _, ok := p.(ai.Forker); !ok {
+				t.Error("session provider can't fork, so generation runs on one thread")
+			}
+			if _, ok := p.(ai.StructuredSender); !ok {
+				t.Error("session provider lost structured output")
+			}
+			if _, ok := p.(ai.CapabilityProber); !ok {
+				t.Error("session provider lost capability probing")
+			}
+			if _, ok := p.(server.Metered); !ok {
+				t.Error("session provider no longer reports usage")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ecoker/launchpad/internal/browse"
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Print one catalog item and its template",
+	Long: `Print the preview browse shows for a profile, add-on, or asset: its summary,
+scaffold command, and template. Palettes are drawn as color swatches and
+font pairings with sample text, so you can see a design asset before
+picking it.
+
+  launchpad show obsidian-indigo
+  launchpad show asset.fonts.inter-jetbrains`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runShow,
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	it, ok := browse.Find(browse.Items(), args[0])
+	if !ok {
+		return fmt.Errorf("no catalog item %q (see launchpad list)", args[0])
+	}
+	fmt.Println(browse.Preview(it))
+	return nil
+}
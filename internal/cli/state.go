@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/config"
+	"github.com/ecoker/launchpad/internal/manifest"
+	"github.com/ecoker/launchpad/internal/sandbox"
+	"github.com/ecoker/launchpad/internal/store"
+	"github.com/ecoker/launchpad/internal/ui"
+)
+
+// stateEnv overrides the configured state location.
+const stateEnv = "LAUNCHPAD_STATE"
+
+// stateLocation returns where projectDir's launchpad state is mirrored, or
+// "" when it only lives on disk.
+func stateLocation(projectDir string) (string, error) {
+	loc := os.Getenv(stateEnv)
+	if loc == "" {
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return "", err
+		}
+		loc = cfg.State
+	}
+	if loc == "" {
+		return "", nil
+	}
+	loc = strings.ReplaceAll(loc, "{project}", filepath.Base(projectDir))
+	if !store.IsRemote(loc) && !strings.HasPrefix(loc, "file://") && !filepath.IsAbs(loc) {
+		loc = filepath.Join(projectDir, loc)
+	}
+	return loc, nil
+}
+
+// stateStore opens the store stateLocation names, or returns nil.
+func stateStore(projectDir string) (store.Store, string, error) {
+	loc, err := stateLocation(projectDir)
+	if err != nil || loc == "" {
+		return nil, "", err
+	}
+	st, err := store.Open(loc)
+	if err != nil {
+		return nil, "", fmt.Errorf("state store: %w", err)
+	}
+	if d, ok := st.(store.Dir); ok {
+		if err := sandbox.Allow(string(d)); err != nil {
+			return nil, "", err
+		}
+	}
+	return st, loc, nil
+}
+
+// pullState restores projectDir's state from its store when the project
+// has none on disk yet.
+func pullState(projectDir string) error {
+	st, loc, err := stateStore(projectDir)
+	if err != nil || st == nil {
+		return err
+	}
+	n, err := manifest.Pull(context.Background(), st, projectDir)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		fmt.Println(ui.DimStyle.Render(fmt.Sprintf("Restored launchpad state from %s (%d file(s))", loc, n)))
+	}
+	return nil
+}
+
+// pushState mirrors projectDir's state to its store, if it has one.
+func pushState(projectDir string) error {
+	st, loc, err := stateStore(projectDir)
+	if err != nil || st == nil {
+		return err
+	}
+	if _, err := manifest.Push(context.Background(), st, projectDir); err != nil {
+		return err
+	}
+	fmt.Println(ui.DimStyle.Render("Saved launchpad state to " + loc))
+	return nil
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/config"
+)
+
+func TestStateLocation(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	root := filepath.Join(t.TempDir(), "ledger")
+	if err := os.MkdirAll(filepath.Join(root, ".launchpad"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(stateEnv, "")
+	if loc, err := stateLocation(root); err != nil || loc != "" {
+		t.Errorf("unconfigured = %q, %v; want local only", loc, err)
+	}
+
+	// A checked-in project config can't point the state outside the
+	// project; the user config can.
+	cfg := `{"state": "../state/{project}"}`
+	if err := os.WriteFile(filepath.Join(root, ".launchpad", "config.json"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stateLocation(root); err == nil {
+		t.Error("expected an error for project state outside the project")
+	}
+	if err := os.Remove(filepath.Join(root, ".launchpad", "config.json")); err != nil {
+		t.Fatal(err)
+	}
+	user, err := config.UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(user), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(user, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if loc, _ := stateLocation(root); loc != filepath.Join(filepath.Dir(root), "state", "ledger") {
+		t.Errorf("relative location = %q, want it resolved against the project", loc)
+	}
+
+	t.Setenv(stateEnv, "s3://launchpad-state/{project}")
+	if loc, _ := stateLocation(root); loc != "s3://launchpad-state/ledger" {
+		t.Errorf("env location = %q", loc)
+	}
+}
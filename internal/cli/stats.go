@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/history"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show which profiles and assets you generate most, with token use and failure rates",
+	Long: `Summarize the local generation history: runs per profile, average tokens
+per profile, failure rates, and the most-used add-ons and assets.
+
+History is kept only on this machine, in the launchpad folder of your user
+config directory. Set ` + history.DisableEnv + `=1 to stop recording.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runStats,
+}
+
+// statsTop caps how many add-ons and assets are listed.
+const statsTop = 10
+
+func runStats(cmd *cobra.Command, args []string) error {
+	path, err := history.DefaultPath()
+	if err != nil {
+		return err
+	}
+	entries, err := history.Load(path)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println(ui.DimStyle.Render("No generation history yet — run launchpad init first."))
+		return nil
+	}
+
+	st := history.Summarize(entries)
+	fmt.Println(ui.Heading.Render(fmt.Sprintf("%d run(s), %d failed (%.0f%%)",
+		st.Runs, st.Failures, 100*float64(st.Failures)/float64(st.Runs))))
+	fmt.Println()
+
+	fmt.Println(ui.Heading.Render("  Profiles:"))
+	for _, p := range st.Profiles {
+		tokens := "—"
+		if p.AvgTokens > 0 {
+			tokens = fmt.Sprintf("%d tokens avg", p.AvgTokens)
+		}
+		fmt.Printf("    %-22s %4d run(s)  %5.0f%% failed  %s\n",
+			ui.ProfileID.Render(p.ProfileID), p.Runs, 100*p.FailureRate(), ui.DimStyle.Render(tokens))
+	}
+	printCounts("Add-ons:", st.Addons)
+	printCounts("Assets:", st.Assets)
+	fmt.Println()
+	return nil
+}
+
+func printCounts(title string, counts []history.Count) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println(ui.Heading.Render("  " + title))
+	for i, c := range counts {
+		if i == statsTop {
+			fmt.Println(ui.DimStyle.Render(fmt.Sprintf("    … and %d more", len(counts)-statsTop)))
+			break
+		}
+		fmt.Printf("    %-36s %4d run(s)\n", c.ID, c.Runs)
+	}
+}
+
+// recordRun appends a generation attempt to the local history. History is a
+// convenience, so failures to write it are ignored.
+func recordRun(provider *ai.OpenAIProvider, sel *ai.Selection, files int, genErr error) {
+	if os.Getenv(history.DisableEnv) != "" {
+		return
+	}
+	path, err := history.DefaultPath()
+	if err != nil {
+		return
+	}
+	in, out := provider.Usage()
+	e := history.Entry{
+		At:           time.Now().UTC(),
+		Model:        provider.Model(),
+		ProfileID:    sel.ProfileID,
+		AddonIDs:     sel.AddonIDs,
+		AssetIDs:     sel.AssetIDs,
+		InputTokens:  in,
+		OutputTokens: out,
+		Files:        files,
+	}
+	if genErr != nil {
+		e.Error = genErr.Error()
+	}
+	_ = history.Append(path, e)
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ecoker/launchpad/internal/manifest"
+	"github.com/ecoker/launchpad/internal/sandbox"
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var flagUndoTo string
+
+var historyCmd = &cobra.Command{
+	Use:   "history [directory]",
+	Short: "List previous generation runs that undo can return to",
+	Long: `List the snapshots Launchpad keeps of a project's generated files, newest
+first. Each generation run is snapshotted, as is the state undo replaces,
+so any entry can be restored with launchpad undo --to <id>.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runHistory,
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [directory]",
+	Short: "Revert the generated instruction files to a previous run",
+	Long: `Put the project's generated files back the way an earlier run left them.
+Without --to, goes back one generation run. The current files are
+snapshotted first, so an undo can itself be undone.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runUndo,
+}
+
+func init() {
+	undoCmd.Flags().StringVar(&flagUndoTo, "to", "", "Run ID to restore (see launchpad history)")
+}
+
+func projectDirArg(args []string) (string, error) {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	return abs, sandbox.Confine(abs)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	root, err := projectDirArg(args)
+	if err != nil {
+		return err
+	}
+	if err := pullState(root); err != nil {
+		return err
+	}
+	runs, err := manifest.Runs(root)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		fmt.Println(ui.DimStyle.Render("No runs recorded yet — launchpad init snapshots each generation."))
+		return nil
+	}
+	current := manifest.Current(root)
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := runs[i]
+		marker := "  "
+		if r.ID == current {
+			marker = ui.Success.Render("▸ ")
+		}
+		fmt.Printf("%s%s  %s  %-24s %3d file(s)  %s\n", marker, ui.Accent.Render(r.ID),
+			ui.DimStyle.Render(r.At.Local().Format("2006-01-02 15:04")),
+			ui.ProfileID.Render(r.Manifest.ProfileID), len(r.Restorable), ui.DimStyle.Render(r.Note))
+	}
+	return nil
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	root, err := projectDirArg(args)
+	if err != nil {
+		return err
+	}
+	if err := pullState(root); err != nil {
+		return err
+	}
+	id := flagUndoTo
+	if id == "" {
+		prev, err := manifest.Previous(root)
+		if err != nil {
+			return err
+		}
+		if prev == nil {
+			return fmt.Errorf("nothing to undo — no earlier generation run (see launchpad history)")
+		}
+		id = prev.ID
+	}
+
+	written, removed, err := manifest.Restore(root, id)
+	if err != nil {
+		return err
+	}
+	if m, err := manifest.Load(root); err == nil && m != nil {
+		if err := manifest.WriteCapabilities(root, m); err != nil {
+			return err
+		}
+	}
+	if err := pushState(root); err != nil {
+		return err
+	}
+	for _, p := range written {
+		fmt.Printf("%s %s\n", ui.Success.Render("↺"), ui.FileStyle.Render(p))
+	}
+	for _, p := range removed {
+		fmt.Printf("%s %s\n", ui.Warning.Render("–"), ui.FileStyle.Render(p))
+	}
+	fmt.Printf("%s Restored run %s (%d file(s) written, %d removed)\n",
+		ui.Success.Render("✔"), ui.Accent.Render(id), len(written), len(removed))
+	return nil
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ecoker/launchpad/internal/ui"
+	"github.com/ecoker/launchpad/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagValidateJSON  bool
+	flagValidateDrift bool
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [directory]",
+	Short: "Check instruction files for frontmatter, applyTo, and tool errors",
+	Long: `Validate .github/copilot-instructions.md, .github/instructions/*.instructions.md,
+and .github/prompts/*.prompt.md in an existing repository.
+
+Checks YAML frontmatter syntax, that scoped instruction files declare a valid
+applyTo glob, and that prompt files only use supported tools. Exits non-zero
+when any error is found; warnings alone don't fail.
+
+With --drift, also compares what the instructions say about the test runner,
+styling system, package manager, linter, and framework versions against the
+repository's package.json, go.mod, lockfiles, and config files, and warns
+about guidance the codebase has outgrown.
+
+With --json, prints {"checked": N, "diagnostics": [...]} where each diagnostic
+has file, line, rule, severity ("error" or "warning"), and message.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runValidate,
+}
+
+func init() {
+	validateCmd.Flags().BoolVar(&flagValidateJSON, "json", false, "Print diagnostics as JSON for editors and CI annotations")
+	validateCmd.Flags().BoolVar(&flagValidateDrift, "drift", false, "Also flag guidance that no longer matches the codebase")
+}
+
+// validateReport is the --json output.
+type validateReport struct {
+	Checked     int                   `json:"checked"`
+	Diagnostics []validate.Diagnostic `json:"diagnostics"`
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("resolving path: %w", err)
+	}
+
+	diags, checked, err := validate.Dir(root)
+	if err != nil {
+		return err
+	}
+	if flagValidateDrift {
+		drift, err := validate.Drift(root)
+		if err != nil {
+			return err
+		}
+		diags = append(diags, drift...)
+	}
+	errs := validate.Errors(diags)
+
+	if flagValidateJSON {
+		if diags == nil {
+			diags = []validate.Diagnostic{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(validateReport{Checked: checked, Diagnostics: diags}); err != nil {
+			return err
+		}
+		if len(errs) > 0 {
+			cmd.SilenceErrors = true
+			return fmt.Errorf("%d error(s)", len(errs))
+		}
+		return nil
+	}
+
+	if checked == 0 {
+		fmt.Println(ui.Warning.Render("No instruction files found in " + ui.DisplayPath(root)))
+		return nil
+	}
+
+	for _, d := range diags {
+		mark := ui.Error.Render("✘")
+		if d.Severity == validate.SeverityWarning {
+			mark = ui.Warning.Render("!")
+		}
+		fmt.Printf("%s %s\n", mark, d)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d error(s), %d warning(s) in %d instruction file(s)",
+			len(errs), len(diags)-len(errs), checked)
+	}
+	if len(diags) > 0 {
+		fmt.Printf("%s %d instruction file(s) are valid, with %d warning(s)\n", ui.Success.Render("✔"), checked, len(diags))
+		return nil
+	}
+	fmt.Printf("%s %d instruction file(s) are valid\n", ui.Success.Render("✔"), checked)
+	return nil
+}
@@ -0,0 +1,523 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/diff"
+	"github.com/ecoker/launchpad/internal/manifest"
+	"github.com/ecoker/launchpad/internal/sandbox"
+	"github.com/ecoker/launchpad/internal/ui"
+)
+
+// conflictAction is what to do with a generated file whose path already
+// holds different content.
+type conflictAction string
+
+const (
+	actionMerge     conflictAction = "merge"
+	actionOverwrite conflictAction = "overwrite"
+	actionSkip      conflictAction = "skip"
+	actionDiff      conflictAction = "diff"
+)
+
+// --force modes. Without --force every conflicting file is confirmed.
+// Ownership comes from the manifest: a file is launchpad's if a previous
+// run recorded it.
+const (
+	forceAll          = "all"          // overwrite anything without asking
+	forcePaths        = "paths"        // overwrite files launchpad generated, even if edited; ask about the rest
+	forceInstructions = "instructions" // overwrite files launchpad generated, even if edited; skip the rest
+)
+
+// backupRoot holds copies of files replaced by a run, one timestamped
+// directory per run (see newBackupDir), relative to the project root.
+const backupRoot = ".launchpad/backup"
+
+// runSettings are the generation settings recorded with the selection:
+// the model, so the next run can explain churn, and the rest so regen can
+// repeat the run.
+type runSettings struct {
+	model    string
+	targets  []string
+	language string
+	tone     ai.Tone
+}
+
+// writeGenerated writes files under outputPath, asking per file before
+// replacing anything the user wrote or edited, and updates the manifest.
+// A partial run regenerated only some files; the rest keep their recorded
+// state rather than counting as no longer generated.
+// It returns the absolute paths that were written.
+func writeGenerated(outputPath, projectName string, run runSettings, sel *ai.Selection, files []ai.FileOutput, partial bool) ([]string, error) {
+	// Compare against the previous manifest so files the user edited since
+	// the last generation aren't silently clobbered.
+	prev, err := manifest.Load(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	next := manifest.New(projectName)
+	next.ProfileID = sel.ProfileID
+	next.AddonIDs = sel.AddonIDs
+	next.AssetIDs = sel.AssetIDs
+	next.Tags = sel.Tags
+	next.Constraints = sel.Constraints
+	next.Model = run.model
+	next.Targets = run.targets
+	next.Language = run.language
+	next.Tone = manifest.Tone{Verbosity: run.tone.Verbosity, Formality: run.tone.Formality, Emoji: run.tone.Emoji}
+	for _, p := range sel.Packages {
+		next.Packages = append(next.Packages, manifest.Package{Path: p.Path, ProfileID: p.ProfileID})
+	}
+	if next.Templates, err = ai.TemplateHashes(*sel); err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		for path, entry := range prev.Files {
+			next.Files[path] = entry
+		}
+	}
+
+	// Resolve every conflict before touching the disk, so a run the user
+	// aborts half-way through the prompts writes nothing.
+	var plan []pendingWrite
+	generated := make(map[string]string, len(files))
+	for _, f := range files {
+		if err := ensureWithin(outputPath, f.Path); err != nil {
+			return nil, err
+		}
+		content, err := keepRegions(outputPath, f.Path, []byte(f.Content+"\n"))
+		if err != nil {
+			return nil, err
+		}
+		generated[f.Path] = manifest.Hash(content)
+		data, write, err := resolveConflict(outputPath, f.Path, content, prev)
+		if err != nil {
+			return nil, err
+		}
+		if !write {
+			fmt.Printf("%s Kept existing %s\n", ui.DimStyle.Render("–"), ui.FileStyle.Render(f.Path))
+			continue
+		}
+		plan = append(plan, pendingWrite{path: f.Path, data: data, content: content})
+	}
+	if partial && prev != nil {
+		for path, entry := range prev.Files {
+			if _, ok := generated[path]; !ok {
+				generated[path] = entry.SHA256
+			}
+		}
+	}
+
+	backupDir := newBackupDir(outputPath, time.Now())
+	var backedUp int
+	for _, w := range plan {
+		saved, err := backupFile(outputPath, backupDir, w.path, w.data)
+		if err != nil {
+			return nil, err
+		}
+		if saved {
+			backedUp++
+		}
+	}
+
+	if err := applyWrites(outputPath, plan); err != nil {
+		return nil, err
+	}
+
+	created := make([]string, 0, len(plan))
+	for _, w := range plan {
+		// The manifest and base copy always track what Launchpad generated,
+		// so merged files keep registering as edited on the next run.
+		if err := manifest.WriteBase(outputPath, w.path, w.content); err != nil {
+			return nil, err
+		}
+		next.Record(w.path, w.content)
+		created = append(created, filepath.Join(outputPath, w.path))
+	}
+
+	next.GeneratedAt = time.Now().UTC()
+	if err := next.Save(outputPath); err != nil {
+		return nil, err
+	}
+	if err := manifest.WriteCapabilities(outputPath, next); err != nil {
+		return nil, err
+	}
+	if _, err := manifest.Snapshot(outputPath, next, manifest.NoteGenerate); err != nil {
+		return nil, err
+	}
+
+	printChurn(manifest.Compare(prev, next, generated))
+
+	if backedUp > 0 {
+		fmt.Printf("%s Backed up %d replaced file(s) to %s\n",
+			ui.DimStyle.Render("↺"), backedUp, ui.FileStyle.Render(backupDir))
+		fmt.Printf("  %s cp -R %s/. .\n", ui.DimStyle.Render("restore with:"), filepath.ToSlash(backupDir))
+	}
+	return created, nil
+}
+
+// keepRegions carries the keep regions of the file already at path into
+// content, so hand-written rules between the markers survive regeneration.
+func keepRegions(root, path string, content []byte) ([]byte, error) {
+	local, err := os.ReadFile(filepath.Join(root, path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return content, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return []byte(diff.Keep(string(local), string(content))), nil
+}
+
+// printChurn reports how this run's output differs from the last one.
+func printChurn(c *manifest.Churn) {
+	if c == nil {
+		return
+	}
+	if c.Empty() {
+		fmt.Printf("%s Output identical to the previous run\n", ui.DimStyle.Render("="))
+		return
+	}
+	fmt.Printf("%s Since the previous run: %d changed, %d new, %d no longer generated\n",
+		ui.DimStyle.Render("Δ"), len(c.Changed), len(c.Added), len(c.Removed))
+	for _, cause := range c.Causes {
+		fmt.Printf("  %s %s\n", ui.DimStyle.Render("because"), cause)
+	}
+	for _, group := range []struct {
+		mark  string
+		paths []string
+	}{{"~", c.Changed}, {"+", c.Added}, {"-", c.Removed}} {
+		for _, p := range group.paths {
+			fmt.Printf("  %s %s\n", ui.DimStyle.Render(group.mark), ui.FileStyle.Render(p))
+		}
+	}
+}
+
+// maxListed caps how many top-level entries printExisting names.
+const maxListed = 12
+
+// printExisting describes a non-empty target directory before the
+// conversation starts: its top-level entries, and which files in the
+// expected output set already exist and what will happen to them.
+func printExisting(root string, entries []os.DirEntry, outputs []string) error {
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() {
+			name += "/"
+		}
+		names = append(names, name)
+	}
+	listed := names
+	if len(listed) > maxListed {
+		listed = append(listed[:maxListed:maxListed], fmt.Sprintf("(+%d more)", len(names)-maxListed))
+	}
+	fmt.Printf("%s %s\n", ui.DimStyle.Render("Directory isn't empty:"), strings.Join(listed, "  "))
+
+	existing, err := existingOutputs(root, outputs)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		fmt.Println(ui.DimStyle.Render("None of it is in the way of the generated files."))
+		return nil
+	}
+	prev, err := manifest.Load(root)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s %d file(s) launchpad writes already exist:\n", ui.Warning.Render("!"), len(existing))
+	for _, p := range existing {
+		status, err := prev.Status(root, p)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  %s  %s\n", ui.FileStyle.Render(p), ui.DimStyle.Render(existingFate(status)))
+	}
+	return nil
+}
+
+// existingFate says what a run will do to an existing file, given --force.
+func existingFate(status manifest.FileStatus) string {
+	overwrite := "overwritten, with a backup"
+	switch status {
+	case manifest.Unchanged:
+		return "generated earlier, unchanged — replaced"
+	case manifest.Edited:
+		if flagForce != "" {
+			return "generated earlier, edited since — " + overwrite
+		}
+		return "generated earlier, edited since — you'll be asked"
+	}
+	switch flagForce {
+	case forceAll:
+		return "not from launchpad — " + overwrite
+	case forceInstructions:
+		return "not from launchpad — left alone"
+	}
+	return "not from launchpad — you'll be asked"
+}
+
+// existingOutputs returns the files under root, slash-separated and
+// sorted, that fall within outputs. A trailing slash in outputs covers a
+// whole directory.
+func existingOutputs(root string, outputs []string) ([]string, error) {
+	var found []string
+	for _, out := range outputs {
+		full := filepath.Join(root, filepath.FromSlash(out))
+		if !strings.HasSuffix(out, "/") {
+			if info, err := os.Stat(full); err == nil && !info.IsDir() {
+				found = append(found, out)
+			}
+			continue
+		}
+		err := filepath.WalkDir(full, func(path string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			found = append(found, filepath.ToSlash(rel))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", out, err)
+		}
+	}
+	sort.Strings(found)
+	return found, nil
+}
+
+// ensureWithin refuses any path that would resolve outside root once joined
+// to it. Paths are sanitized by the engine already; this is the last check
+// before anything touches the disk.
+func ensureWithin(root, path string) error {
+	if _, err := ai.SanitizePath(path); err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(root, filepath.Join(root, path))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("refusing to write %q outside %s", path, root)
+	}
+	return nil
+}
+
+// pendingWrite is one file a run has decided to write.
+type pendingWrite struct {
+	path    string // relative to the project root
+	data    []byte // what lands on disk (possibly merged with user edits)
+	content []byte // what Launchpad generated
+}
+
+// applyWrites writes every planned file or none of them. Each file is
+// written to a temp sibling and renamed into place; if any step fails, files
+// already replaced are restored, new files are removed, and directories the
+// run created are deleted.
+func applyWrites(root string, plan []pendingWrite) (err error) {
+	var undo []func() error
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(undo) - 1; i >= 0; i-- {
+			if undoErr := undo[i](); undoErr != nil {
+				err = fmt.Errorf("%w (rollback also failed: %v)", err, undoErr)
+			}
+		}
+	}()
+
+	for _, w := range plan {
+		fullPath := filepath.Join(root, w.path)
+
+		if dir := firstMissingDir(filepath.Dir(fullPath)); dir != "" {
+			if err := sandbox.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+				return fmt.Errorf("creating directory for %s: %w", w.path, err)
+			}
+			undo = append(undo, func() error { return sandbox.RemoveAll(dir) })
+		}
+
+		original, readErr := os.ReadFile(fullPath)
+		existed := readErr == nil
+
+		tmp := fullPath + ".launchpad-tmp"
+		if err := sandbox.WriteFile(tmp, w.data, 0o644); err != nil {
+			sandbox.Remove(tmp)
+			return fmt.Errorf("writing %s: %w", w.path, err)
+		}
+		if err := sandbox.Rename(tmp, fullPath); err != nil {
+			sandbox.Remove(tmp)
+			return fmt.Errorf("writing %s: %w", w.path, err)
+		}
+
+		if existed {
+			undo = append(undo, func() error { return sandbox.WriteFile(fullPath, original, 0o644) })
+		} else {
+			undo = append(undo, func() error { return sandbox.Remove(fullPath) })
+		}
+	}
+	return nil
+}
+
+// firstMissingDir returns the outermost ancestor of dir (inclusive) that
+// doesn't exist yet, or "" when dir already exists.
+func firstMissingDir(dir string) string {
+	missing := ""
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return missing
+		}
+		missing = dir
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return missing
+		}
+		dir = parent
+	}
+}
+
+// newBackupDir names this run's backup directory under root, relative to
+// it: a millisecond timestamp, suffixed when an earlier run already took
+// that name, so runs never share a directory.
+func newBackupDir(root string, now time.Time) string {
+	stamp := now.UTC().Format("20060102-150405.000")
+	dir := filepath.Join(backupRoot, stamp)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(root, dir)); errors.Is(err, fs.ErrNotExist) {
+			return dir
+		}
+		dir = filepath.Join(backupRoot, fmt.Sprintf("%s-%d", stamp, n))
+	}
+}
+
+// backupFile copies the current content of path into backupDir before it is
+// replaced with data. Nothing is copied when the file doesn't exist or
+// already holds data. It reports whether a copy was made.
+func backupFile(root, backupDir, path string, data []byte) (bool, error) {
+	current, err := os.ReadFile(filepath.Join(root, path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading %s for backup: %w", path, err)
+	}
+	if bytes.Equal(current, data) {
+		return false, nil
+	}
+
+	dest := filepath.Join(root, backupDir, path)
+	if err := sandbox.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return false, fmt.Errorf("creating backup directory: %w", err)
+	}
+	if err := sandbox.WriteFile(dest, current, 0o644); err != nil {
+		return false, fmt.Errorf("backing up %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// resolveConflict decides what to write at path. Missing files, files
+// identical to the new content, and launchpad-owned files untouched outside
+// their keep regions are written without asking; anything else is confirmed unless --force is set.
+// --force=instructions never writes over a file launchpad didn't generate.
+func resolveConflict(root, path string, content []byte, prev *manifest.Manifest) ([]byte, bool, error) {
+	status, err := prev.Status(root, path)
+	if err != nil {
+		return nil, false, err
+	}
+	if flagForce == forceAll || status == manifest.Missing || status == manifest.Unchanged {
+		return content, true, nil
+	}
+	if (flagForce == forcePaths || flagForce == forceInstructions) && status == manifest.Edited {
+		return content, true, nil
+	}
+	if flagForce == forceInstructions {
+		return nil, false, nil
+	}
+
+	local, err := os.ReadFile(filepath.Join(root, path))
+	if err != nil {
+		return nil, false, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if string(local) == string(content) {
+		return content, true, nil
+	}
+
+	var base []byte
+	if status == manifest.Edited {
+		if base, err = manifest.ReadBase(root, path); err != nil {
+			return nil, false, err
+		}
+		// Edits confined to keep regions are already carried into content.
+		if base != nil && diff.EqualOutsideKept(string(base), string(local)) {
+			return content, true, nil
+		}
+	}
+
+	for {
+		action, err := chooseConflictAction(path, status, base != nil)
+		if err != nil {
+			return nil, false, err
+		}
+		switch action {
+		case actionSkip:
+			return nil, false, nil
+		case actionOverwrite:
+			return content, true, nil
+		case actionMerge:
+			merged, conflicts := diff.Merge3(string(base), string(local), string(content))
+			if conflicts > 0 {
+				fmt.Printf("%s Merged %s with %d conflict(s) — resolve the markers before committing\n",
+					ui.Warning.Render("!"), ui.FileStyle.Render(path), conflicts)
+			}
+			return []byte(merged), true, nil
+		case actionDiff:
+			fmt.Println()
+			fmt.Print(diff.Unified(string(local), string(content), path+" (current)", path+" (generated)"))
+			fmt.Println()
+		}
+	}
+}
+
+// chooseConflictAction asks how to handle one conflicting path. Merging is
+// only offered for launchpad-owned files that have a base copy.
+func chooseConflictAction(path string, status manifest.FileStatus, canMerge bool) (conflictAction, error) {
+	title := fmt.Sprintf("%s already exists.", path)
+	if status == manifest.Edited {
+		title = fmt.Sprintf("%s was edited since it was generated.", path)
+	}
+
+	var options []huh.Option[conflictAction]
+	if canMerge {
+		options = append(options, huh.NewOption("Merge — keep my edits, mark conflicts", actionMerge))
+	}
+	options = append(options,
+		huh.NewOption("Overwrite with the generated file", actionOverwrite),
+		huh.NewOption("Skip — keep the existing file", actionSkip),
+		huh.NewOption("View diff", actionDiff),
+	)
+
+	action := options[0].Value
+	err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[conflictAction]().
+				Title(title).
+				Options(options...).
+				Value(&action),
+		),
+	).Run()
+	return action, err
+}
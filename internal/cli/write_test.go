@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/manifest"
+)
+
+// TestResolveConflict_NoPrompt covers the cases that must be written without
+// asking the user anything.
+func TestResolveConflict_NoPrompt(t *testing.T) {
+	root := t.TempDir()
+	owned := []byte("generated\n")
+	if err := os.WriteFile(filepath.Join(root, "AGENTS.md"), owned, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("same\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	prev := manifest.New("demo")
+	prev.Record("AGENTS.md", owned)
+
+	tests := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		{"missing file", ".github/copilot-instructions.md", "new\n"},
+		{"untouched launchpad-owned file", "AGENTS.md", "regenerated\n"},
+		{"identical existing content", "README.md", "same\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, write, err := resolveConflict(root, tt.path, []byte(tt.content), prev)
+			if err != nil {
+				t.Fatalf("resolveConflict: %v", err)
+			}
+			if !write || string(data) != tt.content {
+				t.Errorf("got (%q, %v), want (%q, true)", data, write, tt.content)
+			}
+		})
+	}
+}
+
+func TestResolveConflict_Force(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "AGENTS.md"), []byte("mine\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	flagForce = forceAll
+	t.Cleanup(func() { flagForce = "" })
+
+	data, write, err := resolveConflict(root, "AGENTS.md", []byte("generated\n"), nil)
+	if err != nil {
+		t.Fatalf("resolveConflict: %v", err)
+	}
+	if !write || string(data) != "generated\n" {
+		t.Errorf("got (%q, %v), want forced overwrite", data, write)
+	}
+}
+
+func TestResolveConflict_ForcePaths(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "AGENTS.md"), []byte("generated, then edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	prev := manifest.New("demo")
+	prev.Record("AGENTS.md", []byte("generated\n"))
+	flagForce = forcePaths
+	t.Cleanup(func() { flagForce = "" })
+
+	data, write, err := resolveConflict(root, "AGENTS.md", []byte("regenerated\n"), prev)
+	if err != nil {
+		t.Fatalf("resolveConflict: %v", err)
+	}
+	if !write || string(data) != "regenerated\n" {
+		t.Errorf("got (%q, %v), want launchpad-owned file overwritten", data, write)
+	}
+}
+
+func TestResolveConflict_ForceInstructions(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{"AGENTS.md": "generated, then edited\n", "CLAUDE.md": "mine\n"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prev := manifest.New("demo")
+	prev.Record("AGENTS.md", []byte("generated\n"))
+	flagForce = forceInstructions
+	t.Cleanup(func() { flagForce = "" })
+
+	data, write, err := resolveConflict(root, "AGENTS.md", []byte("regenerated\n"), prev)
+	if err != nil {
+		t.Fatalf("resolveConflict: %v", err)
+	}
+	if !write || string(data) != "regenerated\n" {
+		t.Errorf("AGENTS.md: got (%q, %v), want launchpad-owned file overwritten", data, write)
+	}
+	if _, write, err := resolveConflict(root, "CLAUDE.md", []byte("generated\n"), prev); err != nil || write {
+		t.Errorf("CLAUDE.md: got (write=%v, %v), want the unmanaged file skipped without asking", write, err)
+	}
+}
+
+func TestExistingOutputs(t *testing.T) {
+	root := t.TempDir()
+	for _, p := range []string{"AGENTS.md", "README.md", ".github/instructions/go.instructions.md", ".github/workflows/ci.yml"} {
+		full := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := existingOutputs(root, []string{"AGENTS.md", ".github/instructions/", ".github/prompts/", "CLAUDE.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{".github/instructions/go.instructions.md", "AGENTS.md"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("existingOutputs = %v, want %v", got, want)
+	}
+}
+
+func TestBackupFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "AGENTS.md"), []byte("mine\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	backupDir := filepath.Join(backupRoot, "run")
+
+	saved, err := backupFile(root, backupDir, "AGENTS.md", []byte("generated\n"))
+	if err != nil || !saved {
+		t.Fatalf("backupFile = (%v, %v), want (true, nil)", saved, err)
+	}
+	got, err := os.ReadFile(filepath.Join(root, backupDir, "AGENTS.md"))
+	if err != nil || string(got) != "mine\n" {
+		t.Errorf("backup content = (%q, %v), want original", got, err)
+	}
+
+	if saved, _ := backupFile(root, backupDir, "missing.md", []byte("x")); saved {
+		t.Error("expected no backup for a missing file")
+	}
+	if saved, _ := backupFile(root, backupDir, "AGENTS.md", []byte("mine\n")); saved {
+		t.Error("expected no backup when content is unchanged")
+	}
+}
+
+func TestNewBackupDir_Unique(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	seen := map[string]bool{}
+	for range 3 {
+		dir := newBackupDir(root, now)
+		if seen[dir] {
+			t.Fatalf("%s handed out twice", dir)
+		}
+		seen[dir] = true
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !seen[filepath.Join(backupRoot, "20260301-120000.000-3")] {
+		t.Errorf("dirs = %v, want suffixed names for same-instant runs", seen)
+	}
+}
+
+func TestApplyWrites_RollsBackOnFailure(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "AGENTS.md"), []byte("original\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := []pendingWrite{
+		{path: ".github/copilot-instructions.md", data: []byte("new\n")},
+		{path: "AGENTS.md", data: []byte("replaced\n")},
+		// AGENTS.md is a file, so writing beneath it fails.
+		{path: "AGENTS.md/broken.md", data: []byte("x\n")},
+	}
+	if err := applyWrites(root, plan); err == nil {
+		t.Fatal("expected applyWrites to fail")
+	}
+
+	if _, err := os.Stat(filepath.Join(root, ".github")); !os.IsNotExist(err) {
+		t.Errorf("expected .github to be rolled back, stat err = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(root, "AGENTS.md"))
+	if err != nil || string(got) != "original\n" {
+		t.Errorf("AGENTS.md = (%q, %v), want original content restored", got, err)
+	}
+}
+
+func TestApplyWrites_Success(t *testing.T) {
+	root := t.TempDir()
+	plan := []pendingWrite{
+		{path: ".github/instructions/go.instructions.md", data: []byte("go\n")},
+		{path: "AGENTS.md", data: []byte("agents\n")},
+	}
+	if err := applyWrites(root, plan); err != nil {
+		t.Fatalf("applyWrites: %v", err)
+	}
+	for _, w := range plan {
+		got, err := os.ReadFile(filepath.Join(root, w.path))
+		if err != nil || string(got) != string(w.data) {
+			t.Errorf("%s = (%q, %v), want %q", w.path, got, err, w.data)
+		}
+	}
+}
+
+func TestEnsureWithin(t *testing.T) {
+	root := t.TempDir()
+	for _, ok := range []string{"AGENTS.md", ".github/instructions/go.instructions.md"} {
+		if err := ensureWithin(root, ok); err != nil {
+			t.Errorf("ensureWithin(%q) unexpected error: %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"../escape.md", "/etc/passwd", ".github/../../escape.md", ""} {
+		if err := ensureWithin(root, bad); err == nil {
+			t.Errorf("ensureWithin(%q) = nil, want error", bad)
+		}
+	}
+}
+
+func TestResolveConflict_KeepRegionEdits(t *testing.T) {
+	root := t.TempDir()
+	generated := []byte("# Rules\n\n- one\n")
+	edited := "# Rules\n\n- one\n\n<!-- launchpad:keep -->\n- ours\n<!-- /launchpad:keep -->\n"
+	if err := os.WriteFile(filepath.Join(root, "AGENTS.md"), []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := manifest.WriteBase(root, "AGENTS.md", generated); err != nil {
+		t.Fatal(err)
+	}
+	prev := manifest.New("demo")
+	prev.Record("AGENTS.md", generated)
+
+	content, err := keepRegions(root, "AGENTS.md", []byte("# Rules\n\n- one\n- two\n"))
+	if err != nil {
+		t.Fatalf("keepRegions: %v", err)
+	}
+	data, write, err := resolveConflict(root, "AGENTS.md", content, prev)
+	if err != nil {
+		t.Fatalf("resolveConflict: %v", err)
+	}
+	want := "# Rules\n\n- one\n\n<!-- launchpad:keep -->\n- ours\n<!-- /launchpad:keep -->\n\n- two\n"
+	if !write || string(data) != want {
+		t.Errorf("got (%q, %v), want (%q, true)", data, write, want)
+	}
+}
@@ -0,0 +1,242 @@
+// Package config loads Launchpad settings from the user's config directory
+// and from the project, with project values taking precedence.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/store"
+)
+
+// ProjectPath is the project-level config file, relative to the project.
+const ProjectPath = ".launchpad/config.json"
+
+// Config is the merged configuration.
+type Config struct {
+	// PostProcessors are external commands run on generated files after the
+	// built-in processors, in order. Only the user config may set them.
+	PostProcessors []Command `json:"post_processors,omitempty"`
+	// DisableBuiltins names built-in post-processors to skip.
+	DisableBuiltins []string `json:"disable_builtins,omitempty"`
+	// Placeholders are extra {{key}} substitutions for generated files.
+	Placeholders map[string]string `json:"placeholders,omitempty"`
+	// Decisions adjusts the decision map stacks are recommended from.
+	Decisions Decisions `json:"decisions,omitempty"`
+	// AuditLog is a JSONL file every prompt and response is appended to.
+	// Relative paths are resolved against the project directory. The project
+	// config may only name a file inside the project.
+	AuditLog string `json:"audit_log,omitempty"`
+	// State is where the project's launchpad state is mirrored so it
+	// outlives the machine: s3://bucket/prefix, gs://bucket/prefix, or a
+	// directory. {project} is replaced with the project directory's name.
+	// The project config may only name a directory inside the project.
+	State string `json:"state,omitempty"`
+	// Layout moves generated files to where the org's repositories keep
+	// them, e.g. {".github/instructions/": "docs/ai/", "AGENTS.md":
+	// "AGENT_GUIDE.md"}. Keys ending in / move a whole directory.
+	Layout map[string]string `json:"layout,omitempty"`
+	// Conversation adjusts the advisor's tone during init.
+	Conversation Conversation `json:"conversation,omitempty"`
+	// AllowedRoots are extra paths generated files may be written to, on
+	// top of .github/, AGENTS.md, and the outputs of the selected assets
+	// and targets. A trailing slash allows a whole directory.
+	AllowedRoots []string `json:"allowed_roots,omitempty"`
+}
+
+// Conversation is the advisor's style. Empty fields keep the default.
+type Conversation struct {
+	Verbosity string `json:"verbosity,omitempty"` // concise or chatty
+	Tone      string `json:"tone,omitempty"`      // formal or casual
+	Emoji     *bool  `json:"emoji,omitempty"`
+}
+
+// Decisions holds organization overrides to the decision map.
+type Decisions struct {
+	// Rules set the profiles for a use case, best first. A rule named like a
+	// built-in use case replaces its picks; others are added.
+	Rules []DecisionRule `json:"rules,omitempty"`
+	// Weights scale how strongly each profile is recommended. 1 is neutral
+	// and 0 rules a profile out.
+	Weights map[string]float64 `json:"weights,omitempty"`
+}
+
+// DecisionRule maps a use case to the org's preferred profiles.
+type DecisionRule struct {
+	UseCase  string   `json:"use_case"`
+	Profiles []string `json:"profiles"`
+	Keywords []string `json:"keywords,omitempty"` // for offline recommend
+}
+
+// Command is an external post-processor. It receives the selection and
+// files as JSON on stdin and prints the resulting files as JSON on stdout.
+type Command struct {
+	Name    string   `json:"name"`
+	Command []string `json:"command"`
+}
+
+// UserPath returns the per-user config file location.
+func UserPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "launchpad", "config.json"), nil
+}
+
+// Load reads the user config, then the project config in projectDir, and
+// merges them. Missing files are fine; malformed ones are errors.
+func Load(projectDir string) (*Config, error) {
+	cfg := &Config{}
+	if user, err := UserPath(); err == nil {
+		if err := mergeFile(cfg, user, false); err != nil {
+			return nil, err
+		}
+	}
+	if err := mergeFile(cfg, filepath.Join(projectDir, ProjectPath), true); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// mergeFile layers the file at path over cfg. Lists accumulate so an org
+// processor in the user config still runs in every project; placeholders
+// are overridden key by key. A project file comes with the code, so it
+// can't name commands to run, and the files it points launchpad at must
+// stay inside the project.
+func mergeFile(cfg *Config, path string, project bool) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	var layer Config
+	if err := json.Unmarshal(data, &layer); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	if project && len(layer.PostProcessors) > 0 {
+		return fmt.Errorf("%s: post_processors run commands, so they can only be set in the user config", path)
+	}
+	for _, c := range layer.PostProcessors {
+		if c.Name == "" || len(c.Command) == 0 {
+			return fmt.Errorf("%s: post-processors need a name and a command", path)
+		}
+	}
+	for from, to := range layer.Layout {
+		if err := checkLayout(from, to); err != nil {
+			return fmt.Errorf("%s: layout: %w", path, err)
+		}
+	}
+	if project && layer.AuditLog != "" {
+		if err := checkRelative(layer.AuditLog); err != nil {
+			return fmt.Errorf("%s: audit_log: %w; set it in the user config to log elsewhere", path, err)
+		}
+	}
+	if project && layer.State != "" && !store.IsRemote(layer.State) {
+		if err := checkRelative(layer.State); err != nil {
+			return fmt.Errorf("%s: state: %w, or a bucket; set it in the user config to keep state elsewhere", path, err)
+		}
+	}
+	for _, root := range layer.AllowedRoots {
+		if err := checkRoot(root); err != nil {
+			return fmt.Errorf("%s: allowed_roots: %w", path, err)
+		}
+	}
+	if v := layer.Conversation.Verbosity; v != "" && v != "concise" && v != "chatty" {
+		return fmt.Errorf("%s: conversation verbosity must be \"concise\" or \"chatty\", got %q", path, v)
+	}
+	if v := layer.Conversation.Tone; v != "" && v != "formal" && v != "casual" {
+		return fmt.Errorf("%s: conversation tone must be \"formal\" or \"casual\", got %q", path, v)
+	}
+	cfg.PostProcessors = append(cfg.PostProcessors, layer.PostProcessors...)
+	cfg.DisableBuiltins = append(cfg.DisableBuiltins, layer.DisableBuiltins...)
+	cfg.AllowedRoots = append(cfg.AllowedRoots, layer.AllowedRoots...)
+	for k, v := range layer.Placeholders {
+		if cfg.Placeholders == nil {
+			cfg.Placeholders = make(map[string]string)
+		}
+		cfg.Placeholders[k] = v
+	}
+	// Rules are applied in order, so a project rule for the same use case
+	// wins over the user's.
+	cfg.Decisions.Rules = append(cfg.Decisions.Rules, layer.Decisions.Rules...)
+	for k, v := range layer.Decisions.Weights {
+		if cfg.Decisions.Weights == nil {
+			cfg.Decisions.Weights = make(map[string]float64)
+		}
+		cfg.Decisions.Weights[k] = v
+	}
+	for k, v := range layer.Layout {
+		if cfg.Layout == nil {
+			cfg.Layout = make(map[string]string)
+		}
+		cfg.Layout[k] = v
+	}
+	if layer.Conversation.Verbosity != "" {
+		cfg.Conversation.Verbosity = layer.Conversation.Verbosity
+	}
+	if layer.Conversation.Tone != "" {
+		cfg.Conversation.Tone = layer.Conversation.Tone
+	}
+	if layer.Conversation.Emoji != nil {
+		cfg.Conversation.Emoji = layer.Conversation.Emoji
+	}
+	if layer.AuditLog != "" {
+		cfg.AuditLog = layer.AuditLog
+	}
+	if layer.State != "" {
+		cfg.State = layer.State
+	}
+	return nil
+}
+
+// checkLayout requires both sides of a layout entry to be clean relative
+// paths inside the project, and directories to map to directories.
+func checkLayout(from, to string) error {
+	for _, p := range []string{from, to} {
+		if err := checkRelative(p); err != nil {
+			return err
+		}
+	}
+	if strings.HasSuffix(from, "/") != strings.HasSuffix(to, "/") {
+		return fmt.Errorf("%q -> %q: a directory (ending in /) must map to a directory", from, to)
+	}
+	return nil
+}
+
+// checkRelative requires p to be a clean relative path inside the project,
+// optionally ending in / for a directory.
+func checkRelative(p string) error {
+	trimmed := strings.TrimSuffix(p, "/")
+	if trimmed == "" || path.IsAbs(p) || filepath.IsAbs(p) || path.Clean(trimmed) != trimmed || trimmed == ".." || strings.HasPrefix(trimmed, "../") {
+		return fmt.Errorf("%q must be a relative path inside the project", p)
+	}
+	return nil
+}
+
+// reservedDirs hold git's and launchpad's own state. Generated output must
+// never reach them: a file there could be a git hook or a forged manifest.
+var reservedDirs = []string{".git", ".launchpad"}
+
+// checkRoot requires an allowed root to be inside the project and outside
+// the reserved directories.
+func checkRoot(root string) error {
+	if err := checkRelative(root); err != nil {
+		return err
+	}
+	trimmed := strings.TrimSuffix(root, "/")
+	for _, dir := range reservedDirs {
+		if trimmed == dir || strings.HasPrefix(trimmed, dir+"/") {
+			return fmt.Errorf("%q is inside %s/, which generated files may not write to", root, dir)
+		}
+	}
+	return nil
+}
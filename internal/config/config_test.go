@@ -0,0 +1,254 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad_Merges(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("HOME", home)
+	user, err := UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(t, user, `{"post_processors":[{"name":"org","command":["org-fix"]}],"placeholders":{"org":"Acme","team":"core"}}`)
+	project := t.TempDir()
+	write(t, filepath.Join(project, ProjectPath), `{"disable_builtins":["markdown"],"placeholders":{"team":"web"}}`)
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.PostProcessors) != 1 || cfg.PostProcessors[0].Name != "org" {
+		t.Errorf("post-processors = %+v", cfg.PostProcessors)
+	}
+	if cfg.Placeholders["org"] != "Acme" || cfg.Placeholders["team"] != "web" {
+		t.Errorf("placeholders = %v", cfg.Placeholders)
+	}
+	if len(cfg.DisableBuiltins) != 1 {
+		t.Errorf("disable = %v", cfg.DisableBuiltins)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg, err := Load(t.TempDir())
+	if err != nil || len(cfg.PostProcessors) != 0 {
+		t.Errorf("Load = %+v, %v", cfg, err)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("HOME", home)
+	user, err := UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(t, user, `{"post_processors":[{"name":"x"}]}`)
+	if _, err := Load(t.TempDir()); err == nil {
+		t.Error("expected error for processor without command")
+	}
+}
+
+func TestLoad_ProjectPostProcessorsRejected(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	project := t.TempDir()
+	write(t, filepath.Join(project, ProjectPath), `{"post_processors":[{"name":"lint","command":["lint","--fix"]}]}`)
+	if _, err := Load(project); err == nil || !strings.Contains(err.Error(), "user config") {
+		t.Errorf("Load = %v, want a cloned repo's commands refused", err)
+	}
+}
+
+func TestLoad_Decisions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("HOME", home)
+	user, err := UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(t, user, `{"decisions":{"rules":[{"use_case":"enterprise API/C#","profiles":["java-spring"]}],"weights":{"laravel":0,"go-service":1.5}}}`)
+	project := t.TempDir()
+	write(t, filepath.Join(project, ProjectPath), `{"decisions":{"rules":[{"use_case":"enterprise API/C#","profiles":["dotnet-api"]}],"weights":{"go-service":1}}}`)
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := cfg.Decisions.Rules
+	if len(rules) != 2 || rules[1].Profiles[0] != "dotnet-api" {
+		t.Errorf("rules = %+v, want the project rule last", rules)
+	}
+	if w := cfg.Decisions.Weights; w["laravel"] != 0 || w["go-service"] != 1 || len(w) != 2 {
+		t.Errorf("weights = %v", w)
+	}
+}
+
+func TestLoad_Layout(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("HOME", home)
+	user, err := UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(t, user, `{"layout":{".github/instructions/":"docs/ai/","AGENTS.md":"AGENT_GUIDE.md"}}`)
+	project := t.TempDir()
+	write(t, filepath.Join(project, ProjectPath), `{"layout":{"AGENTS.md":"docs/AGENTS.md"}}`)
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Layout[".github/instructions/"] != "docs/ai/" || cfg.Layout["AGENTS.md"] != "docs/AGENTS.md" {
+		t.Errorf("layout = %v", cfg.Layout)
+	}
+
+	for _, bad := range []string{
+		`{"layout":{"AGENTS.md":"../AGENTS.md"}}`,
+		`{"layout":{"AGENTS.md":"/etc/AGENTS.md"}}`,
+		`{"layout":{".github/instructions/":"docs/ai"}}`,
+	} {
+		write(t, filepath.Join(project, ProjectPath), bad)
+		if _, err := Load(project); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
+
+func TestLoad_AllowedRoots(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("HOME", home)
+	user, err := UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(t, user, `{"allowed_roots":["docs/ai/"]}`)
+	project := t.TempDir()
+	write(t, filepath.Join(project, ProjectPath), `{"allowed_roots":["Makefile"]}`)
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.AllowedRoots) != 2 || cfg.AllowedRoots[0] != "docs/ai/" || cfg.AllowedRoots[1] != "Makefile" {
+		t.Errorf("allowed roots = %v", cfg.AllowedRoots)
+	}
+
+	for _, bad := range []string{
+		`{"allowed_roots":["../outside/"]}`,
+		`{"allowed_roots":["/etc/"]}`,
+		`{"allowed_roots":[""]}`,
+		`{"allowed_roots":[".git/"]}`,
+		`{"allowed_roots":[".git/hooks/"]}`,
+		`{"allowed_roots":[".git/hooks/pre-commit"]}`,
+		`{"allowed_roots":[".launchpad/"]}`,
+		`{"allowed_roots":[".launchpad/manifest.json"]}`,
+	} {
+		write(t, filepath.Join(project, ProjectPath), bad)
+		if _, err := Load(project); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
+
+func TestLoad_AuditLogOutsideProject(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("HOME", home)
+	user, err := UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := t.TempDir()
+	for _, bad := range []string{"/var/log/launchpad.jsonl", "../audit.jsonl", "logs/../../audit.jsonl"} {
+		write(t, filepath.Join(project, ProjectPath), `{"audit_log":"`+bad+`"}`)
+		if _, err := Load(project); err == nil {
+			t.Errorf("project audit_log %q: expected an error", bad)
+		}
+	}
+
+	write(t, filepath.Join(project, ProjectPath), `{"audit_log":"logs/audit.jsonl"}`)
+	if cfg, err := Load(project); err != nil || cfg.AuditLog != "logs/audit.jsonl" {
+		t.Errorf("project audit_log inside the project = %+v, %v", cfg, err)
+	}
+
+	write(t, filepath.Join(project, ProjectPath), `{}`)
+	write(t, user, `{"audit_log":"/var/log/launchpad.jsonl"}`)
+	if cfg, err := Load(project); err != nil || cfg.AuditLog != "/var/log/launchpad.jsonl" {
+		t.Errorf("user audit_log = %+v, %v", cfg, err)
+	}
+}
+
+func TestLoad_StateOutsideProject(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("HOME", home)
+	user, err := UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := t.TempDir()
+	for _, bad := range []string{"/srv/launchpad/{project}", "../state", "file:///srv/state"} {
+		write(t, filepath.Join(project, ProjectPath), `{"state":"`+bad+`"}`)
+		if _, err := Load(project); err == nil {
+			t.Errorf("project state %q: expected an error", bad)
+		}
+	}
+	for _, good := range []string{"s3://acme/{project}", "gs://acme/state", ".state"} {
+		write(t, filepath.Join(project, ProjectPath), `{"state":"`+good+`"}`)
+		if cfg, err := Load(project); err != nil || cfg.State != good {
+			t.Errorf("project state %q = %+v, %v", good, cfg, err)
+		}
+	}
+
+	write(t, filepath.Join(project, ProjectPath), `{}`)
+	write(t, user, `{"state":"/srv/launchpad/{project}"}`)
+	if cfg, err := Load(project); err != nil || cfg.State != "/srv/launchpad/{project}" {
+		t.Errorf("user state = %+v, %v", cfg, err)
+	}
+}
+
+func TestLoad_Conversation(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("HOME", home)
+	user, err := UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(t, user, `{"conversation":{"verbosity":"concise","emoji":false}}`)
+	project := t.TempDir()
+	write(t, filepath.Join(project, ProjectPath), `{"conversation":{"tone":"formal"}}`)
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cfg.Conversation
+	if c.Verbosity != "concise" || c.Tone != "formal" || c.Emoji == nil || *c.Emoji {
+		t.Errorf("conversation = %+v", c)
+	}
+
+	write(t, filepath.Join(project, ProjectPath), `{"conversation":{"verbosity":"loud"}}`)
+	if _, err := Load(project); err == nil {
+		t.Error("expected an error for an unknown verbosity")
+	}
+}
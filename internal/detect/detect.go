@@ -0,0 +1,177 @@
+// Package detect infers the stack of existing code from the manifest files a
+// framework leaves behind (package.json, mix.exs, go.mod, ...).
+package detect
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// WorkspaceDirs are the conventional monorepo folders whose immediate
+// children are treated as packages.
+var WorkspaceDirs = []string{"apps", "packages", "services", "libs"}
+
+// Package is one workspace member with a recognized stack.
+type Package struct {
+	Path      string // slash-separated, relative to the repository root
+	ProfileID string
+	Marker    string // the file that identified the stack
+}
+
+// rule maps a marker file to a profile. contains, when set, must appear in
+// the marker's content; it separates e.g. Rails from other Ruby apps.
+type rule struct {
+	marker    string
+	contains  string
+	profileID string
+}
+
+// rules are checked in order; the first match wins. More specific rules come
+// before general ones sharing a marker file.
+var rules = []rule{
+	{marker: "mix.exs", contains: ":phoenix", profileID: "elixir-phoenix"},
+	{marker: "Gemfile", contains: "rails", profileID: "ruby-rails"},
+	{marker: "composer.json", contains: "laravel/framework", profileID: "laravel"},
+	{marker: "deno.json", contains: "fresh", profileID: "deno-fresh"},
+	{marker: "manifest.json", contains: "\"manifest_version\"", profileID: "browser-extension"}, // a web app manifest has none
+	{marker: "go.mod", profileID: "go-service"},
+	{marker: "Cargo.toml", contains: "axum", profileID: "rust-axum"},
+	{marker: "Package.swift", contains: "vapor", profileID: "swift-vapor"},
+	{marker: "project.yml", contains: "platform: ios", profileID: "ios-swiftui"},
+	{marker: "CMakeLists.txt", contains: "cxx", profileID: "cpp-service"},
+	{marker: "pubspec.yaml", contains: "flutter", profileID: "dart-flutter"},
+	{marker: "build.gradle.kts", contains: "android", profileID: "android-compose"},
+	{marker: "build.gradle", contains: "com.android", profileID: "android-compose"},
+	{marker: "project.godot", profileID: "godot"}, // before the .csproj check a C# Godot project also has
+	{marker: "dbt_project.yml", profileID: "data-dbt"},
+	{marker: "pyproject.toml", contains: "dagster", profileID: "data-dbt"},
+	{marker: "requirements.txt", contains: "apache-airflow", profileID: "data-dbt"},
+	{marker: "pyproject.toml", contains: "fastapi", profileID: "python-fastapi"},
+	{marker: "pyproject.toml", contains: "django", profileID: "python-django"},
+	{marker: "requirements.txt", contains: "fastapi", profileID: "python-fastapi"},
+	{marker: "requirements.txt", contains: "django", profileID: "python-django"},
+	{marker: "pyproject.toml", contains: "torch", profileID: "python-ml"}, // after the web frameworks: a service may serve a model
+	{marker: "pyproject.toml", contains: "scikit-learn", profileID: "python-ml"},
+	{marker: "requirements.txt", contains: "torch", profileID: "python-ml"},
+	{marker: "requirements.txt", contains: "scikit-learn", profileID: "python-ml"},
+	{marker: "pom.xml", contains: "spring", profileID: "java-spring"},
+	{marker: "build.gradle", contains: "spring", profileID: "java-spring"},
+	{marker: "build.gradle.kts", contains: "spring", profileID: "java-spring"},
+	{marker: ".terraform.lock.hcl", profileID: "platform-infra"}, // last: app repos often carry some Terraform
+}
+
+// npmProfiles maps a package.json dependency to a profile, most specific first.
+var npmProfiles = []struct{ dep, profileID string }{
+	{"electron", "electron"}, // before the web frameworks an Electron renderer may use
+	{"wxt", "browser-extension"},
+	{"plasmo", "browser-extension"},
+	{"@crxjs/vite-plugin", "browser-extension"},
+	{"expo", "react-native-expo"},
+	{"@sveltejs/kit", "typescript-sveltekit"},
+	{"next", "typescript-nextjs"},
+	{"nuxt", "typescript-nuxt"},
+	{"fastify", "typescript-fastify"},
+	{"hono", "bun-hono"},
+}
+
+// Stack returns the profile ID for the code in dir and the marker file that
+// identified it, or empty strings when nothing matches.
+func Stack(dir string) (profileID, marker string) {
+	if id := npmStack(filepath.Join(dir, "package.json")); id != "" {
+		return id, "package.json"
+	}
+	for _, r := range rules {
+		data, err := os.ReadFile(filepath.Join(dir, r.marker))
+		if err != nil {
+			continue
+		}
+		if r.contains == "" || strings.Contains(strings.ToLower(string(data)), r.contains) {
+			return r.profileID, r.marker
+		}
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*.csproj")); len(matches) > 0 {
+		return "dotnet-api", filepath.Base(matches[0])
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*.xcodeproj")); len(matches) > 0 {
+		return "ios-swiftui", filepath.Base(matches[0])
+	}
+	return "", ""
+}
+
+func npmStack(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if json.Unmarshal(data, &pkg) != nil {
+		return ""
+	}
+	for _, np := range npmProfiles {
+		if _, ok := pkg.Dependencies[np.dep]; ok {
+			return np.profileID
+		}
+		if _, ok := pkg.DevDependencies[np.dep]; ok {
+			return np.profileID
+		}
+	}
+	return ""
+}
+
+// Workspace lists the packages under root's workspace directories whose
+// stack can be inferred, sorted by path. Members with no recognizable stack
+// are skipped; a repository without workspace directories yields nil.
+func Workspace(root string) ([]Package, error) {
+	var pkgs []Package
+	for _, ws := range WorkspaceDirs {
+		entries, err := os.ReadDir(filepath.Join(root, ws))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			id, marker := Stack(filepath.Join(root, ws, e.Name()))
+			if id == "" {
+				continue
+			}
+			pkgs = append(pkgs, Package{Path: ws + "/" + e.Name(), ProfileID: id, Marker: marker})
+		}
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Path < pkgs[j].Path })
+	return pkgs, nil
+}
+
+// RepoRoot walks up from dir to the nearest directory containing .git and
+// returns it along with dir's slash-separated path relative to it. rel is
+// "." when dir is the root; ok is false outside a repository.
+func RepoRoot(dir string) (root, rel string, ok bool) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", false
+	}
+	for cur := abs; ; {
+		if _, err := os.Stat(filepath.Join(cur, ".git")); err == nil {
+			r, err := filepath.Rel(cur, abs)
+			if err != nil {
+				return "", "", false
+			}
+			return cur, filepath.ToSlash(r), true
+		}
+		parent := filepath.Dir(cur)
+		if parent == cur {
+			return "", "", false
+		}
+		cur = parent
+	}
+}
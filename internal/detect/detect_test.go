@@ -0,0 +1,164 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStack(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"sveltekit", map[string]string{"package.json": `{"devDependencies":{"@sveltejs/kit":"^2"}}`}, "typescript-sveltekit"},
+		{"nextjs", map[string]string{"package.json": `{"dependencies":{"next":"14","react":"18"}}`}, "typescript-nextjs"},
+		{"nuxt", map[string]string{"package.json": `{"devDependencies":{"nuxt":"^3.12"}}`}, "typescript-nuxt"},
+		{"plain node", map[string]string{"package.json": `{"dependencies":{"lodash":"4"}}`}, ""},
+		{"phoenix", map[string]string{"mix.exs": `{:phoenix, "~> 1.7"}`}, "elixir-phoenix"},
+		{"go", map[string]string{"go.mod": "module example.com/api\n"}, "go-service"},
+		{"django", map[string]string{"pyproject.toml": "dependencies = [\"Django>=5\"]"}, "python-django"},
+		{"vapor", map[string]string{"Package.swift": `.package(url: "https://github.com/vapor/vapor.git", from: "4.0.0")`}, "swift-vapor"},
+		{"android", map[string]string{"build.gradle.kts": "plugins {\n  alias(libs.plugins.android.application) apply false\n}"}, "android-compose"},
+		{"spring gradle", map[string]string{"build.gradle.kts": `plugins { id("org.springframework.boot") version "3.3.0" }`}, "java-spring"},
+		{"xcodegen", map[string]string{"project.yml": "targets:\n  App:\n    type: application\n    platform: iOS\n"}, "ios-swiftui"},
+		{"xcodeproj", map[string]string{"Notes.xcodeproj/project.pbxproj": "// !$*UTF8*$!"}, "ios-swiftui"},
+		{"electron", map[string]string{"package.json": `{"dependencies":{"react":"18"},"devDependencies":{"electron":"31.0.0","next":"14"}}`}, "electron"},
+		{"expo", map[string]string{"package.json": `{"dependencies":{"expo":"~51.0.0","react-native":"0.74.1"}}`}, "react-native-expo"},
+		{"fresh", map[string]string{"deno.json": `{"imports": {"$fresh/": "https://deno.land/x/fresh@1.6.8/"}}`}, "deno-fresh"},
+		{"hono", map[string]string{"package.json": `{"dependencies":{"hono":"^4.4.0"},"devDependencies":{"@types/bun":"latest"}}`}, "bun-hono"},
+		{"cmake", map[string]string{"CMakeLists.txt": "cmake_minimum_required(VERSION 3.25)\nproject(ledger LANGUAGES CXX)\n"}, "cpp-service"},
+		{"terraform", map[string]string{".terraform.lock.hcl": "provider \"registry.terraform.io/hashicorp/aws\" {}\n"}, "platform-infra"},
+		{"go with terraform", map[string]string{"go.mod": "module x", ".terraform.lock.hcl": ""}, "go-service"},
+		{"wxt", map[string]string{"package.json": `{"devDependencies":{"wxt":"^0.19.0","vue":"^3.5.0"}}`}, "browser-extension"},
+		{"mv3 manifest", map[string]string{"manifest.json": `{"manifest_version": 3, "name": "Tab Saver"}`}, "browser-extension"},
+		{"godot", map[string]string{"project.godot": "config_version=5\n\n[application]\nconfig/name=\"Slime Quest\"\n"}, "godot"},
+		{"godot csharp", map[string]string{"project.godot": "config_version=5\n", "SlimeQuest.csproj": "<Project Sdk=\"Godot.NET.Sdk/4.3.0\"/>"}, "godot"},
+		{"pytorch", map[string]string{"pyproject.toml": "[project]\ndependencies = [\"torch>=2.4\", \"numpy\"]\n"}, "python-ml"},
+		{"model serving", map[string]string{"requirements.txt": "fastapi\ntorch\n"}, "python-fastapi"},
+		{"dbt", map[string]string{"dbt_project.yml": "name: warehouse\nprofile: warehouse\n"}, "data-dbt"},
+		{"dagster", map[string]string{"pyproject.toml": "[project]\ndependencies = [\"dagster\", \"dagster-dbt\"]\n"}, "data-dbt"},
+		{"dotnet", map[string]string{"Api.csproj": "<Project/>"}, "dotnet-api"},
+		{"empty", map[string]string{"README.md": "hi"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			if got, _ := Stack(dir); got != tt.want {
+				t.Errorf("Stack() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"apps/web/package.json":      `{"devDependencies":{"@sveltejs/kit":"^2"}}`,
+		"services/api/go.mod":        "module example.com/api\n",
+		"packages/ui/README.md":      "no stack here",
+		"packages/.cache/go.mod":     "module hidden\n",
+		"tools/scripts/package.json": `{"dependencies":{"next":"14"}}`,
+	})
+
+	pkgs, err := Workspace(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Package{
+		{Path: "apps/web", ProfileID: "typescript-sveltekit", Marker: "package.json"},
+		{Path: "services/api", ProfileID: "go-service", Marker: "go.mod"},
+	}
+	if len(pkgs) != len(want) {
+		t.Fatalf("Workspace() = %+v, want %+v", pkgs, want)
+	}
+	for i := range want {
+		if pkgs[i] != want[i] {
+			t.Errorf("pkgs[%d] = %+v, want %+v", i, pkgs[i], want[i])
+		}
+	}
+}
+
+func TestRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	got, rel, ok := RepoRoot(nested)
+	if !ok || got != root || rel != "services/api" {
+		t.Errorf("RepoRoot(nested) = %s, %s, %v", got, rel, ok)
+	}
+	// Targets that don't exist yet still resolve against their parents.
+	if _, rel, ok := RepoRoot(filepath.Join(root, "new-app")); !ok || rel != "new-app" {
+		t.Errorf("RepoRoot(new dir) = %s, %v", rel, ok)
+	}
+	if _, rel, _ := RepoRoot(root); rel != "." {
+		t.Errorf("RepoRoot(root) rel = %s", rel)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	healthy := t.TempDir()
+	writeFiles(t, healthy, map[string]string{
+		"README.md":                    strings.Repeat("Ledger tracks shared expenses between friends. ", 6),
+		"pyproject.toml":               "[project]\nname = \"ledger\"\n\n[tool.ruff]\nline-length = 100\n",
+		".github/workflows/ci.yml":     "on: push\n",
+		"src/ledger/api.py":            "",
+		"tests/test_api.py":            "",
+		"node_modules/x/index.test.js": "",
+	})
+	for _, c := range Health(healthy) {
+		if !c.OK() {
+			t.Errorf("%s: missing in a healthy project", c.Name)
+		}
+	}
+
+	bare := t.TempDir()
+	writeFiles(t, bare, map[string]string{
+		"README.md":                    "# app\n",
+		"go.mod":                       "module app\n",
+		"main.go":                      "package main\n",
+		"node_modules/x/index.test.js": "",
+	})
+	got := map[string]Check{}
+	for _, c := range Health(bare) {
+		got[c.Name] = c
+	}
+	for name, suggest := range map[string]string{"tests": "asset.testing.pragmatic", "lint": "asset.lint.strict", "ci": "addon.ci-cd", "docs": ""} {
+		if c := got[name]; c.OK() || c.Suggest != suggest {
+			t.Errorf("%s = %+v, want missing with suggestion %q", name, c, suggest)
+		}
+	}
+}
+
+func TestIsTestFile(t *testing.T) {
+	for name, want := range map[string]bool{
+		"server_test.go": true, "test_models.py": true, "user_spec.rb": true, "Button.test.tsx": true,
+		"api.spec.ts": true, "OrderServiceTest.java": true, "LedgerTests.swift": true, "page_test.exs": true,
+		"testdata.go": false, "contest.py": false, "spec.md": false, "latest.ts": false,
+	} {
+		if got := isTestFile(name); got != want {
+			t.Errorf("isTestFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
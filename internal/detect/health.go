@@ -0,0 +1,143 @@
+package detect
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Check is one line of an existing project's health scorecard.
+type Check struct {
+	Name    string // tests, lint, ci, docs
+	Found   string // slash-separated path that satisfied the check; "" when missing
+	Suggest string // catalog ID that fills the gap, or "" when none does
+}
+
+// OK reports whether the project passed the check.
+func (c Check) OK() bool { return c.Found != "" }
+
+// lintConfigs are linter and formatter configs, looked for at the root.
+var lintConfigs = []string{
+	".eslintrc", ".eslintrc.js", ".eslintrc.cjs", ".eslintrc.json", ".eslintrc.yml",
+	"eslint.config.js", "eslint.config.mjs", "eslint.config.ts", "biome.json", "biome.jsonc",
+	".golangci.yml", ".golangci.yaml", "ruff.toml", ".ruff.toml", ".flake8", ".pylintrc",
+	".rubocop.yml", ".credo.exs", ".swiftlint.yml", "analysis_options.yaml",
+	"clippy.toml", ".clippy.toml", "phpstan.neon", "pint.json", ".clang-tidy", "detekt.yml",
+}
+
+// ciConfigs are the files and directories CI services read.
+var ciConfigs = []string{
+	".github/workflows", ".gitlab-ci.yml", ".circleci/config.yml", "azure-pipelines.yml",
+	"bitbucket-pipelines.yml", "Jenkinsfile", ".buildkite", ".woodpecker.yml",
+}
+
+// skipDirs are never searched for tests: dependencies and build output.
+var skipDirs = map[string]bool{
+	".git": true, ".launchpad": true, "node_modules": true, "vendor": true, "deps": true,
+	"_build": true, "target": true, "dist": true, "build": true, ".venv": true, "venv": true,
+	"Pods": true, ".dart_tool": true, ".next": true, ".nuxt": true, ".svelte-kit": true,
+}
+
+// maxScanned bounds how many files the test search looks at, so huge
+// repositories don't stall init.
+const maxScanned = 20000
+
+// Health scores the project at root on whether it has tests, a lint
+// config, CI, and documentation. Missing items carry the catalog ID that
+// would add guidance for them.
+func Health(root string) []Check {
+	return []Check{
+		{Name: "tests", Found: findTests(root), Suggest: "asset.testing.pragmatic"},
+		{Name: "lint", Found: findLint(root), Suggest: "asset.lint.strict"},
+		{Name: "ci", Found: firstExisting(root, ciConfigs), Suggest: "addon.ci-cd"},
+		{Name: "docs", Found: findDocs(root)},
+	}
+}
+
+func firstExisting(root string, paths []string) string {
+	for _, p := range paths {
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(p))); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+func findLint(root string) string {
+	if p := firstExisting(root, lintConfigs); p != "" {
+		return p
+	}
+	// Python and Rust keep lint settings in their main manifest.
+	for _, m := range []struct{ file, section string }{
+		{"pyproject.toml", "[tool.ruff"}, {"pyproject.toml", "[tool.pylint"}, {"Cargo.toml", "[lints"},
+	} {
+		if data, err := os.ReadFile(filepath.Join(root, m.file)); err == nil && strings.Contains(string(data), m.section) {
+			return m.file
+		}
+	}
+	return ""
+}
+
+// findDocs accepts a README with more than a title, or a docs/ directory.
+func findDocs(root string) string {
+	for _, name := range []string{"README.md", "README.rst", "README.txt", "README"} {
+		if data, err := os.ReadFile(filepath.Join(root, name)); err == nil && len(strings.Fields(string(data))) >= 30 {
+			return name
+		}
+	}
+	if info, err := os.Stat(filepath.Join(root, "docs")); err == nil && info.IsDir() {
+		return "docs"
+	}
+	return ""
+}
+
+// errStop ends a walk early.
+var errStop = errors.New("stop walking")
+
+func findTests(root string) string {
+	found, scanned := "", 0
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if scanned++; scanned > maxScanned {
+			return errStop
+		}
+		// Rust integration tests are plain files under tests/.
+		if isTestFile(d.Name()) || filepath.Ext(path) == ".rs" && filepath.Base(filepath.Dir(path)) == "tests" {
+			rel, _ := filepath.Rel(root, path)
+			found = filepath.ToSlash(rel)
+			return errStop
+		}
+		return nil
+	})
+	return found
+}
+
+// isTestFile recognizes test files by the naming conventions of the
+// supported stacks.
+func isTestFile(name string) bool {
+	base, ext := strings.TrimSuffix(name, filepath.Ext(name)), filepath.Ext(name)
+	switch {
+	case strings.HasSuffix(base, "_test") && (ext == ".go" || ext == ".py" || ext == ".exs" || ext == ".dart"):
+		return true
+	case strings.HasPrefix(base, "test_") && ext == ".py":
+		return true
+	case strings.HasSuffix(base, "_spec") && ext == ".rb":
+		return true
+	case strings.HasSuffix(base, ".test") || strings.HasSuffix(base, ".spec"):
+		return true // foo.test.ts, foo.spec.js
+	case (strings.HasSuffix(base, "Test") || strings.HasSuffix(base, "Tests")) &&
+		(ext == ".java" || ext == ".kt" || ext == ".cs" || ext == ".swift" || ext == ".php"):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+)
+
+// Markers delimiting a hand-written region that regeneration must carry over
+// untouched. Each sits on a line of its own.
+const (
+	KeepOpen  = "<!-- launchpad:keep -->"
+	KeepClose = "<!-- /launchpad:keep -->"
+)
+
+// keptBlock is one keep region of a file, markers included.
+type keptBlock struct {
+	lines  []string
+	anchor string // nearest non-blank line above the block outside any block; "" at the top
+}
+
+// splitKept separates the keep regions of lines from the rest. An opening
+// marker with no closing marker after it is left as ordinary text.
+func splitKept(lines []string) (blocks []keptBlock, rest []string) {
+	for i := 0; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != KeepOpen {
+			rest = append(rest, lines[i])
+			continue
+		}
+		end := -1
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == KeepClose {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			rest = append(rest, lines[i])
+			continue
+		}
+		anchor := ""
+		for k := len(rest) - 1; k >= 0; k-- {
+			if strings.TrimSpace(rest[k]) != "" {
+				anchor = rest[k]
+				break
+			}
+		}
+		blocks = append(blocks, keptBlock{lines: lines[i : end+1], anchor: anchor})
+		i = end
+	}
+	return blocks, rest
+}
+
+// Keep carries the keep regions of local into generated. Any keep regions in
+// generated are dropped, since the copy on disk is authoritative. Each region
+// is placed after the line it followed in local, matched in order; a region
+// whose anchor line is gone is appended at the end so nothing hand-written is
+// lost. generated is returned unchanged when local has no keep regions.
+func Keep(local, generated string) string {
+	blocks, _ := splitKept(splitLines(local))
+	if len(blocks) == 0 {
+		return generated
+	}
+	_, gen := splitKept(splitLines(generated))
+	for len(gen) > 0 && strings.TrimSpace(gen[len(gen)-1]) == "" {
+		gen = gen[:len(gen)-1]
+	}
+
+	// Anchors are searched in order, so two regions that followed the same
+	// line stay together, in their original order.
+	at := make([]int, len(blocks))
+	from := 0
+	for i, b := range blocks {
+		at[i] = len(gen) - 1
+		if b.anchor == "" {
+			at[i] = -1
+			continue
+		}
+		for j := from; j < len(gen); j++ {
+			if strings.TrimSpace(gen[j]) == strings.TrimSpace(b.anchor) {
+				at[i], from = j, j
+				break
+			}
+		}
+	}
+	order := make([]int, len(blocks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return at[order[a]] < at[order[b]] })
+
+	var out []string
+	gap := false // a region was just emitted; separate it from what follows
+	emit := func(b keptBlock) {
+		if len(out) > 0 && strings.TrimSpace(out[len(out)-1]) != "" {
+			out = append(out, "")
+		}
+		out = append(out, b.lines...)
+		gap = true
+	}
+	next := 0
+	for next < len(order) && at[order[next]] < 0 {
+		emit(blocks[order[next]])
+		next++
+	}
+	for i, line := range gen {
+		if gap && strings.TrimSpace(line) != "" {
+			out = append(out, "")
+		}
+		gap = false
+		out = append(out, line)
+		for next < len(order) && at[order[next]] == i {
+			emit(blocks[order[next]])
+			next++
+		}
+	}
+	for ; next < len(order); next++ {
+		emit(blocks[order[next]])
+	}
+
+	merged := strings.Join(out, "\n")
+	if strings.HasSuffix(generated, "\n") || generated == "" {
+		merged += "\n"
+	}
+	return merged
+}
+
+// EqualOutsideKept reports whether a and b differ only inside keep regions.
+// Blank lines are ignored, because placing a region adds spacing around it.
+func EqualOutsideKept(a, b string) bool {
+	_, ra := splitKept(splitLines(a))
+	_, rb := splitKept(splitLines(b))
+	ra, rb = nonBlank(ra), nonBlank(rb)
+	if len(ra) != len(rb) {
+		return false
+	}
+	for i := range ra {
+		if ra[i] != rb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// nonBlank returns the lines of lines that aren't blank.
+func nonBlank(lines []string) []string {
+	var out []string
+	for _, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
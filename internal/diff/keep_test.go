@@ -0,0 +1,71 @@
+package diff
+
+import "testing"
+
+func TestKeep(t *testing.T) {
+	block := "<!-- launchpad:keep -->\n- ours\n<!-- /launchpad:keep -->\n"
+	tests := []struct {
+		name             string
+		local, generated string
+		want             string
+	}{
+		{
+			name:      "no keep regions",
+			local:     "# Rules\n\n- edited\n",
+			generated: "# Rules\n\n- one\n",
+			want:      "# Rules\n\n- one\n",
+		},
+		{
+			name:      "region follows its anchor",
+			local:     "# Rules\n\n- one\n\n" + block + "\n## More\n",
+			generated: "# Rules\n\n- one\n- two\n\n## More\n\n- three\n",
+			want:      "# Rules\n\n- one\n\n" + block + "\n- two\n\n## More\n\n- three\n",
+		},
+		{
+			name:      "missing anchor appends at the end",
+			local:     "# Rules\n\n- gone\n\n" + block,
+			generated: "# Rules\n\n- one\n",
+			want:      "# Rules\n\n- one\n\n" + block,
+		},
+		{
+			name:      "region at the top",
+			local:     block + "\n# Rules\n",
+			generated: "# Rules\n\n- one\n",
+			want:      block + "\n# Rules\n\n- one\n",
+		},
+		{
+			name:      "generated regions are replaced",
+			local:     "# Rules\n\n" + block,
+			generated: "# Rules\n\n<!-- launchpad:keep -->\n- theirs\n<!-- /launchpad:keep -->\n",
+			want:      "# Rules\n\n" + block,
+		},
+		{
+			name:      "unterminated marker is ordinary text",
+			local:     "# Rules\n\n<!-- launchpad:keep -->\n- ours\n",
+			generated: "# Rules\n\n- one\n",
+			want:      "# Rules\n\n- one\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Keep(tt.local, tt.generated)
+			if got != tt.want {
+				t.Errorf("Keep =\n%s\nwant\n%s", got, tt.want)
+			}
+			// Carrying the regions over again must not move them.
+			if again := Keep(got, tt.generated); again != got {
+				t.Errorf("Keep is not stable:\n%s\nthen\n%s", got, again)
+			}
+		})
+	}
+}
+
+func TestEqualOutsideKept(t *testing.T) {
+	base := "# Rules\n\n- one\n"
+	if !EqualOutsideKept(base, "# Rules\n\n<!-- launchpad:keep -->\n- ours\n<!-- /launchpad:keep -->\n\n- one\n") {
+		t.Error("adding a keep region should not count as an edit")
+	}
+	if EqualOutsideKept(base, "# Rules\n\n- one (edited)\n") {
+		t.Error("an edit outside keep regions should count")
+	}
+}
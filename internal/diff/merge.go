@@ -0,0 +1,63 @@
+package diff
+
+import "strings"
+
+// Conflict marker labels written into merged output.
+const (
+	markerLocal  = "<<<<<<< your edits"
+	markerSep    = "======="
+	markerRemote = ">>>>>>> regenerated"
+)
+
+// Merge3 performs a line-based three-way merge. base is the content Launchpad
+// originally generated, local is the user's edited copy, and remote is the
+// newly generated content. Changes made on only one side are taken as-is;
+// overlapping changes are emitted between conflict markers. It returns the
+// merged text and the number of conflicts.
+func Merge3(base, local, remote string) (string, int) {
+	b, l, r := splitLines(base), splitLines(local), splitLines(remote)
+	lm, rm := matchLines(b, l), matchLines(b, r)
+
+	var out []string
+	conflicts := 0
+	i, j, k := 0, 0, 0
+	for i < len(b) || j < len(l) || k < len(r) {
+		if i < len(b) && lm[i] == j && rm[i] == k {
+			out = append(out, b[i])
+			i, j, k = i+1, j+1, k+1
+			continue
+		}
+
+		// Find the next base line both sides still share; everything before
+		// it is an unstable chunk.
+		next := i
+		for next < len(b) && (lm[next] < j || rm[next] < k) {
+			next++
+		}
+		lEnd, rEnd := len(l), len(r)
+		if next < len(b) {
+			lEnd, rEnd = lm[next], rm[next]
+		}
+		bc, lc, rc := b[i:next], l[j:lEnd], r[k:rEnd]
+
+		switch {
+		case equalLines(lc, bc):
+			out = append(out, rc...)
+		case equalLines(rc, bc), equalLines(lc, rc):
+			out = append(out, lc...)
+		default:
+			conflicts++
+			out = append(out, markerLocal)
+			out = append(out, lc...)
+			out = append(out, markerSep)
+			out = append(out, rc...)
+			out = append(out, markerRemote)
+		}
+		i, j, k = next, lEnd, rEnd
+	}
+
+	if len(out) == 0 {
+		return "", conflicts
+	}
+	return strings.Join(out, "\n") + "\n", conflicts
+}
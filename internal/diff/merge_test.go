@@ -0,0 +1,76 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMerge3(t *testing.T) {
+	base := "# Rules\n\n- one\n- two\n- three\n"
+	tests := []struct {
+		name          string
+		local, remote string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:   "no changes",
+			local:  base,
+			remote: base,
+			want:   base,
+		},
+		{
+			name:   "only user edited",
+			local:  "# Rules\n\n- one\n- two (ours)\n- three\n",
+			remote: base,
+			want:   "# Rules\n\n- one\n- two (ours)\n- three\n",
+		},
+		{
+			name:   "only regenerated",
+			local:  base,
+			remote: "# Rules\n\n- one\n- two\n- three\n- four\n",
+			want:   "# Rules\n\n- one\n- two\n- three\n- four\n",
+		},
+		{
+			name:   "non-overlapping edits on both sides",
+			local:  "# Rules\n\n- zero\n- one\n- two\n- three\n",
+			remote: "# Rules\n\n- one\n- two\n- three\n- four\n",
+			want:   "# Rules\n\n- zero\n- one\n- two\n- three\n- four\n",
+		},
+		{
+			name:          "overlapping edits conflict",
+			local:         "# Rules\n\n- one\n- two (ours)\n- three\n",
+			remote:        "# Rules\n\n- one\n- two (theirs)\n- three\n",
+			want:          "# Rules\n\n- one\n<<<<<<< your edits\n- two (ours)\n=======\n- two (theirs)\n>>>>>>> regenerated\n- three\n",
+			wantConflicts: 1,
+		},
+		{
+			name:   "identical edits on both sides",
+			local:  "# Rules\n\n- one\n- 2\n- three\n",
+			remote: "# Rules\n\n- one\n- 2\n- three\n",
+			want:   "# Rules\n\n- one\n- 2\n- three\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := Merge3(base, tt.local, tt.remote)
+			if got != tt.want {
+				t.Errorf("merged output mismatch\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if conflicts != tt.wantConflicts {
+				t.Errorf("conflicts = %d, want %d", conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestMerge3_EmptyBase(t *testing.T) {
+	got, conflicts := Merge3("", "mine\n", "theirs\n")
+	if conflicts != 1 {
+		t.Fatalf("conflicts = %d, want 1", conflicts)
+	}
+	if !strings.Contains(got, "mine") || !strings.Contains(got, "theirs") {
+		t.Errorf("expected both sides in output, got %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is how many unchanged lines surround each hunk.
+const contextLines = 3
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	text string
+	a, b int // line index in a and b (for equal/delete use a, insert uses b)
+}
+
+// script returns the line edit script turning a into b.
+func script(a, b []string) []op {
+	match := matchLines(a, b)
+	var ops []op
+	j := 0
+	for i, m := range match {
+		if m == -1 {
+			ops = append(ops, op{kind: opDelete, text: a[i], a: i, b: j})
+			continue
+		}
+		for ; j < m; j++ {
+			ops = append(ops, op{kind: opInsert, text: b[j], a: i, b: j})
+		}
+		ops = append(ops, op{kind: opEqual, text: a[i], a: i, b: j})
+		j++
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{kind: opInsert, text: b[j], a: len(a), b: j})
+	}
+	return ops
+}
+
+// Unified renders a unified diff from a to b with the given file labels.
+// It returns an empty string when the inputs are identical.
+func Unified(a, b, fromLabel, toLabel string) string {
+	ops := script(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	for start := 0; start < len(ops); {
+		// Skip to the next change.
+		for start < len(ops) && ops[start].kind == opEqual {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromLabel, toLabel)
+		}
+
+		// Extend the hunk until a run of unchanged lines long enough to
+		// separate it from the next change.
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == opEqual {
+				run++
+			}
+			if run == len(ops) || run-end > 2*contextLines {
+				break
+			}
+			end = run
+		}
+
+		lo := max(start-contextLines, 0)
+		for lo < start && ops[lo].kind != opEqual {
+			lo++
+		}
+		hi := min(end+contextLines, len(ops))
+
+		var aCount, bCount int
+		for _, o := range ops[lo:hi] {
+			if o.kind != opInsert {
+				aCount++
+			}
+			if o.kind != opDelete {
+				bCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", ops[lo].a+1, aCount, ops[lo].b+1, bCount)
+		for _, o := range ops[lo:hi] {
+			sb.WriteByte(byte(o.kind))
+			sb.WriteString(o.text)
+			sb.WriteByte('\n')
+		}
+		start = hi
+	}
+	return sb.String()
+}
@@ -0,0 +1,29 @@
+package diff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	a := "one\ntwo\nthree\n"
+	b := "one\n2\nthree\nfour\n"
+	want := "--- a\n+++ b\n@@ -1,3 +1,4 @@\n one\n-two\n+2\n three\n+four\n"
+	if got := Unified(a, b, "a", "b"); got != want {
+		t.Errorf("Unified mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnified_Identical(t *testing.T) {
+	if got := Unified("same\n", "same\n", "a", "b"); got != "" {
+		t.Errorf("expected empty diff, got %q", got)
+	}
+}
+
+func TestUnified_SeparateHunks(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n"
+	want := "--- a\n+++ b\n" +
+		"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+		"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n"
+	if got := Unified(a, b, "a", "b"); got != want {
+		t.Errorf("Unified mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package eval
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Bucket is the observed pass rate for one confidence range.
+type Bucket struct {
+	Low, High float64
+	Runs      int
+	Passed    int
+}
+
+// PassRate is the fraction of runs in the bucket that passed the rubric.
+func (b Bucket) PassRate() float64 {
+	if b.Runs == 0 {
+		return 0
+	}
+	return float64(b.Passed) / float64(b.Runs)
+}
+
+// ModelCalibration compares confidence with correctness for one model.
+type ModelCalibration struct {
+	Model   string
+	Runs    int
+	Errors  int // runs that never produced a selection
+	Buckets []Bucket
+	// Suggested is the lowest threshold at which accepted runs pass at least
+	// TargetPassRate of the time, or 0 when no threshold reaches it.
+	Suggested float64
+	// Accepted is the share of scored runs the suggested threshold lets through.
+	Accepted float64
+}
+
+// Report is a calibration report across models.
+type Report struct {
+	Threshold      float64 // the threshold currently in use
+	TargetPassRate float64
+	Models         []ModelCalibration
+}
+
+// buckets splits the confidence range into tenths.
+const buckets = 10
+
+// Calibrate groups records by model and measures how well confidence
+// predicts a rubric pass. Suggested thresholds are picked from the
+// confidences actually observed.
+func Calibrate(recs []Record, current, targetPassRate float64) Report {
+	byModel := make(map[string][]Record)
+	for _, r := range recs {
+		byModel[r.Model] = append(byModel[r.Model], r)
+	}
+	models := make([]string, 0, len(byModel))
+	for m := range byModel {
+		models = append(models, m)
+	}
+	sort.Strings(models)
+
+	report := Report{Threshold: current, TargetPassRate: targetPassRate}
+	for _, m := range models {
+		report.Models = append(report.Models, calibrateModel(m, byModel[m], targetPassRate))
+	}
+	return report
+}
+
+func calibrateModel(model string, recs []Record, target float64) ModelCalibration {
+	mc := ModelCalibration{Model: model, Runs: len(recs)}
+	for i := 0; i < buckets; i++ {
+		mc.Buckets = append(mc.Buckets, Bucket{Low: float64(i) / buckets, High: float64(i+1) / buckets})
+	}
+
+	var scored []Record
+	for _, r := range recs {
+		if r.Error != "" {
+			mc.Errors++
+			continue
+		}
+		scored = append(scored, r)
+		// Round to hundredths first so 0.7 lands in 0.7–0.8, not 0.6–0.7.
+		i := int(math.Round(r.Confidence*100)) / (100 / buckets)
+		i = max(0, min(i, buckets-1))
+		mc.Buckets[i].Runs++
+		if r.Passed {
+			mc.Buckets[i].Passed++
+		}
+	}
+	if len(scored) == 0 {
+		return mc
+	}
+
+	// Try every observed confidence as a threshold, lowest first, and keep
+	// the first whose accepted runs meet the target.
+	sort.Slice(scored, func(i, j int) bool { return scored[i].Confidence < scored[j].Confidence })
+	for i := range scored {
+		if i > 0 && scored[i].Confidence == scored[i-1].Confidence {
+			continue
+		}
+		accepted := scored[i:]
+		passed := 0
+		for _, r := range accepted {
+			if r.Passed {
+				passed++
+			}
+		}
+		if float64(passed)/float64(len(accepted)) >= target {
+			mc.Suggested = scored[i].Confidence
+			mc.Accepted = float64(len(accepted)) / float64(len(scored))
+			break
+		}
+	}
+	return mc
+}
+
+// String renders the report as a plain-text table per model.
+func (r Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Current threshold: %.2f   Target pass rate: %.0f%%\n", r.Threshold, r.TargetPassRate*100)
+	if len(r.Models) == 0 {
+		sb.WriteString("\nNo records yet.\n")
+		return sb.String()
+	}
+	for _, m := range r.Models {
+		fmt.Fprintf(&sb, "\n%s — %d run(s), %d error(s)\n", m.Model, m.Runs, m.Errors)
+		sb.WriteString("  confidence   runs  pass rate\n")
+		for _, b := range m.Buckets {
+			if b.Runs == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "  %.1f–%.1f    %5d  %8.0f%%\n", b.Low, b.High, b.Runs, b.PassRate()*100)
+		}
+		if m.Suggested > 0 {
+			fmt.Fprintf(&sb, "  suggested threshold: %.2f (accepts %.0f%% of runs)\n", m.Suggested, m.Accepted*100)
+		} else {
+			sb.WriteString("  suggested threshold: none reaches the target pass rate\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,51 @@
+package eval
+
+// DefaultCases cover one clear-cut conversation per canonical use case, plus
+// one deliberately vague one that should come back with low confidence.
+var DefaultCases = []Case{
+	{
+		Name: "realtime-voting",
+		Turns: []string{
+			"Project name: \"votely\". What I'm building: a live voting app for meetups where the host shows a question and everyone votes from their phone.",
+			"Results update live on the projector, no accounts, rooms join by short code, results don't need to persist.",
+			"That's everything. Go with your top pick.",
+		},
+		WantProfile: "elixir-phoenix",
+	},
+	{
+		Name: "admin-crud",
+		Turns: []string{
+			"Project name: \"stockroom\". What I'm building: an internal inventory admin for a small warehouse team.",
+			"Staff log in, manage products, suppliers and stock counts, and export CSV reports. Nothing real-time.",
+			"That's all. Use your top pick.",
+		},
+		WantProfile: "ruby-rails",
+	},
+	{
+		Name: "cli-tool",
+		Turns: []string{
+			"Project name: \"logtail\". What I'm building: a command-line tool that tails and filters JSON logs from several services.",
+			"Single static binary, runs on macOS and Linux, config file plus flags, no UI.",
+			"Nothing else. Go with your top pick and include CLI conventions.",
+		},
+		WantProfile: "go-service",
+		WantAssets:  []string{"asset.app.cli"},
+	},
+	{
+		Name: "mobile-app",
+		Turns: []string{
+			"Project name: \"habitly\". What I'm building: a habit tracker app for iOS and Android.",
+			"Offline first, reminders via notifications, we will ship to both app stores.",
+			"That's it. Use your top pick.",
+		},
+		WantProfile: "dart-flutter",
+	},
+	{
+		Name: "vague",
+		Turns: []string{
+			"Project name: \"thing\". What I'm building: not sure yet, something with data.",
+			"/done",
+		},
+		WantProfile: "",
+	},
+}
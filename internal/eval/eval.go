@@ -0,0 +1,148 @@
+// Package eval replays scripted conversations against a live model and
+// scores the extracted selection against a rubric. Results are recorded so
+// confidence can be compared with actual correctness over many runs.
+package eval
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/sandbox"
+)
+
+// Case is one scripted conversation and the selection it should produce.
+type Case struct {
+	Name        string   `json:"name"`
+	Turns       []string `json:"turns"` // user messages, sent in order
+	WantProfile string   `json:"want_profile"`
+	WantAssets  []string `json:"want_assets,omitempty"` // must all be selected
+}
+
+// Record is the outcome of running one case once.
+type Record struct {
+	Case       string    `json:"case"`
+	Model      string    `json:"model"`
+	Confidence float64   `json:"confidence"`
+	Passed     bool      `json:"passed"`
+	Failures   []string  `json:"failures,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	At         time.Time `json:"at"`
+	Run        int       `json:"run,omitempty"`
+	Attempts   int       `json:"attempts,omitempty"`
+	Flaky      bool      `json:"flaky,omitempty"` // passed only after a retry
+	Seconds    float64   `json:"seconds,omitempty"`
+}
+
+// Conversation is the part of ai.Engine a run needs.
+type Conversation interface {
+	Chat(ctx context.Context, message string) (string, error)
+	ExtractDecision(ctx context.Context) (*ai.Selection, error)
+}
+
+// Score checks sel against the case rubric and returns the failed checks.
+func (c Case) Score(sel *ai.Selection) []string {
+	var failures []string
+	if sel.ProfileID != c.WantProfile {
+		failures = append(failures, fmt.Sprintf("profile %q, want %q", sel.ProfileID, c.WantProfile))
+	}
+	have := make(map[string]bool, len(sel.AssetIDs))
+	for _, id := range sel.AssetIDs {
+		have[id] = true
+	}
+	for _, id := range c.WantAssets {
+		if !have[id] {
+			failures = append(failures, "missing asset "+id)
+		}
+	}
+	return failures
+}
+
+// RunCase plays c through a fresh conversation and scores the extraction.
+// Model errors are recorded rather than returned so one bad run doesn't stop
+// a batch.
+func RunCase(ctx context.Context, conv Conversation, c Case, model string) Record {
+	rec := Record{Case: c.Name, Model: model, At: time.Now().UTC()}
+	for _, turn := range c.Turns {
+		if _, err := conv.Chat(ctx, turn); err != nil {
+			rec.Error = err.Error()
+			return rec
+		}
+	}
+	sel, err := conv.ExtractDecision(ctx)
+	if err != nil {
+		rec.Error = err.Error()
+		return rec
+	}
+	rec.Confidence = sel.Confidence
+	rec.Failures = c.Score(sel)
+	rec.Passed = len(rec.Failures) == 0
+	return rec
+}
+
+// LoadCases reads a JSON array of cases.
+func LoadCases(path string) ([]Case, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cases: %w", err)
+	}
+	var cases []Case
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("parse cases: %w", err)
+	}
+	return cases, nil
+}
+
+// AppendRecords adds records to a JSON-lines file, creating it if needed, so
+// runs accumulate across invocations.
+func AppendRecords(path string, recs []Record) error {
+	if err := sandbox.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create records dir: %w", err)
+	}
+	f, err := sandbox.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open records: %w", err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	for _, r := range recs {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("write record: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadRecords reads every record from a JSON-lines file. A missing file
+// yields no records.
+func LoadRecords(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open records: %w", err)
+	}
+	defer f.Close()
+
+	var recs []Record
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("records line %d: %w", line, err)
+		}
+		recs = append(recs, r)
+	}
+	return recs, sc.Err()
+}
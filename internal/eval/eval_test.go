@@ -0,0 +1,200 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/ai"
+)
+
+type fakeConversation struct {
+	sel     *ai.Selection
+	err     error
+	turns   int
+	chatErr error
+}
+
+func (f *fakeConversation) Chat(context.Context, string) (string, error) {
+	f.turns++
+	return "ok", f.chatErr
+}
+
+func (f *fakeConversation) ExtractDecision(context.Context) (*ai.Selection, error) {
+	return f.sel, f.err
+}
+
+func TestRunCase(t *testing.T) {
+	c := Case{Name: "cli", Turns: []string{"a", "b"}, WantProfile: "go-service", WantAssets: []string{"asset.app.cli"}}
+
+	conv := &fakeConversation{sel: &ai.Selection{ProfileID: "go-service", Confidence: 0.9}}
+	rec := RunCase(context.Background(), conv, c, "gpt-test")
+	if conv.turns != 2 {
+		t.Errorf("sent %d turns, want 2", conv.turns)
+	}
+	if rec.Passed || len(rec.Failures) != 1 || !strings.Contains(rec.Failures[0], "asset.app.cli") {
+		t.Errorf("record = %+v, want a missing-asset failure", rec)
+	}
+	if rec.Confidence != 0.9 || rec.Model != "gpt-test" {
+		t.Errorf("record = %+v", rec)
+	}
+
+	conv = &fakeConversation{chatErr: errors.New("boom")}
+	if rec := RunCase(context.Background(), conv, c, "gpt-test"); rec.Error != "boom" || rec.Passed {
+		t.Errorf("record = %+v, want error recorded", rec)
+	}
+}
+
+func TestRecordsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "eval", "records.jsonl")
+	if recs, err := LoadRecords(path); err != nil || recs != nil {
+		t.Fatalf("LoadRecords(missing) = %v, %v", recs, err)
+	}
+	if err := AppendRecords(path, []Record{{Case: "a", Passed: true}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendRecords(path, []Record{{Case: "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	recs, err := LoadRecords(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 || recs[0].Case != "a" || !recs[0].Passed || recs[1].Case != "b" {
+		t.Errorf("records = %+v", recs)
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	var recs []Record
+	add := func(model string, conf float64, passed bool) {
+		recs = append(recs, Record{Model: model, Confidence: conf, Passed: passed})
+	}
+	// m1: everything under 0.8 is unreliable, everything at or above passes.
+	add("m1", 0.60, false)
+	add("m1", 0.70, false)
+	add("m1", 0.75, true)
+	add("m1", 0.80, true)
+	add("m1", 0.85, true)
+	add("m1", 0.95, true)
+	recs = append(recs, Record{Model: "m1", Error: "timeout"})
+	// m2: never good enough.
+	add("m2", 0.90, false)
+
+	report := Calibrate(recs, 0.72, 0.9)
+	if len(report.Models) != 2 {
+		t.Fatalf("models = %d, want 2", len(report.Models))
+	}
+	m1 := report.Models[0]
+	if m1.Model != "m1" || m1.Runs != 7 || m1.Errors != 1 {
+		t.Errorf("m1 = %+v", m1)
+	}
+	if m1.Suggested != 0.75 {
+		t.Errorf("m1 suggested = %.2f, want 0.75", m1.Suggested)
+	}
+	if b := m1.Buckets[7]; b.Runs != 2 || b.Passed != 1 {
+		t.Errorf("0.7 bucket = %+v", b)
+	}
+	if report.Models[1].Suggested != 0 {
+		t.Errorf("m2 should have no suggestion, got %.2f", report.Models[1].Suggested)
+	}
+	if out := report.String(); !strings.Contains(out, "suggested threshold: 0.75") {
+		t.Errorf("report missing suggestion:\n%s", out)
+	}
+}
+
+func TestRunnerRetriesAndOrder(t *testing.T) {
+	cases := []Case{
+		{Name: "steady", Turns: []string{"steady"}, WantProfile: "go-service"},
+		{Name: "flaky", Turns: []string{"flaky"}, WantProfile: "go-service"},
+		{Name: "broken", Turns: []string{"broken"}, WantProfile: "go-service"},
+	}
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	r := Runner{
+		Model:   "gpt-test",
+		Workers: 3,
+		Retries: 2,
+		NewConversation: func() (Conversation, error) {
+			return &scriptedConversation{pick: func(first string) *ai.Selection {
+				mu.Lock()
+				defer mu.Unlock()
+				attempts[first]++
+				switch {
+				case first == "steady", first == "flaky" && attempts[first] > 1:
+					return &ai.Selection{ProfileID: "go-service"}
+				}
+				return &ai.Selection{ProfileID: "laravel"}
+			}}, nil
+		},
+	}
+	done := 0
+	r.OnDone = func(Record) { done++ }
+	recs := r.Run(context.Background(), cases, 2)
+
+	if len(recs) != 6 || done != 6 {
+		t.Fatalf("got %d records, %d callbacks; want 6", len(recs), done)
+	}
+	for i, rec := range recs {
+		if rec.Case != cases[i%3].Name || rec.Run != i/3+1 {
+			t.Errorf("recs[%d] = %s run %d, out of order", i, rec.Case, rec.Run)
+		}
+	}
+	if rec := recs[0]; !rec.Passed || rec.Attempts != 1 || rec.Flaky {
+		t.Errorf("steady = %+v", rec)
+	}
+	if rec := recs[2]; rec.Passed || rec.Attempts != 3 {
+		t.Errorf("broken = %+v, want 3 failed attempts", rec)
+	}
+	if attempts["flaky"] < 2 {
+		t.Errorf("flaky was tried %d time(s), want a retry", attempts["flaky"])
+	}
+}
+
+// scriptedConversation selects whatever pick returns for its first turn.
+type scriptedConversation struct {
+	pick  func(first string) *ai.Selection
+	first string
+}
+
+func (s *scriptedConversation) Chat(_ context.Context, msg string) (string, error) {
+	if s.first == "" {
+		s.first = msg
+	}
+	return "ok", nil
+}
+
+func (s *scriptedConversation) ExtractDecision(context.Context) (*ai.Selection, error) {
+	return s.pick(s.first), nil
+}
+
+func TestWriteJUnit(t *testing.T) {
+	recs := []Record{
+		{Case: "ok", Model: "m2", Passed: true, Attempts: 2, Flaky: true, Seconds: 1.5},
+		{Case: "miss", Model: "m1", Failures: []string{`profile "laravel", want "go-service"`}, Attempts: 1},
+		{Case: "down", Model: "m1", Error: "timeout", Run: 2},
+	}
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, recs); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<testsuites name="launchpad eval" tests="3" failures="1" errors="1"`,
+		`<testsuite name="m1" tests="2" failures="1" errors="1"`,
+		`<testcase name="down (run 2)" classname="launchpad.eval.m1"`,
+		`<failure message="rubric not met">profile &#34;laravel&#34;, want &#34;go-service&#34;</failure>`,
+		`<property name="flaky" value="true"></property>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("junit missing %s:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, `name="m1"`) > strings.Index(out, `name="m2"`) {
+		t.Error("suites should be sorted by model")
+	}
+}
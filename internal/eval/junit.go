@@ -0,0 +1,122 @@
+package eval
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr,omitempty"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name       string          `xml:"name,attr"`
+	Classname  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitMessage   `xml:"failure,omitempty"`
+	Error      *junitMessage   `xml:"error,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes recs as JUnit XML with one test suite per model, so CI
+// dashboards can track generation quality per model version. Rubric misses
+// are failures; model or transport errors are errors.
+func WriteJUnit(w io.Writer, recs []Record) error {
+	byModel := map[string][]Record{}
+	for _, r := range recs {
+		byModel[r.Model] = append(byModel[r.Model], r)
+	}
+	models := make([]string, 0, len(byModel))
+	for m := range byModel {
+		models = append(models, m)
+	}
+	sort.Strings(models)
+
+	doc := junitSuites{Name: "launchpad eval"}
+	var total float64
+	for _, model := range models {
+		suite := junitSuite{Name: model}
+		var elapsed float64
+		for _, r := range byModel[model] {
+			if suite.Timestamp == "" && !r.At.IsZero() {
+				suite.Timestamp = r.At.Format("2006-01-02T15:04:05")
+			}
+			tc := junitCase{
+				Name:      r.Case,
+				Classname: "launchpad.eval." + model,
+				Time:      seconds(r.Seconds),
+				Properties: []junitProperty{
+					{"confidence", strconv.FormatFloat(r.Confidence, 'f', 2, 64)},
+					{"attempts", strconv.Itoa(r.Attempts)},
+					{"flaky", strconv.FormatBool(r.Flaky)},
+				},
+			}
+			if r.Run > 1 {
+				tc.Name = fmt.Sprintf("%s (run %d)", r.Case, r.Run)
+			}
+			switch {
+			case r.Error != "":
+				tc.Error = &junitMessage{Message: r.Error, Body: r.Error}
+				suite.Errors++
+			case !r.Passed:
+				tc.Failure = &junitMessage{Message: "rubric not met", Body: strings.Join(r.Failures, "\n")}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, tc)
+			elapsed += r.Seconds
+		}
+		suite.Tests = len(suite.Cases)
+		suite.Time = seconds(elapsed)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Errors += suite.Errors
+		total += elapsed
+		doc.Suites = append(doc.Suites, suite)
+	}
+	doc.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("write junit: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(s float64) string {
+	return strconv.FormatFloat(s, 'f', 3, 64)
+}
@@ -0,0 +1,78 @@
+package eval
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Runner plays cases concurrently, retrying runs that fail or error.
+type Runner struct {
+	// NewConversation returns a fresh conversation for one attempt.
+	NewConversation func() (Conversation, error)
+	Model           string
+	// Workers is how many attempts run at once; below 1 means one.
+	Workers int
+	// Retries is the per-case budget of extra attempts after a failure or
+	// error. A run that passes on a retry is recorded as flaky.
+	Retries int
+	// OnDone, when set, receives each final record as it completes. Calls
+	// are serialized.
+	OnDone func(Record)
+}
+
+// Run plays every case runs times and returns the records in run order,
+// then case order, however the attempts were scheduled.
+func (r Runner) Run(ctx context.Context, cases []Case, runs int) []Record {
+	recs := make([]Record, runs*len(cases))
+	workers := r.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				recs[i] = r.runWithRetries(ctx, cases[i%len(cases)])
+				recs[i].Run = i/len(cases) + 1
+				if r.OnDone != nil {
+					mu.Lock()
+					r.OnDone(recs[i])
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range recs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return recs
+}
+
+// runWithRetries keeps the first passing attempt, or the last one when the
+// budget runs out.
+func (r Runner) runWithRetries(ctx context.Context, c Case) Record {
+	start := time.Now()
+	var rec Record
+	for attempt := 1; attempt <= r.Retries+1; attempt++ {
+		conv, err := r.NewConversation()
+		if err != nil {
+			rec = Record{Case: c.Name, Model: r.Model, Error: err.Error(), At: time.Now().UTC()}
+		} else {
+			rec = RunCase(ctx, conv, c, r.Model)
+		}
+		rec.Attempts = attempt
+		if rec.Passed || ctx.Err() != nil {
+			break
+		}
+	}
+	rec.Flaky = rec.Passed && rec.Attempts > 1
+	rec.Seconds = time.Since(start).Seconds()
+	return rec
+}
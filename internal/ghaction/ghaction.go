@@ -0,0 +1,220 @@
+// Package ghaction implements the GitHub Actions runner protocol: inputs from
+// INPUT_* variables, outputs and step summaries via the files the runner
+// provides, workflow-command annotations, and pull request comments.
+package ghaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Env looks up a runner environment variable; tests supply a map.
+type Env func(key string) string
+
+// Runner is the slice of the Actions environment launchpad uses.
+type Runner struct {
+	env Env
+}
+
+// New returns a Runner over env; pass os.Getenv outside tests.
+func New(env Env) *Runner {
+	return &Runner{env: env}
+}
+
+// InActions reports whether the process is running inside a workflow.
+func (r *Runner) InActions() bool {
+	return r.env("GITHUB_ACTIONS") == "true"
+}
+
+// Input returns the action input name, or def when it is unset. The runner
+// upper-cases input names and replaces spaces with underscores.
+func (r *Runner) Input(name, def string) string {
+	key := "INPUT_" + strings.ToUpper(strings.ReplaceAll(name, " ", "_"))
+	if v := strings.TrimSpace(r.env(key)); v != "" {
+		return v
+	}
+	return def
+}
+
+// BoolInput parses a true/false input.
+func (r *Runner) BoolInput(name string, def bool) bool {
+	switch strings.ToLower(r.Input(name, "")) {
+	case "true", "yes", "1":
+		return true
+	case "false", "no", "0":
+		return false
+	}
+	return def
+}
+
+// SetOutput appends name=value to the step's output file. Multi-line values
+// use the heredoc form the runner expects.
+func (r *Runner) SetOutput(name, value string) error {
+	if strings.Contains(value, "\n") {
+		return r.appendFile("GITHUB_OUTPUT", fmt.Sprintf("%s<<LAUNCHPAD_EOF\n%s\nLAUNCHPAD_EOF\n", name, value))
+	}
+	return r.appendFile("GITHUB_OUTPUT", fmt.Sprintf("%s=%s\n", name, value))
+}
+
+// AddSummary appends markdown to the job summary page.
+func (r *Runner) AddSummary(markdown string) error {
+	return r.appendFile("GITHUB_STEP_SUMMARY", markdown)
+}
+
+// appendFile appends to the file named by env var key. Outside a runner the
+// variable is unset and the write is skipped.
+func (r *Runner) appendFile(key, data string) error {
+	path := r.env(key)
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", key, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		return fmt.Errorf("writing %s: %w", key, err)
+	}
+	return nil
+}
+
+// Annotation formats a workflow command that shows msg inline on file:line
+// in the pull request diff. level is "error", "warning", or "notice".
+func Annotation(level, file string, line int, title, msg string) string {
+	return fmt.Sprintf("::%s file=%s,line=%d,title=%s::%s",
+		level, escapeProperty(file), line, escapeProperty(title), escapeData(msg))
+}
+
+// Message formats a workflow command that logs msg at level without a
+// file location.
+func Message(level, msg string) string {
+	return fmt.Sprintf("::%s::%s", level, escapeData(msg))
+}
+
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// PullRequestNumber returns the pull request that triggered the workflow, or
+// 0 for any other event.
+func (r *Runner) PullRequestNumber() (int, error) {
+	path := r.env("GITHUB_EVENT_PATH")
+	if path == "" {
+		return 0, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("reading event payload: %w", err)
+	}
+	var event struct {
+		PullRequest *struct {
+			Number int `json:"number"`
+		} `json:"pull_request"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return 0, fmt.Errorf("parsing event payload: %w", err)
+	}
+	if event.PullRequest == nil {
+		return 0, nil
+	}
+	return event.PullRequest.Number, nil
+}
+
+// Comment posts body as a comment on pull request number using token.
+func (r *Runner) Comment(ctx context.Context, client *http.Client, token string, number int, body string) error {
+	path := fmt.Sprintf("issues/%d/comments", number)
+	if err := r.call(ctx, client, token, http.MethodPost, path, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("posting comment: %w", err)
+	}
+	return nil
+}
+
+// FindComment returns the ID of the first comment on pull request number
+// whose body contains marker, or 0 when there is none.
+func (r *Runner) FindComment(ctx context.Context, client *http.Client, token string, number int, marker string) (int64, error) {
+	const perPage = 100
+	for page := 1; ; page++ {
+		var comments []struct {
+			ID   int64  `json:"id"`
+			Body string `json:"body"`
+		}
+		path := fmt.Sprintf("issues/%d/comments?per_page=%d&page=%d", number, perPage, page)
+		if err := r.call(ctx, client, token, http.MethodGet, path, nil, &comments); err != nil {
+			return 0, fmt.Errorf("listing comments: %w", err)
+		}
+		for _, c := range comments {
+			if strings.Contains(c.Body, marker) {
+				return c.ID, nil
+			}
+		}
+		if len(comments) < perPage {
+			return 0, nil
+		}
+	}
+}
+
+// EditComment replaces the body of comment id using token.
+func (r *Runner) EditComment(ctx context.Context, client *http.Client, token string, id int64, body string) error {
+	path := fmt.Sprintf("issues/comments/%d", id)
+	if err := r.call(ctx, client, token, http.MethodPatch, path, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("editing comment: %w", err)
+	}
+	return nil
+}
+
+// call sends a REST API request for path under the workflow's repository,
+// encoding payload (if any) as the body and decoding the response into out
+// (if any).
+func (r *Runner) call(ctx context.Context, client *http.Client, token, method, path string, payload, out any) error {
+	api := r.env("GITHUB_API_URL")
+	if api == "" {
+		api = "https://api.github.com"
+	}
+	repo := r.env("GITHUB_REPOSITORY")
+	if repo == "" {
+		return fmt.Errorf("GITHUB_REPOSITORY is not set")
+	}
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s", strings.TrimSuffix(api, "/"), repo, path)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
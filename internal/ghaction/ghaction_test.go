@@ -0,0 +1,148 @@
+package ghaction
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func envMap(m map[string]string) Env {
+	return func(k string) string { return m[k] }
+}
+
+func TestInputs(t *testing.T) {
+	r := New(envMap(map[string]string{"INPUT_COMMAND": " validate ", "INPUT_PR_COMMENT": "false"}))
+	if got := r.Input("command", "x"); got != "validate" {
+		t.Errorf("Input(command) = %q", got)
+	}
+	if got := r.Input("path", "."); got != "." {
+		t.Errorf("Input(path) default = %q", got)
+	}
+	if r.BoolInput("pr_comment", true) {
+		t.Error("BoolInput(pr_comment) = true, want false")
+	}
+}
+
+func TestSetOutputAndSummary(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "output")
+	summary := filepath.Join(dir, "summary")
+	r := New(envMap(map[string]string{"GITHUB_OUTPUT": out, "GITHUB_STEP_SUMMARY": summary}))
+
+	if err := r.SetOutput("errors", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetOutput("report", "a\nb"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddSummary("## Report\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(out)
+	want := "errors=2\nreport<<LAUNCHPAD_EOF\na\nb\nLAUNCHPAD_EOF\n"
+	if string(data) != want {
+		t.Errorf("output file = %q, want %q", data, want)
+	}
+	if data, _ := os.ReadFile(summary); string(data) != "## Report\n" {
+		t.Errorf("summary file = %q", data)
+	}
+}
+
+func TestAnnotation(t *testing.T) {
+	got := Annotation("error", "a,b.md", 3, "applyto-glob", "bad: 50%\nglob")
+	want := "::error file=a%2Cb.md,line=3,title=applyto-glob::bad: 50%25%0Aglob"
+	if got != want {
+		t.Errorf("Annotation() = %q, want %q", got, want)
+	}
+}
+
+func TestPullRequestNumberAndComment(t *testing.T) {
+	dir := t.TempDir()
+	event := filepath.Join(dir, "event.json")
+	if err := os.WriteFile(event, []byte(`{"pull_request":{"number":42}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotPath, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotPath = req.URL.Path
+		data, _ := io.ReadAll(req.Body)
+		var payload map[string]string
+		json.Unmarshal(data, &payload)
+		gotBody = payload["body"]
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	r := New(envMap(map[string]string{
+		"GITHUB_EVENT_PATH": event,
+		"GITHUB_API_URL":    srv.URL,
+		"GITHUB_REPOSITORY": "acme/app",
+	}))
+	n, err := r.PullRequestNumber()
+	if err != nil || n != 42 {
+		t.Fatalf("PullRequestNumber() = %d, %v", n, err)
+	}
+	if err := r.Comment(context.Background(), srv.Client(), "tok", n, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/repos/acme/app/issues/42/comments" || gotBody != "hello" {
+		t.Errorf("posted %q to %s", gotBody, gotPath)
+	}
+}
+
+func TestFindAndEditComment(t *testing.T) {
+	var patched, patchedBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/repos/acme/app/issues/42/comments":
+			comments := []map[string]any{}
+			if req.URL.Query().Get("page") == "1" {
+				for i := 1; i <= 100; i++ {
+					comments = append(comments, map[string]any{"id": i, "body": "unrelated"})
+				}
+			} else {
+				comments = append(comments, map[string]any{"id": 101, "body": "<!-- mark -->\nold"})
+			}
+			json.NewEncoder(w).Encode(comments)
+		case req.Method == http.MethodPatch:
+			patched = req.URL.Path
+			data, _ := io.ReadAll(req.Body)
+			var payload map[string]string
+			json.Unmarshal(data, &payload)
+			patchedBody = payload["body"]
+		default:
+			t.Errorf("unexpected %s %s", req.Method, req.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r := New(envMap(map[string]string{"GITHUB_API_URL": srv.URL, "GITHUB_REPOSITORY": "acme/app"}))
+	ctx := context.Background()
+	id, err := r.FindComment(ctx, srv.Client(), "tok", 42, "<!-- mark -->")
+	if err != nil || id != 101 {
+		t.Fatalf("FindComment() = %d, %v; want 101 from the second page", id, err)
+	}
+	if id, err := r.FindComment(ctx, srv.Client(), "tok", 42, "<!-- other -->"); err != nil || id != 0 {
+		t.Errorf("FindComment(other) = %d, %v; want 0", id, err)
+	}
+	if err := r.EditComment(ctx, srv.Client(), "tok", id, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if patched != "/repos/acme/app/issues/comments/101" || patchedBody != "new" {
+		t.Errorf("patched %q at %s", patchedBody, patched)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	if got := Message("warning", "no token\nskipped"); got != "::warning::no token%0Askipped" {
+		t.Errorf("Message() = %q", got)
+	}
+}
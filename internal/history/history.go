@@ -0,0 +1,186 @@
+// Package history keeps a local, per-user log of generation runs so
+// template maintainers can see what gets generated and what fails.
+// Nothing in it ever leaves the machine.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/sandbox"
+)
+
+// DisableEnv turns off recording when set to any non-empty value.
+const DisableEnv = "LAUNCHPAD_NO_HISTORY"
+
+// Entry is one generation attempt.
+type Entry struct {
+	At           time.Time `json:"at"`
+	Model        string    `json:"model"`
+	ProfileID    string    `json:"profile_id"`
+	AddonIDs     []string  `json:"addon_ids,omitempty"`
+	AssetIDs     []string  `json:"asset_ids,omitempty"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	Files        int       `json:"files"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// Failed reports whether the run ended without writing files.
+func (e Entry) Failed() bool {
+	return e.Error != ""
+}
+
+// DefaultPath is the history file in the user's config directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating config dir: %w", err)
+	}
+	return filepath.Join(dir, "launchpad", "history.jsonl"), nil
+}
+
+// Append adds e to the history file at path.
+func Append(path string, e Entry) error {
+	if err := sandbox.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+	f, err := sandbox.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open history: %w", err)
+	}
+	defer f.Close()
+	if err := json.NewEncoder(f).Encode(e); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+// Load reads every entry at path. A missing file is an empty history; lines
+// that don't parse (e.g. from a crash mid-write) are skipped.
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e Entry
+		if json.Unmarshal(sc.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, sc.Err()
+}
+
+// Count is how often an ID appears across runs.
+type Count struct {
+	ID   string
+	Runs int
+}
+
+// ProfileStats aggregates runs that selected one profile.
+type ProfileStats struct {
+	ProfileID string
+	Runs      int
+	Failures  int
+	AvgTokens int // input + output, averaged over runs with recorded usage
+}
+
+// FailureRate is the fraction of runs that failed.
+func (p ProfileStats) FailureRate() float64 {
+	if p.Runs == 0 {
+		return 0
+	}
+	return float64(p.Failures) / float64(p.Runs)
+}
+
+// Stats summarizes a history.
+type Stats struct {
+	Runs     int
+	Failures int
+	Profiles []ProfileStats // most-used first
+	Addons   []Count        // most-used first
+	Assets   []Count        // most-used first
+}
+
+// Summarize aggregates entries for the stats command.
+func Summarize(entries []Entry) Stats {
+	var st Stats
+	profiles := make(map[string]*ProfileStats)
+	tokens := make(map[string][2]int) // profile -> total tokens, runs with usage
+	addons := make(map[string]int)
+	assets := make(map[string]int)
+
+	for _, e := range entries {
+		st.Runs++
+		id := e.ProfileID
+		if id == "" {
+			id = "(none)"
+		}
+		ps := profiles[id]
+		if ps == nil {
+			ps = &ProfileStats{ProfileID: id}
+			profiles[id] = ps
+		}
+		ps.Runs++
+		if e.Failed() {
+			st.Failures++
+			ps.Failures++
+		}
+		if used := e.InputTokens + e.OutputTokens; used > 0 {
+			t := tokens[id]
+			tokens[id] = [2]int{t[0] + used, t[1] + 1}
+		}
+		for _, a := range e.AddonIDs {
+			addons[a]++
+		}
+		for _, a := range e.AssetIDs {
+			assets[a]++
+		}
+	}
+
+	for id, ps := range profiles {
+		if t := tokens[id]; t[1] > 0 {
+			ps.AvgTokens = t[0] / t[1]
+		}
+		st.Profiles = append(st.Profiles, *ps)
+	}
+	sort.Slice(st.Profiles, func(i, j int) bool {
+		if st.Profiles[i].Runs != st.Profiles[j].Runs {
+			return st.Profiles[i].Runs > st.Profiles[j].Runs
+		}
+		return st.Profiles[i].ProfileID < st.Profiles[j].ProfileID
+	})
+	st.Addons = ranked(addons)
+	st.Assets = ranked(assets)
+	return st
+}
+
+func ranked(counts map[string]int) []Count {
+	out := make([]Count, 0, len(counts))
+	for id, n := range counts {
+		out = append(out, Count{ID: id, Runs: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Runs != out[j].Runs {
+			return out[i].Runs > out[j].Runs
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
@@ -0,0 +1,59 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "launchpad", "history.jsonl")
+	if entries, err := Load(path); err != nil || entries != nil {
+		t.Fatalf("Load(missing) = %v, %v", entries, err)
+	}
+	if err := Append(path, Entry{ProfileID: "go-service", Files: 5}); err != nil {
+		t.Fatal(err)
+	}
+	// A torn line from an interrupted write must not hide later entries.
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	f.WriteString("{\"profile_id\": \"trunc\n")
+	f.Close()
+	if err := Append(path, Entry{ProfileID: "ruby-rails", Error: "boom"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].ProfileID != "go-service" || !entries[1].Failed() {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	entries := []Entry{
+		{ProfileID: "go-service", AssetIDs: []string{"asset.app.cli"}, InputTokens: 1000, OutputTokens: 500},
+		{ProfileID: "go-service", AssetIDs: []string{"asset.app.cli", "asset.testing.pragmatic"}, InputTokens: 3000, OutputTokens: 500},
+		{ProfileID: "go-service", Error: "timeout"},
+		{ProfileID: "ruby-rails", AddonIDs: []string{"data-intensive"}, InputTokens: 10, OutputTokens: 10},
+	}
+	st := Summarize(entries)
+
+	if st.Runs != 4 || st.Failures != 1 {
+		t.Errorf("runs/failures = %d/%d", st.Runs, st.Failures)
+	}
+	if len(st.Profiles) != 2 || st.Profiles[0].ProfileID != "go-service" {
+		t.Fatalf("profiles = %+v", st.Profiles)
+	}
+	gs := st.Profiles[0]
+	if gs.Runs != 3 || gs.Failures != 1 || gs.AvgTokens != 2500 {
+		t.Errorf("go-service = %+v, want avg over runs with usage", gs)
+	}
+	if st.Assets[0] != (Count{ID: "asset.app.cli", Runs: 2}) {
+		t.Errorf("top asset = %+v", st.Assets[0])
+	}
+	if len(st.Addons) != 1 || st.Addons[0].ID != "data-intensive" {
+		t.Errorf("addons = %+v", st.Addons)
+	}
+}
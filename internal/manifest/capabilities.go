@@ -0,0 +1,93 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/ecoker/launchpad/internal/sandbox"
+)
+
+// CapabilitiesPath is where editor integrations look for what this project
+// was generated with and which launchpad commands they can offer.
+const CapabilitiesPath = ".launchpad/capabilities.json"
+
+// capabilitiesVersion is bumped whenever the capabilities format changes
+// shape; extensions should ignore versions they don't understand.
+const capabilitiesVersion = 1
+
+// Capabilities is the editor-facing summary of a generated project. It is
+// derived from the manifest so extensions never parse markdown.
+type Capabilities struct {
+	Version     int       `json:"version"`
+	ProjectName string    `json:"project_name"`
+	ProfileID   string    `json:"profile_id"`
+	AddonIDs    []string  `json:"addon_ids,omitempty"`
+	AssetIDs    []string  `json:"asset_ids,omitempty"`
+	Files       []string  `json:"files"`
+	Commands    []Command `json:"commands"`
+}
+
+// Command is an action an extension can run from the project root.
+type Command struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Args        []string `json:"args"` // argv, starting with the launchpad binary name
+	Interactive bool     `json:"interactive"`
+}
+
+// defaultCommands are the launchpad invocations every generated project
+// supports.
+var defaultCommands = []Command{
+	{
+		ID:          "update",
+		Title:       "Update AI instructions",
+		Args:        []string{"launchpad", "init", "."},
+		Interactive: true,
+	},
+	{
+		ID:    "validate",
+		Title: "Validate AI instruction files",
+		Args:  []string{"launchpad", "validate", "--json", "."},
+	},
+	{
+		ID:    "undo",
+		Title: "Undo the last AI instructions update",
+		Args:  []string{"launchpad", "undo", "."},
+	},
+}
+
+// CapabilitiesFor summarizes m for editor integrations.
+func CapabilitiesFor(m *Manifest) Capabilities {
+	files := make([]string, 0, len(m.Files))
+	for p := range m.Files {
+		files = append(files, p)
+	}
+	sort.Strings(files)
+	return Capabilities{
+		Version:     capabilitiesVersion,
+		ProjectName: m.ProjectName,
+		ProfileID:   m.ProfileID,
+		AddonIDs:    m.AddonIDs,
+		AssetIDs:    m.AssetIDs,
+		Files:       files,
+		Commands:    defaultCommands,
+	}
+}
+
+// WriteCapabilities writes the capabilities file for m under root.
+func WriteCapabilities(root string, m *Manifest) error {
+	data, err := json.MarshalIndent(CapabilitiesFor(m), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal capabilities: %w", err)
+	}
+	full := filepath.Join(root, CapabilitiesPath)
+	if err := sandbox.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return fmt.Errorf("create capabilities dir: %w", err)
+	}
+	if err := sandbox.WriteFile(full, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write capabilities: %w", err)
+	}
+	return nil
+}
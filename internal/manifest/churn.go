@@ -0,0 +1,110 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Churn explains how a regeneration's output differs from the previous run.
+type Churn struct {
+	Changed []string // generated again with different content
+	Added   []string // generated now but not last time
+	Removed []string // generated last time but not now
+	Causes  []string // why, most specific first
+}
+
+// Empty reports whether the run reproduced the previous output exactly.
+func (c *Churn) Empty() bool {
+	return len(c.Changed)+len(c.Added)+len(c.Removed) == 0
+}
+
+// Compare contrasts the previous manifest with the next one, given the hash
+// of every file this run generated (including files the user chose to keep).
+// Causes are attributed from the recorded inputs: a different selection,
+// updated templates, or a different model. When the inputs match but the
+// output doesn't, the difference is model drift. It returns nil when there
+// is no previous run to compare with.
+func Compare(prev, next *Manifest, generated map[string]string) *Churn {
+	if prev == nil {
+		return nil
+	}
+	c := &Churn{}
+	for p, h := range generated {
+		entry, ok := prev.Files[p]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, p)
+		case entry.SHA256 != h:
+			c.Changed = append(c.Changed, p)
+		}
+	}
+	for p := range prev.Files {
+		if _, ok := generated[p]; !ok {
+			c.Removed = append(c.Removed, p)
+		}
+	}
+	sort.Strings(c.Changed)
+	sort.Strings(c.Added)
+	sort.Strings(c.Removed)
+	if c.Empty() {
+		return c
+	}
+
+	if prev.ProfileID != next.ProfileID {
+		c.Causes = append(c.Causes, fmt.Sprintf("selection changed: profile %s → %s", prev.ProfileID, next.ProfileID))
+	}
+	for _, list := range []struct {
+		kind       string
+		prev, next []string
+	}{
+		{"add-on", prev.AddonIDs, next.AddonIDs},
+		{"asset", prev.AssetIDs, next.AssetIDs},
+	} {
+		added, removed := difference(list.next, list.prev), difference(list.prev, list.next)
+		if len(added)+len(removed) > 0 {
+			var parts []string
+			for _, id := range added {
+				parts = append(parts, "+"+id)
+			}
+			for _, id := range removed {
+				parts = append(parts, "-"+id)
+			}
+			c.Causes = append(c.Causes, fmt.Sprintf("selection changed: %s %s", list.kind, strings.Join(parts, " ")))
+		}
+	}
+
+	var updated []string
+	for id, h := range next.Templates {
+		if old, ok := prev.Templates[id]; ok && old != h {
+			updated = append(updated, id)
+		}
+	}
+	sort.Strings(updated)
+	if len(updated) > 0 {
+		c.Causes = append(c.Causes, "template changed: "+strings.Join(updated, ", "))
+	}
+
+	if prev.Model != "" && next.Model != "" && prev.Model != next.Model {
+		c.Causes = append(c.Causes, fmt.Sprintf("model changed: %s → %s", prev.Model, next.Model))
+	}
+	if len(c.Causes) == 0 {
+		c.Causes = append(c.Causes, "model drift: same selection, templates, and model")
+	}
+	return c
+}
+
+// difference returns the items of a not in b.
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	var out []string
+	for _, v := range a {
+		if !in[v] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
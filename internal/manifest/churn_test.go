@@ -0,0 +1,75 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	prev := New("demo")
+	prev.ProfileID = "go-service"
+	prev.AssetIDs = []string{"asset.ci.github"}
+	prev.Model = "gpt-4.1"
+	prev.Templates = map[string]string{"profile.go-service": "aaa"}
+	prev.Files = map[string]FileEntry{"AGENTS.md": {SHA256: "1"}, "old.md": {SHA256: "2"}, "same.md": {SHA256: "3"}}
+
+	tests := []struct {
+		name       string
+		edit       func(m *Manifest)
+		wantCauses []string
+	}{
+		{
+			name:       "same inputs",
+			edit:       func(*Manifest) {},
+			wantCauses: []string{"model drift: same selection, templates, and model"},
+		},
+		{
+			name: "selection",
+			edit: func(m *Manifest) {
+				m.ProfileID = "rust-axum"
+				m.AssetIDs = []string{"asset.app.cli"}
+			},
+			wantCauses: []string{
+				"selection changed: profile go-service → rust-axum",
+				"selection changed: asset +asset.app.cli -asset.ci.github",
+			},
+		},
+		{
+			name: "template and model",
+			edit: func(m *Manifest) {
+				m.Templates = map[string]string{"profile.go-service": "bbb"}
+				m.Model = "gpt-4.1-mini"
+			},
+			wantCauses: []string{"template changed: profile.go-service", "model changed: gpt-4.1 → gpt-4.1-mini"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := New("demo")
+			next.ProfileID, next.AssetIDs, next.Model = prev.ProfileID, prev.AssetIDs, prev.Model
+			next.Templates = map[string]string{"profile.go-service": "aaa"}
+			tt.edit(next)
+
+			c := Compare(prev, next, map[string]string{"AGENTS.md": "9", "new.md": "4", "same.md": "3"})
+			if !reflect.DeepEqual(c.Changed, []string{"AGENTS.md"}) || !reflect.DeepEqual(c.Added, []string{"new.md"}) ||
+				!reflect.DeepEqual(c.Removed, []string{"old.md"}) {
+				t.Errorf("files: changed %v added %v removed %v", c.Changed, c.Added, c.Removed)
+			}
+			if !reflect.DeepEqual(c.Causes, tt.wantCauses) {
+				t.Errorf("causes = %q, want %q", c.Causes, tt.wantCauses)
+			}
+		})
+	}
+}
+
+func TestCompare_Reproduced(t *testing.T) {
+	prev := New("demo")
+	prev.Files = map[string]FileEntry{"AGENTS.md": {SHA256: "1"}}
+	c := Compare(prev, New("demo"), map[string]string{"AGENTS.md": "1"})
+	if !c.Empty() || len(c.Causes) != 0 {
+		t.Errorf("got %+v, want no churn", c)
+	}
+	if Compare(nil, prev, nil) != nil {
+		t.Error("first run should have nothing to compare")
+	}
+}
@@ -0,0 +1,186 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/sandbox"
+)
+
+// Path is the manifest location relative to the project root.
+const Path = ".launchpad/manifest.json"
+
+// BaseDir holds a pristine copy of every generated file, relative to the
+// project root. It is the common ancestor for three-way merges on regeneration.
+const BaseDir = ".launchpad/base"
+
+// currentVersion is bumped whenever the on-disk format changes shape.
+const currentVersion = 1
+
+// Manifest records what Launchpad generated into a project so later runs can
+// tell launchpad-owned files apart from the user's own edits.
+type Manifest struct {
+	Version     int                  `json:"version"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	ProjectName string               `json:"project_name"`
+	ProfileID   string               `json:"profile_id"`
+	AddonIDs    []string             `json:"addon_ids,omitempty"`
+	AssetIDs    []string             `json:"asset_ids,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`        // project intent from the first conversation on
+	Constraints []string             `json:"constraints,omitempty"` // hard requirements the user stated, kept across runs
+	Files       map[string]FileEntry `json:"files"`
+
+	// Model and Templates record the inputs behind the files, so the next
+	// run can explain why its output differs.
+	Model     string            `json:"model,omitempty"`
+	Templates map[string]string `json:"templates,omitempty"` // asset ID → template SHA-256
+
+	// Targets, Language, Tone, and Packages are the settings the files were
+	// generated with, so regen can repeat them without a conversation.
+	Targets  []string  `json:"targets,omitempty"`
+	Language string    `json:"language,omitempty"`
+	Tone     Tone      `json:"tone,omitzero"`
+	Packages []Package `json:"packages,omitempty"`
+}
+
+// Tone is the advisor style the files were written in.
+type Tone struct {
+	Verbosity string `json:"verbosity,omitempty"`
+	Formality string `json:"formality,omitempty"`
+	Emoji     *bool  `json:"emoji,omitempty"`
+}
+
+// Package is a monorepo member that got its own scoped instructions.
+type Package struct {
+	Path      string `json:"path"`
+	ProfileID string `json:"profile_id"`
+}
+
+// FileEntry is the recorded state of a single generated file.
+type FileEntry struct {
+	SHA256 string `json:"sha256"`
+}
+
+// FileStatus describes how a file on disk relates to the manifest.
+type FileStatus int
+
+const (
+	// Missing means the file does not exist on disk.
+	Missing FileStatus = iota
+	// Unchanged means the file matches the hash Launchpad last wrote.
+	Unchanged
+	// Edited means Launchpad wrote the file but its content has since changed.
+	Edited
+	// Unmanaged means the file exists but Launchpad never generated it.
+	Unmanaged
+)
+
+// New returns an empty manifest for the given project.
+func New(projectName string) *Manifest {
+	return &Manifest{
+		Version:     currentVersion,
+		ProjectName: projectName,
+		Files:       make(map[string]FileEntry),
+	}
+}
+
+// Load reads the manifest under root. It returns (nil, nil) when the project
+// has never been generated into.
+func Load(root string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(root, Path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]FileEntry)
+	}
+	return &m, nil
+}
+
+// Save writes the manifest under root, creating .launchpad/ if needed.
+func (m *Manifest) Save(root string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	full := filepath.Join(root, Path)
+	if err := sandbox.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return fmt.Errorf("create manifest dir: %w", err)
+	}
+	if err := sandbox.WriteFile(full, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
+
+// Record stores the hash of content as the generated state of path.
+func (m *Manifest) Record(path string, content []byte) {
+	m.Files[filepath.ToSlash(path)] = FileEntry{SHA256: Hash(content)}
+}
+
+// Status compares the file at root/path against the recorded hash.
+func (m *Manifest) Status(root, path string) (FileStatus, error) {
+	data, err := os.ReadFile(filepath.Join(root, path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Missing, nil
+	}
+	if err != nil {
+		return Missing, fmt.Errorf("read %s: %w", path, err)
+	}
+	if m == nil {
+		return Unmanaged, nil
+	}
+	entry, ok := m.Files[filepath.ToSlash(path)]
+	if !ok {
+		return Unmanaged, nil
+	}
+	if entry.SHA256 != Hash(data) {
+		return Edited, nil
+	}
+	return Unchanged, nil
+}
+
+// Hash returns the hex-encoded SHA-256 of content.
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// WriteBase stores the pristine generated content of path.
+func WriteBase(root, path string, content []byte) error {
+	full := filepath.Join(root, BaseDir, path)
+	if err := sandbox.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return fmt.Errorf("create base dir: %w", err)
+	}
+	if err := sandbox.WriteFile(full, content, 0o644); err != nil {
+		return fmt.Errorf("write base %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadBase returns the pristine generated content of path, or (nil, nil)
+// when no base copy was kept.
+func ReadBase(root, path string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(root, BaseDir, path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read base %s: %w", path, err)
+	}
+	return data, nil
+}
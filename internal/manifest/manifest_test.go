@@ -0,0 +1,142 @@
+package manifest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, content string) {
+		t.Helper()
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := New("demo")
+	write("AGENTS.md", "generated\n")
+	m.Record("AGENTS.md", []byte("generated\n"))
+	write(".github/copilot-instructions.md", "generated\n")
+	m.Record(".github/copilot-instructions.md", []byte("generated\n"))
+	write(".github/copilot-instructions.md", "generated\nplus my rule\n")
+	write("README.md", "mine\n")
+
+	tests := []struct {
+		path string
+		want FileStatus
+	}{
+		{"AGENTS.md", Unchanged},
+		{".github/copilot-instructions.md", Edited},
+		{"README.md", Unmanaged},
+		{".github/prompts/start.prompt.md", Missing},
+	}
+	for _, tt := range tests {
+		got, err := m.Status(root, tt.path)
+		if err != nil {
+			t.Fatalf("Status(%q): %v", tt.path, err)
+		}
+		if got != tt.want {
+			t.Errorf("Status(%q) = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestStatus_NilManifest(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "AGENTS.md"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var m *Manifest
+	got, err := m.Status(root, "AGENTS.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != Unmanaged {
+		t.Errorf("Status = %d, want Unmanaged", got)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	m := New("demo")
+	m.ProfileID = "go-service"
+	m.Targets = []string{"copilot", "cursor"}
+	m.Language = "de"
+	m.Tone = Tone{Formality: "formal"}
+	m.Packages = []Package{{Path: "apps/api", ProfileID: "go-service"}}
+	m.Record("AGENTS.md", []byte("hello"))
+	if err := m.Save(root); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.ProfileID != "go-service" || got.Files["AGENTS.md"].SHA256 != Hash([]byte("hello")) {
+		t.Errorf("round trip mismatch: %+v", got)
+	}
+	if len(got.Targets) != 2 || got.Language != "de" || got.Tone.Formality != "formal" || len(got.Packages) != 1 {
+		t.Errorf("settings didn't round trip: %+v", got)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	m, err := Load(t.TempDir())
+	if err != nil || m != nil {
+		t.Fatalf("Load on empty dir = (%v, %v), want (nil, nil)", m, err)
+	}
+}
+
+func TestBaseRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	if got, err := ReadBase(root, "AGENTS.md"); err != nil || got != nil {
+		t.Fatalf("ReadBase before write = (%q, %v), want (nil, nil)", got, err)
+	}
+	if err := WriteBase(root, ".github/copilot-instructions.md", []byte("base")); err != nil {
+		t.Fatalf("WriteBase: %v", err)
+	}
+	got, err := ReadBase(root, ".github/copilot-instructions.md")
+	if err != nil || string(got) != "base" {
+		t.Errorf("ReadBase = (%q, %v), want (\"base\", nil)", got, err)
+	}
+}
+
+func TestWriteCapabilities(t *testing.T) {
+	root := t.TempDir()
+	m := New("demo")
+	m.ProfileID = "go-service"
+	m.Record("AGENTS.md", []byte("a"))
+	m.Record(".github/copilot-instructions.md", []byte("b"))
+
+	if err := WriteCapabilities(root, m); err != nil {
+		t.Fatalf("WriteCapabilities: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(root, CapabilitiesPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var caps Capabilities
+	if err := json.Unmarshal(data, &caps); err != nil {
+		t.Fatal(err)
+	}
+	if caps.ProfileID != "go-service" || caps.ProjectName != "demo" {
+		t.Errorf("caps = %+v", caps)
+	}
+	if len(caps.Files) != 2 || caps.Files[0] != ".github/copilot-instructions.md" {
+		t.Errorf("files = %v, want sorted paths", caps.Files)
+	}
+	var ids []string
+	for _, c := range caps.Commands {
+		ids = append(ids, c.ID)
+	}
+	if len(ids) != 3 || ids[0] != "update" || ids[1] != "validate" || ids[2] != "undo" {
+		t.Errorf("commands = %v", ids)
+	}
+}
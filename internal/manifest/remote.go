@@ -0,0 +1,80 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/store"
+)
+
+// stateDir is the part of the project Push and Pull move.
+const stateDir = ".launchpad/"
+
+// synced reports whether key is launchpad state that belongs in a remote
+// store. Backups hold the user's own files, the project config is checked
+// in with the code, and debug sessions are saved on their own.
+func synced(key string) bool {
+	for _, local := range []string{".launchpad/backup/", ".launchpad/debug/", ".launchpad/config.json"} {
+		if strings.HasPrefix(key, local) {
+			return false
+		}
+	}
+	return true
+}
+
+// Push mirrors the project's launchpad state under root — the manifest,
+// base copies, capabilities, and run snapshots — into st, deleting
+// anything st holds that root no longer does, such as pruned runs. It
+// returns how many files were uploaded.
+func Push(ctx context.Context, st store.Store, root string) (int, error) {
+	local := store.Dir(root)
+	n, err := store.Copy(ctx, st, local, stateDir, synced)
+	if err != nil {
+		return n, fmt.Errorf("push state: %w", err)
+	}
+	have, err := local.List(ctx, stateDir)
+	if err != nil {
+		return n, fmt.Errorf("push state: %w", err)
+	}
+	kept := make(map[string]bool, len(have))
+	for _, k := range have {
+		kept[k] = true
+	}
+	remote, err := st.List(ctx, stateDir)
+	if err != nil {
+		return n, fmt.Errorf("push state: %w", err)
+	}
+	for _, k := range remote {
+		if synced(k) && !kept[k] {
+			if err := st.Delete(ctx, k); err != nil {
+				return n, fmt.Errorf("push state: delete %s: %w", k, err)
+			}
+		}
+	}
+	return n, nil
+}
+
+// Pull restores the state Push stored in st into root, so a fresh checkout
+// or CI runner continues from the last run. It does nothing when root
+// already has a manifest or st has none, and returns how many files were
+// downloaded.
+func Pull(ctx context.Context, st store.Store, root string) (int, error) {
+	if _, err := os.Stat(filepath.Join(root, Path)); err == nil {
+		return 0, nil
+	}
+	if _, err := st.Get(ctx, Path); errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("pull state: %w", err)
+	}
+	n, err := store.Copy(ctx, store.Dir(root), st, stateDir, synced)
+	if err != nil {
+		return n, fmt.Errorf("pull state: %w", err)
+	}
+	return n, nil
+}
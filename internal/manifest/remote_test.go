@@ -0,0 +1,56 @@
+package manifest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/store"
+)
+
+func TestPushPull(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	m := New("app")
+	m.Record("AGENTS.md", []byte("agents\n"))
+	if err := m.Save(root); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteBase(root, "AGENTS.md", []byte("agents\n")); err != nil {
+		t.Fatal(err)
+	}
+	local := store.Dir(root)
+	local.Put(ctx, ".launchpad/backup/20260101-000000/AGENTS.md", []byte("mine"))
+	local.Put(ctx, ".launchpad/config.json", []byte("{}"))
+
+	remote := store.Dir(t.TempDir())
+	remote.Put(ctx, ".launchpad/runs/0001/run.json", []byte("pruned"))
+	if n, err := Push(ctx, remote, root); err != nil || n != 2 {
+		t.Fatalf("Push = %d, %v; want the manifest and base copy", n, err)
+	}
+	if _, err := remote.Get(ctx, ".launchpad/runs/0001/run.json"); err == nil {
+		t.Error("Push should drop remote state the project no longer has")
+	}
+	if _, err := remote.Get(ctx, ".launchpad/backup/20260101-000000/AGENTS.md"); err == nil {
+		t.Error("backups of the user's files must stay local")
+	}
+
+	fresh := t.TempDir()
+	if n, err := Pull(ctx, remote, fresh); err != nil || n != 2 {
+		t.Fatalf("Pull = %d, %v", n, err)
+	}
+	got, err := Load(fresh)
+	if err != nil || got == nil || got.ProjectName != "app" {
+		t.Fatalf("Load after Pull = %v, %v", got, err)
+	}
+	if base, _ := ReadBase(fresh, "AGENTS.md"); string(base) != "agents\n" {
+		t.Errorf("base = %q", base)
+	}
+
+	// A project with its own manifest is left alone.
+	os.WriteFile(filepath.Join(fresh, BaseDir, "AGENTS.md"), []byte("edited\n"), 0o644)
+	if n, err := Pull(ctx, remote, fresh); err != nil || n != 0 {
+		t.Errorf("Pull over existing state = %d, %v; want 0", n, err)
+	}
+}
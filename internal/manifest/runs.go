@@ -0,0 +1,242 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/sandbox"
+)
+
+// RunsDir keeps a snapshot of the project's generated files after each run,
+// relative to the project root.
+const RunsDir = ".launchpad/runs"
+
+// MaxRuns is how many snapshots are kept; older ones are pruned.
+const MaxRuns = 20
+
+// runFile describes a snapshot; the generated files and their base copies
+// sit beside it under files/ and base/.
+const runFile = "run.json"
+
+// currentFile names the run the project's files currently match.
+const currentFile = "current"
+
+// NoteGenerate marks snapshots taken after a generation run, as opposed to
+// the safety snapshots Restore takes.
+const NoteGenerate = "generate"
+
+// Run is one snapshot of the generated files.
+type Run struct {
+	ID         string    `json:"id"`
+	At         time.Time `json:"at"`
+	Note       string    `json:"note"` // what produced the snapshot: init, undo, ...
+	Manifest   *Manifest `json:"manifest"`
+	Restorable []string  `json:"restorable"` // manifest paths present on disk at snapshot time
+}
+
+// Snapshot copies every file m tracks, as it is on disk now, together with
+// its base copy, into a new run. Files the user deleted are left out.
+func Snapshot(root string, m *Manifest, note string) (*Run, error) {
+	runs, err := Runs(root)
+	if err != nil {
+		return nil, err
+	}
+	next := 1
+	if len(runs) > 0 {
+		last, _ := strconv.Atoi(runs[len(runs)-1].ID)
+		next = last + 1
+	}
+	run := &Run{ID: fmt.Sprintf("%04d", next), At: time.Now().UTC(), Note: note, Manifest: m}
+	dir := filepath.Join(root, RunsDir, run.ID)
+
+	paths := make([]string, 0, len(m.Files))
+	for p := range m.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		data, err := os.ReadFile(filepath.Join(root, p))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", p, err)
+		}
+		if err := writeFile(filepath.Join(dir, "files", p), data); err != nil {
+			return nil, err
+		}
+		if base, err := ReadBase(root, p); err != nil {
+			return nil, err
+		} else if base != nil {
+			if err := writeFile(filepath.Join(dir, "base", p), base); err != nil {
+				return nil, err
+			}
+		}
+		run.Restorable = append(run.Restorable, p)
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal run: %w", err)
+	}
+	if err := writeFile(filepath.Join(dir, runFile), append(data, '\n')); err != nil {
+		return nil, err
+	}
+	if err := setCurrent(root, run.ID); err != nil {
+		return nil, err
+	}
+	return run, prune(root, append(runs, *run))
+}
+
+// Runs lists the snapshots under root, oldest first.
+func Runs(root string) ([]Run, error) {
+	entries, err := os.ReadDir(filepath.Join(root, RunsDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read runs: %w", err)
+	}
+	var runs []Run
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, RunsDir, e.Name(), runFile))
+		if err != nil {
+			continue // half-written snapshot; ignore it
+		}
+		var r Run
+		if err := json.Unmarshal(data, &r); err != nil || r.Manifest == nil {
+			continue
+		}
+		runs = append(runs, r)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].ID < runs[j].ID })
+	return runs, nil
+}
+
+// Restore puts the project's generated files back the way run id left them.
+// The current state is snapshotted first, so a restore can itself be undone.
+// Generated files the run didn't have are removed. It returns the paths
+// written and removed.
+func Restore(root, id string) (written, removed []string, err error) {
+	runs, err := Runs(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	var target *Run
+	for i := range runs {
+		if runs[i].ID == id {
+			target = &runs[i]
+		}
+	}
+	if target == nil {
+		return nil, nil, fmt.Errorf("no run %q in %s", id, RunsDir)
+	}
+
+	current, err := Load(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if current != nil {
+		if _, err := Snapshot(root, current, "before restoring "+id); err != nil {
+			return nil, nil, err
+		}
+		for p := range current.Files {
+			if _, keep := target.Manifest.Files[p]; keep {
+				continue
+			}
+			if err := sandbox.Remove(filepath.Join(root, p)); err == nil {
+				removed = append(removed, p)
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return nil, nil, fmt.Errorf("remove %s: %w", p, err)
+			}
+			sandbox.Remove(filepath.Join(root, BaseDir, p))
+		}
+	}
+
+	dir := filepath.Join(root, RunsDir, id)
+	for _, p := range target.Restorable {
+		data, err := os.ReadFile(filepath.Join(dir, "files", p))
+		if err != nil {
+			return nil, nil, fmt.Errorf("read snapshot of %s: %w", p, err)
+		}
+		if err := writeFile(filepath.Join(root, p), data); err != nil {
+			return nil, nil, err
+		}
+		if base, err := os.ReadFile(filepath.Join(dir, "base", p)); err == nil {
+			if err := WriteBase(root, p, base); err != nil {
+				return nil, nil, err
+			}
+		}
+		written = append(written, p)
+	}
+	if err := target.Manifest.Save(root); err != nil {
+		return nil, nil, err
+	}
+	if err := setCurrent(root, id); err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(removed)
+	return written, removed, nil
+}
+
+// Current returns the ID of the run the project's files were last set to,
+// by generation or by Restore, or "" when there are no runs.
+func Current(root string) string {
+	data, err := os.ReadFile(filepath.Join(root, RunsDir, currentFile))
+	if err != nil {
+		return ""
+	}
+	return string(bytes.TrimSpace(data))
+}
+
+// Previous returns the latest generation run older than the current one —
+// what undo goes back to — or nil when there is none.
+func Previous(root string) (*Run, error) {
+	runs, err := Runs(root)
+	if err != nil {
+		return nil, err
+	}
+	current := Current(root)
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].ID < current && runs[i].Note == NoteGenerate {
+			return &runs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func setCurrent(root, id string) error {
+	return writeFile(filepath.Join(root, RunsDir, currentFile), []byte(id+"\n"))
+}
+
+// prune drops the oldest snapshots beyond MaxRuns.
+func prune(root string, runs []Run) error {
+	for len(runs) > MaxRuns {
+		if err := sandbox.RemoveAll(filepath.Join(root, RunsDir, runs[0].ID)); err != nil {
+			return fmt.Errorf("prune run %s: %w", runs[0].ID, err)
+		}
+		runs = runs[1:]
+	}
+	return nil
+}
+
+func writeFile(path string, data []byte) error {
+	if err := sandbox.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+	}
+	if err := sandbox.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// generate simulates a run: writes files, records them, saves the manifest,
+// and snapshots the result.
+func generate(t *testing.T, root string, files map[string]string) *Run {
+	t.Helper()
+	m := New("demo")
+	for p, c := range files {
+		full := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(c), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteBase(root, p, []byte(c)); err != nil {
+			t.Fatal(err)
+		}
+		m.Record(p, []byte(c))
+	}
+	if err := m.Save(root); err != nil {
+		t.Fatal(err)
+	}
+	run, err := Snapshot(root, m, NoteGenerate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return run
+}
+
+func read(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRestore(t *testing.T) {
+	root := t.TempDir()
+	first := generate(t, root, map[string]string{"AGENTS.md": "v1", ".github/copilot-instructions.md": "std v1"})
+	generate(t, root, map[string]string{"AGENTS.md": "v2", ".github/instructions/go.instructions.md": "go"})
+
+	written, removed, err := Restore(root, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 2 || len(removed) != 1 || removed[0] != ".github/instructions/go.instructions.md" {
+		t.Errorf("written %v, removed %v", written, removed)
+	}
+	if got := read(t, filepath.Join(root, "AGENTS.md")); got != "v1" {
+		t.Errorf("AGENTS.md = %q, want v1", got)
+	}
+	if got := read(t, filepath.Join(root, ".github/copilot-instructions.md")); got != "std v1" {
+		t.Errorf("copilot-instructions = %q", got)
+	}
+	if base, _ := ReadBase(root, "AGENTS.md"); string(base) != "v1" {
+		t.Errorf("base = %q, want v1", base)
+	}
+	m, _ := Load(root)
+	if status, _ := m.Status(root, "AGENTS.md"); status != Unchanged {
+		t.Errorf("status after restore = %d, want Unchanged", status)
+	}
+
+	// The restore snapshotted the state it replaced, so it can be undone.
+	runs, err := Runs(root)
+	if err != nil || len(runs) != 3 || runs[2].Note != "before restoring 0001" {
+		t.Fatalf("runs = %+v, %v", runs, err)
+	}
+	if _, _, err := Restore(root, runs[2].ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(t, filepath.Join(root, "AGENTS.md")); got != "v2" {
+		t.Errorf("AGENTS.md after redo = %q, want v2", got)
+	}
+}
+
+func TestPrevious(t *testing.T) {
+	root := t.TempDir()
+	if prev, err := Previous(root); err != nil || prev != nil {
+		t.Fatalf("Previous with no runs = %v, %v", prev, err)
+	}
+	generate(t, root, map[string]string{"AGENTS.md": "v1"})
+	generate(t, root, map[string]string{"AGENTS.md": "v2"})
+	generate(t, root, map[string]string{"AGENTS.md": "v3"})
+
+	// Undoing twice walks back through generations, skipping the safety
+	// snapshots each restore takes.
+	for _, want := range []string{"0002", "0001"} {
+		prev, err := Previous(root)
+		if err != nil || prev == nil || prev.ID != want {
+			t.Fatalf("Previous = %+v, %v, want %s", prev, err, want)
+		}
+		if _, _, err := Restore(root, prev.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := read(t, filepath.Join(root, "AGENTS.md")); got != "v1" {
+		t.Errorf("AGENTS.md = %q, want v1", got)
+	}
+	if prev, _ := Previous(root); prev != nil {
+		t.Errorf("Previous at the first run = %s, want nil", prev.ID)
+	}
+}
+
+func TestRestore_Unknown(t *testing.T) {
+	if _, _, err := Restore(t.TempDir(), "0042"); err == nil {
+		t.Error("expected error for unknown run")
+	}
+}
+
+func TestSnapshot_Prunes(t *testing.T) {
+	root := t.TempDir()
+	for i := 0; i < MaxRuns+3; i++ {
+		generate(t, root, map[string]string{"AGENTS.md": "x"})
+	}
+	runs, err := Runs(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != MaxRuns || runs[0].ID != "0004" {
+		t.Errorf("kept %d runs starting at %s", len(runs), runs[0].ID)
+	}
+}
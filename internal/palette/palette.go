@@ -0,0 +1,208 @@
+// Package palette reads the seed tokens of palette assets and checks that
+// the colors meant to be read against each other have enough contrast.
+package palette
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// tokenLine is a seed token in a palette template: "- Accent: `#6366f1`".
+	tokenLine = regexp.MustCompile("^- (.+?):\\s*`([^`]+)`\\s*$")
+	hexColor  = regexp.MustCompile(`#[0-9a-fA-F]{6}\b`)
+	modeLabel = regexp.MustCompile(`\((light|dark)\)`)
+)
+
+// Token is one seed token: a named color, or the stops of a gradient.
+type Token struct {
+	Label  string
+	Colors []string // #rrggbb
+}
+
+// Tokens returns the seed tokens of a palette template that name a color.
+// Values without a hex color, like an rgba() glow, are skipped.
+func Tokens(template string) []Token {
+	var out []Token
+	for _, line := range strings.Split(template, "\n") {
+		m := tokenLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		if colors := hexColor.FindAllString(m[2], -1); len(colors) > 0 {
+			out = append(out, Token{Label: strings.TrimSpace(strings.ReplaceAll(m[1], "`", "")), Colors: colors})
+		}
+	}
+	return out
+}
+
+// WCAG 2.2 level AA minimums: body text, and large text or the parts of a
+// control someone has to see (SC 1.4.3 and 1.4.11).
+const (
+	MinText = 4.5
+	MinUI   = 3.0
+)
+
+// Contrast returns the WCAG contrast ratio of two #rrggbb colors, from 1
+// (identical) to 21 (black on white).
+func Contrast(a, b string) float64 {
+	la, lb := luminance(a), luminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// Issue is a foreground token that falls short of its minimum contrast on
+// one of the backgrounds it will be drawn on.
+type Issue struct {
+	Foreground, Background Token
+	Ratio, Min             float64
+	// Suggest is the nearest color, along the line toward black or white,
+	// that passes on every background the foreground is checked against;
+	// empty when none does.
+	Suggest string
+}
+
+func (i Issue) String() string {
+	use := "text"
+	if i.Min < MinText {
+		use = "large text and controls"
+	}
+	s := fmt.Sprintf("%s (%s) on %s (%s) is %.1f:1; WCAG AA needs %.1f:1 for %s",
+		i.Foreground.Label, i.Foreground.Colors[0], i.Background.Label, i.Background.Colors[0], i.Ratio, i.Min, use)
+	if i.Suggest != "" {
+		s += " — " + i.Suggest + " would pass"
+	}
+	return s
+}
+
+// Check pairs each foreground token with the backgrounds it will sit on and
+// returns one issue per foreground that fails, naming its worst background.
+// Tokens are sorted by label: backgrounds and surfaces are backgrounds; text
+// and foregrounds must reach MinText; other solid colors (accents, status
+// colors) must reach MinUI; borders, glows, and gradients are decorative and
+// skipped. A "(light)" or "(dark)" label only meets backgrounds of that mode.
+// A palette that names no background is checked against white and black.
+func Check(tokens []Token) []Issue {
+	var bgs, fgs []Token
+	for _, t := range tokens {
+		label := strings.ToLower(t.Label)
+		switch {
+		case len(t.Colors) != 1:
+		case strings.Contains(label, "background") || strings.Contains(label, "surface"):
+			bgs = append(bgs, t)
+		case strings.Contains(label, "border") || strings.Contains(label, "glow") || strings.Contains(label, "shadow"):
+		default:
+			fgs = append(fgs, t)
+		}
+	}
+	if len(bgs) == 0 {
+		bgs = []Token{{Label: "white (light)", Colors: []string{"#ffffff"}}, {Label: "black (dark)", Colors: []string{"#000000"}}}
+	}
+
+	var issues []Issue
+	for _, fg := range fgs {
+		label := strings.ToLower(fg.Label)
+		minimum := MinUI
+		if strings.Contains(label, "text") || strings.Contains(label, "foreground") {
+			minimum = MinText
+		}
+		var on []string
+		worst := Issue{Foreground: fg, Ratio: math.Inf(1), Min: minimum}
+		for _, bg := range bgs {
+			if !sameMode(fg, bg) {
+				continue
+			}
+			on = append(on, bg.Colors[0])
+			if r := Contrast(fg.Colors[0], bg.Colors[0]); r < worst.Ratio {
+				worst.Ratio, worst.Background = r, bg
+			}
+		}
+		if len(on) == 0 || worst.Ratio >= minimum {
+			continue
+		}
+		worst.Suggest = adjust(fg.Colors[0], worst.Background.Colors[0], on, minimum)
+		issues = append(issues, worst)
+	}
+	return issues
+}
+
+// mode returns "light" or "dark" for a token whose label says so, and for
+// a background, otherwise, from how light it is. Foregrounds without a
+// label are "" and meet every background.
+func mode(t Token, background bool) string {
+	if m := modeLabel.FindStringSubmatch(strings.ToLower(t.Label)); m != nil {
+		return m[1]
+	}
+	if !background {
+		return ""
+	}
+	if luminance(t.Colors[0]) > 0.5 {
+		return "light"
+	}
+	return "dark"
+}
+
+func sameMode(fg, bg Token) bool {
+	m := mode(fg, false)
+	return m == "" || m == mode(bg, true)
+}
+
+// adjust moves fg toward black on a light background and toward white on a
+// dark one, a step at a time, until it reaches minimum on every color in
+// on. It returns "" when no step does.
+func adjust(fg, worst string, on []string, minimum float64) string {
+	target := [3]float64{255, 255, 255}
+	if luminance(worst) > luminance(fg) {
+		target = [3]float64{}
+	}
+	from := rgb(fg)
+	for step := 1; step <= 100; step++ {
+		t := float64(step) / 100
+		var c [3]float64
+		for i := range c {
+			c[i] = math.Round(from[i] + (target[i]-from[i])*t)
+		}
+		hex := fmt.Sprintf("#%02x%02x%02x", int(c[0]), int(c[1]), int(c[2]))
+		ok := true
+		for _, bg := range on {
+			if Contrast(hex, bg) < minimum {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return hex
+		}
+	}
+	return ""
+}
+
+// rgb returns the channels of a #rrggbb color, 0–255.
+func rgb(hex string) [3]float64 {
+	var c [3]float64
+	for i := range c {
+		v, _ := strconv.ParseUint(hex[1+2*i:3+2*i], 16, 8)
+		c[i] = float64(v)
+	}
+	return c
+}
+
+// luminance is the WCAG relative luminance of a #rrggbb color.
+func luminance(hex string) float64 {
+	c := rgb(hex)
+	var lin [3]float64
+	for i, v := range c {
+		v /= 255
+		if v <= 0.04045 {
+			lin[i] = v / 12.92
+		} else {
+			lin[i] = math.Pow((v+0.055)/1.055, 2.4)
+		}
+	}
+	return 0.2126*lin[0] + 0.7152*lin[1] + 0.0722*lin[2]
+}
@@ -0,0 +1,89 @@
+package palette
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTokens(t *testing.T) {
+	tmpl := "## Seed Tokens\n\n" +
+		"- Accent: `#6366f1`\n" +
+		"- Primary `500`: `#006fee`\n" +
+		"- Glow: `rgba(99, 102, 241, 0.35)`\n" +
+		"- Gradient: `linear-gradient(135deg, #6366f1, #8B5CF6)`\n" +
+		"Text mentioning `#ffffff` outside a token\n"
+	got := Tokens(tmpl)
+	if len(got) != 3 {
+		t.Fatalf("got %d tokens, want 3: %+v", len(got), got)
+	}
+	if got[0].Label != "Accent" || len(got[0].Colors) != 1 || got[0].Colors[0] != "#6366f1" {
+		t.Errorf("accent = %+v", got[0])
+	}
+	if got[1].Label != "Primary 500" {
+		t.Errorf("label = %q, want backticks stripped", got[1].Label)
+	}
+	if got[2].Label != "Gradient" || strings.Join(got[2].Colors, ",") != "#6366f1,#8B5CF6" {
+		t.Errorf("gradient = %+v", got[2])
+	}
+}
+
+func TestContrast(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"#000000", "#ffffff", 21},
+		{"#ffffff", "#ffffff", 1},
+		{"#767676", "#ffffff", 4.54},
+	}
+	for _, tt := range tests {
+		if got := Contrast(tt.a, tt.b); math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("Contrast(%s, %s) = %.2f, want %.2f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tokens := []Token{
+		{Label: "Background", Colors: []string{"#0f0f0f"}},
+		{Label: "Surface", Colors: []string{"#242424"}},
+		{Label: "Border", Colors: []string{"#2a2a2a"}},
+		{Label: "Text", Colors: []string{"#e4e4e7"}},
+		{Label: "Text muted", Colors: []string{"#71717a"}},
+		{Label: "Accent", Colors: []string{"#6366f1"}},
+		{Label: "Gradient", Colors: []string{"#6366f1", "#8b5cf6"}},
+	}
+	issues := Check(tokens)
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1: %v", len(issues), issues)
+	}
+	got := issues[0]
+	if got.Foreground.Label != "Text muted" || got.Background.Label != "Surface" || got.Min != MinText {
+		t.Errorf("issue = %v", got)
+	}
+	if got.Suggest == "" {
+		t.Fatal("no suggestion")
+	}
+	for _, bg := range []string{"#0f0f0f", "#242424"} {
+		if r := Contrast(got.Suggest, bg); r < MinText {
+			t.Errorf("suggested %s is %.2f:1 on %s", got.Suggest, r, bg)
+		}
+	}
+}
+
+func TestCheckModes(t *testing.T) {
+	// No backgrounds: white and black stand in, and a labelled mode only
+	// meets its own.
+	issues := Check([]Token{
+		{Label: "Foreground (light)", Colors: []string{"#11181c"}},
+		{Label: "Foreground (dark)", Colors: []string{"#ecedee"}},
+		{Label: "Warning", Colors: []string{"#f5a524"}},
+	})
+	if len(issues) != 1 || issues[0].Foreground.Label != "Warning" || issues[0].Background.Colors[0] != "#ffffff" {
+		t.Fatalf("issues = %v, want only Warning on white", issues)
+	}
+	if !strings.Contains(issues[0].String(), "large text and controls") {
+		t.Errorf("String() = %q", issues[0].String())
+	}
+}
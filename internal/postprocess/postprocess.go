@@ -0,0 +1,575 @@
+// Package postprocess provides the built-in post-processors run on generated
+// files, and an adapter that runs an external command as one.
+package postprocess
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+)
+
+// Built-in processor names, usable in a config's disable_builtins.
+const (
+	NameFrontmatter  = "frontmatter"
+	NamePlaceholders = "placeholders"
+	NameMarkdown     = "markdown"
+	NameProvenance   = "provenance"
+)
+
+// Builtins returns the built-in processors in run order, skipping any named
+// in disable. vars are the {{key}} substitutions for the placeholder step;
+// version is the launchpad version stamped by the provenance step.
+func Builtins(vars map[string]string, disable []string, version string) ([]ai.PostProcessor, error) {
+	all := []ai.PostProcessor{Frontmatter{}, Placeholders{Vars: vars}, Provenance{Version: version}, Markdown{}}
+	skip := make(map[string]bool, len(disable))
+	for _, name := range disable {
+		known := false
+		for _, p := range all {
+			known = known || p.Name() == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown built-in post-processor %q (known: %s, %s, %s, %s)",
+				name, NameFrontmatter, NamePlaceholders, NameProvenance, NameMarkdown)
+		}
+		skip[name] = true
+	}
+	var out []ai.PostProcessor
+	for _, p := range all {
+		if !skip[p.Name()] {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// Frontmatter repairs the mechanical frontmatter mistakes models make in
+// instruction and prompt files: a wrapping code fence, blank lines before
+// the opening ---, and unquoted applyTo globs, which YAML reads as aliases.
+type Frontmatter struct{}
+
+func (Frontmatter) Name() string { return NameFrontmatter }
+
+var unquotedApplyTo = regexp.MustCompile(`(?m)^applyTo:[ \t]*([^"'\s][^\n]*?)[ \t]*$`)
+
+func (Frontmatter) Process(files []ai.FileOutput, _ *ai.Selection) ([]ai.FileOutput, error) {
+	out := make([]ai.FileOutput, len(files))
+	for i, f := range files {
+		out[i] = f
+		if !strings.HasSuffix(f.Path, ".instructions.md") && !strings.HasSuffix(f.Path, ".prompt.md") {
+			continue
+		}
+		content := unfence(f.Content)
+		content = strings.TrimLeft(content, "\ufeff \t\n")
+		if !strings.HasPrefix(content, "---\n") {
+			out[i].Content = content
+			continue
+		}
+		end := strings.Index(content[4:], "\n---")
+		if end == -1 {
+			out[i].Content = content
+			continue
+		}
+		head := unquotedApplyTo.ReplaceAllString(content[:4+end], `applyTo: "$1"`)
+		out[i].Content = head + content[4+end:]
+	}
+	return out, nil
+}
+
+// unfence removes a code fence wrapped around an entire file.
+func unfence(content string) string {
+	trimmed := strings.TrimSpace(content)
+	if !strings.HasPrefix(trimmed, "```") || !strings.HasSuffix(trimmed, "```") {
+		return content
+	}
+	first := strings.Index(trimmed, "\n")
+	if first == -1 {
+		return content
+	}
+	inner := strings.TrimSuffix(trimmed[first+1:], "```")
+	if strings.Contains(inner, "\n```") {
+		return content // the file has fences of its own; leave it be
+	}
+	return inner
+}
+
+// Placeholders substitutes {{key}} for each configured key. Unknown keys are
+// left untouched, since templating syntax like Jinja's is legitimate content.
+type Placeholders struct {
+	Vars map[string]string
+}
+
+func (Placeholders) Name() string { return NamePlaceholders }
+
+func (p Placeholders) Process(files []ai.FileOutput, _ *ai.Selection) ([]ai.FileOutput, error) {
+	if len(p.Vars) == 0 {
+		return files, nil
+	}
+	keys := make([]string, 0, len(p.Vars))
+	for k := range p.Vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, k := range keys {
+		pairs = append(pairs, "{{"+k+"}}", p.Vars[k], "{{ "+k+" }}", p.Vars[k])
+	}
+	r := strings.NewReplacer(pairs...)
+	out := make([]ai.FileOutput, len(files))
+	for i, f := range files {
+		out[i] = ai.FileOutput{Path: f.Path, Content: r.Replace(f.Content)}
+	}
+	return out, nil
+}
+
+// Provenance stamps markdown files with an HTML comment saying which
+// launchpad version, templates, and selection produced them, and how to
+// regenerate them. The stamp goes after any frontmatter, which tools expect
+// on the first line, and replaces an earlier stamp rather than stacking.
+// It holds no timestamp, so identical inputs still produce identical files.
+type Provenance struct {
+	Version string
+}
+
+func (Provenance) Name() string { return NameProvenance }
+
+// provenanceMarker opens every stamp; provenanceStamp matches a whole one.
+const provenanceMarker = "<!-- Generated by launchpad"
+
+var provenanceStamp = regexp.MustCompile(`(?s)` + regexp.QuoteMeta(provenanceMarker) + `.*?-->\n*`)
+
+func (p Provenance) Process(files []ai.FileOutput, sel *ai.Selection) ([]ai.FileOutput, error) {
+	if sel == nil {
+		return files, nil
+	}
+	templates, err := templateVersion(*sel)
+	if err != nil {
+		return nil, err
+	}
+	version := p.Version
+	if version == "" {
+		version = "dev"
+	}
+	stamp := fmt.Sprintf("%s %s · templates %s · selection %s\n     Regenerate with: launchpad regen -->\n",
+		provenanceMarker, version, templates, selectionHash(*sel))
+
+	out := make([]ai.FileOutput, len(files))
+	for i, f := range files {
+		out[i] = f
+		if !strings.HasSuffix(f.Path, ".md") && !strings.HasSuffix(f.Path, ".mdc") {
+			continue
+		}
+		content := provenanceStamp.ReplaceAllString(f.Content, "")
+		head, body := "", content
+		if strings.HasPrefix(content, "---\n") {
+			if end := strings.Index(content[4:], "\n---\n"); end != -1 {
+				head, body = content[:4+end+5], content[4+end+5:]
+			}
+		}
+		out[i].Content = head + stamp + "\n" + strings.TrimLeft(body, "\n")
+	}
+	return out, nil
+}
+
+// templateVersion condenses the hashes of every template behind sel.
+func templateVersion(sel ai.Selection) (string, error) {
+	hashes, err := ai.TemplateHashes(sel)
+	if err != nil {
+		return "", err
+	}
+	ids := make([]string, 0, len(hashes))
+	for id := range hashes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	h := sha256.New()
+	for _, id := range ids {
+		fmt.Fprintf(h, "%s=%s\n", id, hashes[id])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12], nil
+}
+
+// selectionHash identifies what was selected, ignoring the model's
+// confidence and rationale, which vary between otherwise identical runs.
+func selectionHash(sel ai.Selection) string {
+	h := sha256.New()
+	for _, list := range [][]string{{sel.ProfileID}, sel.AddonIDs, sel.AssetIDs, sel.Agents} {
+		sorted := append([]string(nil), list...)
+		sort.Strings(sorted)
+		fmt.Fprintf(h, "%s\n", strings.Join(sorted, ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// Markdown normalizes whitespace in markdown files: no trailing spaces
+// outside code blocks, at most one blank line in a row, and exactly one
+// newline at the end.
+type Markdown struct{}
+
+func (Markdown) Name() string { return NameMarkdown }
+
+func (Markdown) Process(files []ai.FileOutput, _ *ai.Selection) ([]ai.FileOutput, error) {
+	out := make([]ai.FileOutput, len(files))
+	for i, f := range files {
+		out[i] = f
+		if strings.HasSuffix(f.Path, ".md") || strings.HasSuffix(f.Path, ".mdc") {
+			out[i].Content = normalizeMarkdown(f.Content)
+		}
+	}
+	return out, nil
+}
+
+func normalizeMarkdown(content string) string {
+	var lines []string
+	inFence, blank := false, 0
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if inFence {
+			lines = append(lines, line)
+			blank = 0
+			continue
+		}
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blank++
+			if blank > 1 {
+				continue
+			}
+		} else {
+			blank = 0
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")) + "\n"
+}
+
+// Rebase rewrites workspace-relative references for files generated into a
+// subdirectory of a larger repository. Copilot matches applyTo globs and
+// resolves #file: references against the workspace root, so both get the
+// subdirectory's path prepended.
+type Rebase struct {
+	Prefix string // slash-separated path from the repository root
+}
+
+func (Rebase) Name() string { return "rebase" }
+
+var (
+	applyToLine = regexp.MustCompile(`(?m)^applyTo:[ \t]*(["']?)([^"'\n]*)(["']?)[ \t]*$`)
+	fileRef     = regexp.MustCompile(`#file:([^\s)\]]+)`)
+)
+
+func (r Rebase) Process(files []ai.FileOutput, _ *ai.Selection) ([]ai.FileOutput, error) {
+	prefix := strings.Trim(r.Prefix, "/")
+	if prefix == "" || prefix == "." {
+		return files, nil
+	}
+	out := make([]ai.FileOutput, len(files))
+	for i, f := range files {
+		out[i] = f
+		switch {
+		case strings.HasSuffix(f.Path, ".instructions.md"):
+			out[i].Content = applyToLine.ReplaceAllStringFunc(f.Content, func(line string) string {
+				m := applyToLine.FindStringSubmatch(line)
+				return "applyTo: " + m[1] + rebaseGlob(prefix, m[2]) + m[3]
+			})
+		case strings.HasSuffix(f.Path, ".prompt.md"):
+			out[i].Content = fileRef.ReplaceAllStringFunc(f.Content, func(ref string) string {
+				target := strings.TrimPrefix(strings.TrimPrefix(ref, "#file:"), "./")
+				if strings.HasPrefix(target, prefix+"/") || strings.HasPrefix(target, "../") {
+					return ref
+				}
+				return "#file:" + prefix + "/" + target
+			})
+		}
+	}
+	return out, nil
+}
+
+// rebaseGlob prefixes every comma-separated pattern in glob. Commas inside
+// braces belong to the pattern, not the list.
+func rebaseGlob(prefix, glob string) string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range glob {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, glob[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, glob[start:])
+	for i, p := range parts {
+		p = strings.TrimPrefix(strings.TrimSpace(p), "./")
+		if !strings.HasPrefix(p, prefix+"/") {
+			p = prefix + "/" + p
+		}
+		parts[i] = p
+	}
+	return strings.Join(parts, ",")
+}
+
+// Inherit folds an instruction file that is new since the previous run into
+// an existing one with the same applyTo glob, as a delimited section, rather
+// than adding a second file that competes for the same sources. Re-running
+// with an extra asset then extends the instruction set instead of forking it.
+type Inherit struct {
+	Existing []string // paths the previous run generated
+}
+
+func (Inherit) Name() string { return "inherit" }
+
+// Markers around a section folded in by Inherit; the %s is the folded file's
+// name without its .instructions.md suffix.
+const (
+	inheritOpen  = "<!-- launchpad:inherit %s -->"
+	inheritClose = "<!-- /launchpad:inherit %s -->"
+)
+
+func (in Inherit) Process(files []ai.FileOutput, _ *ai.Selection) ([]ai.FileOutput, error) {
+	existing := make(map[string]bool, len(in.Existing))
+	for _, p := range in.Existing {
+		existing[p] = true
+	}
+	owner := make(map[string]int) // applyTo glob → index of the existing file
+	for i, f := range files {
+		if glob, ok := applyTo(f); ok && existing[f.Path] {
+			if _, taken := owner[glob]; !taken {
+				owner[glob] = i
+			}
+		}
+	}
+	if len(owner) == 0 {
+		return files, nil
+	}
+
+	out := make([]ai.FileOutput, len(files))
+	copy(out, files)
+	folded := make(map[int]bool)
+	for i, f := range files {
+		glob, ok := applyTo(f)
+		if !ok || existing[f.Path] {
+			continue
+		}
+		target, ok := owner[glob]
+		if !ok {
+			continue
+		}
+		out[target].Content = strings.TrimRight(out[target].Content, "\n") + "\n\n" + inheritSection(f) + "\n"
+		folded[i] = true
+	}
+	kept := out[:0]
+	for i, f := range out {
+		if !folded[i] {
+			kept = append(kept, f)
+		}
+	}
+	return kept, nil
+}
+
+// applyTo returns the applyTo glob of an instruction file.
+func applyTo(f ai.FileOutput) (string, bool) {
+	if !strings.HasSuffix(f.Path, ".instructions.md") {
+		return "", false
+	}
+	m := applyToLine.FindStringSubmatch(f.Content)
+	if m == nil || strings.TrimSpace(m[2]) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(m[2]), true
+}
+
+// inheritSection renders f as a section of another file: frontmatter
+// dropped, its title (or name) as an H2, and its own headings one level down.
+func inheritSection(f ai.FileOutput) string {
+	id := strings.TrimSuffix(path.Base(f.Path), ".instructions.md")
+	body := strings.TrimLeft(f.Content, "\n")
+	if strings.HasPrefix(body, "---\n") {
+		if end := strings.Index(body[4:], "\n---"); end != -1 {
+			body = body[4+end+len("\n---"):]
+		}
+	}
+	body = strings.TrimSpace(body)
+	title := id
+	if strings.HasPrefix(body, "# ") {
+		line, rest, _ := strings.Cut(body, "\n")
+		title, body = strings.TrimSpace(line[2:]), strings.TrimSpace(rest)
+	}
+
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence && strings.HasPrefix(line, "#") && strings.Trim(line[:strings.IndexByte(line+" ", ' ')], "#") == "" {
+			lines[i] = "#" + line
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, inheritOpen+"\n", id)
+	fmt.Fprintf(&sb, "## %s\n\n", title)
+	if body != "" {
+		sb.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+	fmt.Fprintf(&sb, inheritClose, id)
+	return sb.String()
+}
+
+// commandTimeout bounds an external processor so a hung script can't stall
+// init forever.
+const commandTimeout = 30 * time.Second
+
+// Command runs an external program as a post-processor. The program reads
+// {"selection": …, "files": [{"path", "content"}]} on stdin and writes
+// {"files": [...]} on stdout; the files it prints replace the input.
+type Command struct {
+	Label string
+	Args  []string
+	Dir   string // working directory, usually the project
+}
+
+func (c Command) Name() string { return c.Label }
+
+type wireFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+type wirePayload struct {
+	Selection *ai.Selection `json:"selection,omitempty"`
+	Files     []wireFile    `json:"files"`
+}
+
+func (c Command) Process(files []ai.FileOutput, sel *ai.Selection) ([]ai.FileOutput, error) {
+	in := wirePayload{Selection: sel}
+	for _, f := range files {
+		in.Files = append(in.Files, wireFile{Path: f.Path, Content: f.Content})
+	}
+	stdin, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, c.Args[0], c.Args[1:]...)
+	cmd.Dir = c.Dir
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	var result wirePayload
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("parse output: %w", err)
+	}
+	out := make([]ai.FileOutput, len(result.Files))
+	for i, f := range result.Files {
+		out[i] = ai.FileOutput{Path: f.Path, Content: f.Content}
+	}
+	return out, nil
+}
+
+// Layout moves generated files to the paths an organization's repositories
+// use instead of the GitHub defaults, and rewrites references to the moved
+// paths in every file. As an ai.Relocator it runs after the other
+// processors and the target rendering, so both still see the default paths.
+type Layout struct {
+	Paths map[string]string // generated path → path to write; keys ending in / move a directory
+}
+
+func (Layout) Name() string { return "layout" }
+
+func (Layout) Relocates() {}
+
+// Path returns where a file generated at p is written. The longest
+// matching entry wins.
+func (l Layout) Path(p string) string {
+	return remap(l.Paths, p)
+}
+
+// Original returns the path a file written at p was generated at: the
+// inverse of Path.
+func (l Layout) Original(p string) string {
+	inverse := make(map[string]string, len(l.Paths))
+	for from, to := range l.Paths {
+		inverse[to] = from
+	}
+	return remap(inverse, p)
+}
+
+func remap(paths map[string]string, p string) string {
+	best := ""
+	for from := range paths {
+		matches := from == p || strings.HasSuffix(from, "/") && strings.HasPrefix(p, from)
+		if matches && len(from) > len(best) {
+			best = from
+		}
+	}
+	if best == "" {
+		return p
+	}
+	return paths[best] + strings.TrimPrefix(p, best)
+}
+
+func (l Layout) Process(files []ai.FileOutput, _ *ai.Selection) ([]ai.FileOutput, error) {
+	if len(l.Paths) == 0 {
+		return files, nil
+	}
+	var pairs [][2]string
+	owner := make(map[string]string, len(files))
+	out := make([]ai.FileOutput, len(files))
+	for i, f := range files {
+		out[i] = f
+		out[i].Path = l.Path(f.Path)
+		if prev, taken := owner[out[i].Path]; taken {
+			return nil, fmt.Errorf("layout writes both %s and %s to %s", prev, f.Path, out[i].Path)
+		}
+		owner[out[i].Path] = f.Path
+		if out[i].Path != f.Path {
+			pairs = append(pairs, [2]string{f.Path, out[i].Path})
+		}
+	}
+	if len(pairs) == 0 {
+		return out, nil
+	}
+	// Longest paths first, so a file's full path is rewritten before a
+	// shorter one that is a suffix of it.
+	sort.Slice(pairs, func(i, j int) bool { return len(pairs[i][0]) > len(pairs[j][0]) })
+	var args []string
+	for _, p := range pairs {
+		args = append(args, p[0], p[1])
+	}
+	refs := strings.NewReplacer(args...)
+	for i := range out {
+		if strings.HasSuffix(out[i].Path, ".md") {
+			out[i].Content = refs.Replace(out[i].Content)
+		}
+	}
+	return out, nil
+}
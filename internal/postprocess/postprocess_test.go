@@ -0,0 +1,233 @@
+package postprocess
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/ai"
+)
+
+func TestFrontmatter(t *testing.T) {
+	tests := []struct {
+		name, path, in, want string
+	}{
+		{
+			name: "unquoted applyTo",
+			path: ".github/instructions/go.instructions.md",
+			in:   "---\napplyTo: **/*.go\n---\n# Go\n",
+			want: "---\napplyTo: \"**/*.go\"\n---\n# Go\n",
+		},
+		{
+			name: "wrapping fence and leading blank lines",
+			path: ".github/prompts/start.prompt.md",
+			in:   "```markdown\n\n---\nmode: agent\n---\nGo.\n```",
+			want: "---\nmode: agent\n---\nGo.\n",
+		},
+		{
+			name: "quoted applyTo untouched",
+			path: ".github/instructions/go.instructions.md",
+			in:   "---\napplyTo: '**'\n---\n",
+			want: "---\napplyTo: '**'\n---\n",
+		},
+		{
+			name: "other files untouched",
+			path: "AGENTS.md",
+			in:   "\n---\napplyTo: **\n---\n",
+			want: "\n---\napplyTo: **\n---\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Frontmatter{}.Process([]ai.FileOutput{{Path: tt.path, Content: tt.in}}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out[0].Content != tt.want {
+				t.Errorf("got %q, want %q", out[0].Content, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	p := Placeholders{Vars: map[string]string{"name": "demo", "org": "Acme"}}
+	out, _ := p.Process([]ai.FileOutput{{Path: "AGENTS.md", Content: "{{name}} by {{ org }}; {{ user.name }} stays"}}, nil)
+	if want := "demo by Acme; {{ user.name }} stays"; out[0].Content != want {
+		t.Errorf("got %q, want %q", out[0].Content, want)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	in := "# Title  \n\n\n\nText\t\n```\ncode  \n\n\n```\n\n\n"
+	out, _ := Markdown{}.Process([]ai.FileOutput{{Path: "AGENTS.md", Content: in}, {Path: "x.json", Content: "{}  "}}, nil)
+	if want := "# Title\n\nText\n```\ncode  \n\n\n```\n"; out[0].Content != want {
+		t.Errorf("got %q, want %q", out[0].Content, want)
+	}
+	if out[1].Content != "{}  " {
+		t.Errorf("non-markdown file changed: %q", out[1].Content)
+	}
+}
+
+func TestBuiltins(t *testing.T) {
+	procs, err := Builtins(nil, []string{NameMarkdown}, "v1.0.0")
+	if err != nil || len(procs) != 3 || procs[1].Name() != NamePlaceholders || procs[2].Name() != NameProvenance {
+		t.Errorf("Builtins = %v, %v", procs, err)
+	}
+	if _, err := Builtins(nil, []string{"prettier"}, ""); err == nil {
+		t.Error("expected error for unknown built-in")
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if _, err := exec.LookPath("cat"); err != nil {
+		t.Skip("cat not available")
+	}
+	files := []ai.FileOutput{{Path: "AGENTS.md", Content: "# Agents\n"}}
+	out, err := Command{Label: "echo", Args: []string{"cat"}}.Process(files, &ai.Selection{ProfileID: "go-service"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0] != files[0] {
+		t.Errorf("got %v", out)
+	}
+	if _, err := (Command{Label: "fail", Args: []string{"false"}}).Process(files, nil); err == nil {
+		t.Error("expected error from failing command")
+	}
+}
+
+func TestRebase(t *testing.T) {
+	files := []ai.FileOutput{
+		{Path: ".github/instructions/go.instructions.md", Content: "---\napplyTo: \"**/*.{go,mod},cmd/**\"\n---\n# Go\n"},
+		{Path: ".github/instructions/all.instructions.md", Content: "---\napplyTo: '**'\n---\n"},
+		{Path: ".github/prompts/start.prompt.md", Content: "See #file:README.md and #file:./docs/plan.md."},
+		{Path: "AGENTS.md", Content: "applyTo: **"},
+	}
+	out, err := Rebase{Prefix: "services/api"}.Process(files, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"---\napplyTo: \"services/api/**/*.{go,mod},services/api/cmd/**\"\n---\n# Go\n",
+		"---\napplyTo: 'services/api/**'\n---\n",
+		"See #file:services/api/README.md and #file:services/api/docs/plan.md.",
+		"applyTo: **",
+	}
+	for i, w := range want {
+		if out[i].Content != w {
+			t.Errorf("%s = %q, want %q", out[i].Path, out[i].Content, w)
+		}
+	}
+
+	// Running twice, or at the repository root, changes nothing.
+	again, _ := Rebase{Prefix: "services/api"}.Process(out, nil)
+	if again[0].Content != want[0] {
+		t.Errorf("not idempotent: %q", again[0].Content)
+	}
+	same, _ := Rebase{Prefix: "."}.Process(files, nil)
+	if same[0].Content != files[0].Content {
+		t.Errorf("root prefix rewrote %q", same[0].Content)
+	}
+}
+
+func TestInherit(t *testing.T) {
+	files := []ai.FileOutput{
+		{Path: ".github/instructions/go-service.instructions.md", Content: "---\napplyTo: \"**/*.go\"\n---\n# Go Service\n\n- wrap errors\n"},
+		{Path: ".github/instructions/cli.instructions.md", Content: "---\napplyTo: '**/*.go'\n---\n# CLI\n\n## Flags\n\n```sh\n# not a heading\n```\n"},
+		{Path: ".github/instructions/privacy.instructions.md", Content: "---\napplyTo: \"**/*.sql\"\n---\n# Privacy\n"},
+	}
+	out, err := Inherit{Existing: []string{".github/instructions/go-service.instructions.md"}}.Process(files, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 || out[1].Path != files[2].Path {
+		t.Fatalf("got %d files, want cli folded into go-service and privacy kept", len(out))
+	}
+	want := "---\napplyTo: \"**/*.go\"\n---\n# Go Service\n\n- wrap errors\n\n" +
+		"<!-- launchpad:inherit cli -->\n## CLI\n\n### Flags\n\n```sh\n# not a heading\n```\n<!-- /launchpad:inherit cli -->\n"
+	if out[0].Content != want {
+		t.Errorf("folded file =\n%s\nwant\n%s", out[0].Content, want)
+	}
+
+	// Without a previous run nothing is folded.
+	first, _ := Inherit{}.Process(files, nil)
+	if len(first) != len(files) {
+		t.Errorf("first run folded files: %d of %d left", len(first), len(files))
+	}
+}
+
+func TestProvenance(t *testing.T) {
+	sel := &ai.Selection{ProfileID: "go-service", AssetIDs: []string{"asset.app.cli"}}
+	files := []ai.FileOutput{
+		{Path: ".github/instructions/go.instructions.md", Content: "---\napplyTo: \"**/*.go\"\n---\n# Go\n"},
+		{Path: "AGENTS.md", Content: "# Agents\n"},
+		{Path: ".rules", Content: "plain"},
+	}
+	out, err := Provenance{Version: "v1.2.0"}.Process(files, sel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scoped := out[0].Content
+	if !strings.HasPrefix(scoped, "---\napplyTo: \"**/*.go\"\n---\n<!-- Generated by launchpad v1.2.0 · templates ") {
+		t.Errorf("stamp should follow the frontmatter:\n%s", scoped)
+	}
+	if !strings.Contains(scoped, "Regenerate with: launchpad regen -->\n\n# Go\n") {
+		t.Errorf("stamp should end before the body:\n%s", scoped)
+	}
+	if !strings.HasPrefix(out[1].Content, "<!-- Generated by launchpad") || out[2].Content != "plain" {
+		t.Errorf("unexpected stamping: %q, %q", out[1].Content, out[2].Content)
+	}
+
+	again, err := Provenance{Version: "v1.2.0"}.Process(out, sel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again[0].Content != scoped {
+		t.Errorf("restamping changed the file:\n%s", again[0].Content)
+	}
+
+	other, _ := Provenance{Version: "v1.2.0"}.Process(files, &ai.Selection{ProfileID: "rust-axum"})
+	if other[1].Content == out[1].Content {
+		t.Error("a different selection should change the stamp")
+	}
+	if same, _ := (Provenance{}).Process(files, nil); same[1].Content != files[1].Content {
+		t.Error("no selection should leave files alone")
+	}
+}
+
+func TestLayout(t *testing.T) {
+	l := Layout{Paths: map[string]string{
+		".github/instructions/": "docs/ai/",
+		"AGENTS.md":             "AGENT_GUIDE.md",
+	}}
+	files := []ai.FileOutput{
+		{Path: "AGENTS.md", Content: "# Agents\n"},
+		{Path: ".github/copilot-instructions.md", Content: "See AGENTS.md and .github/instructions/go.instructions.md.\n"},
+		{Path: ".github/instructions/go.instructions.md", Content: "---\napplyTo: \"**/*.go\"\n---\n"},
+	}
+	out, err := l.Process(files, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPaths := []string{"AGENT_GUIDE.md", ".github/copilot-instructions.md", "docs/ai/go.instructions.md"}
+	for i, w := range wantPaths {
+		if out[i].Path != w {
+			t.Errorf("path %d = %s, want %s", i, out[i].Path, w)
+		}
+	}
+	if want := "See AGENT_GUIDE.md and docs/ai/go.instructions.md.\n"; out[1].Content != want {
+		t.Errorf("references = %q, want %q", out[1].Content, want)
+	}
+	if got := l.Original("docs/ai/go.instructions.md"); got != ".github/instructions/go.instructions.md" {
+		t.Errorf("Original = %s", got)
+	}
+
+	if _, ok := any(l).(ai.Relocator); !ok {
+		t.Error("layout should run after targets are rendered")
+	}
+
+	clash := Layout{Paths: map[string]string{"AGENTS.md": "GUIDE.md", "CLAUDE.md": "GUIDE.md"}}
+	if _, err := clash.Process([]ai.FileOutput{{Path: "AGENTS.md"}, {Path: "CLAUDE.md"}}, nil); err == nil {
+		t.Error("expected an error when two files land on one path")
+	}
+}
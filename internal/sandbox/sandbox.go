@@ -0,0 +1,187 @@
+// Package sandbox confines launchpad's file writes. Once Confine is called,
+// every write, directory creation, rename, or removal made through this
+// package must land inside the project directory or launchpad's own config
+// and cache directories; anything else is refused with ErrOutside.
+//
+// Callers still validate paths themselves — generated paths are sanitized
+// and joined under the project root long before they get here. The sandbox
+// is the guarantee that holds when one of those checks is missed.
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrOutside is returned for a path that resolves outside every allowed root.
+var ErrOutside = errors.New("outside the sandbox")
+
+var (
+	mu       sync.RWMutex
+	roots    []string
+	confined bool
+)
+
+// Confine limits writes to dirs plus launchpad's config and cache
+// directories. A later call replaces the earlier set. Until Confine is
+// called, writes are unrestricted, which keeps library callers and tests
+// working without setup.
+func Confine(dirs ...string) error {
+	allowed := append([]string(nil), dirs...)
+	allowed = append(allowed, OwnDirs()...)
+	resolved := make([]string, 0, len(allowed))
+	for _, d := range allowed {
+		abs, err := filepath.Abs(d)
+		if err != nil {
+			return fmt.Errorf("sandbox root %s: %w", d, err)
+		}
+		resolved = append(resolved, resolve(abs))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	roots, confined = resolved, true
+	return nil
+}
+
+// Allow adds dir to the allowed roots of a confined sandbox. It does
+// nothing while writes are unrestricted.
+func Allow(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("sandbox root %s: %w", dir, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if confined {
+		roots = append(roots, resolve(abs))
+	}
+	return nil
+}
+
+// Release lifts confinement.
+func Release() {
+	mu.Lock()
+	defer mu.Unlock()
+	roots, confined = nil, false
+}
+
+// Roots returns the allowed roots, or nil when writes are unrestricted.
+func Roots() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]string(nil), roots...)
+}
+
+// OwnDirs returns launchpad's per-user config and cache directories. Either
+// is omitted when the platform can't locate it.
+func OwnDirs() []string {
+	var dirs []string
+	if dir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(dir, "launchpad"))
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		dirs = append(dirs, filepath.Join(dir, "launchpad"))
+	}
+	return dirs
+}
+
+// Check returns an error wrapping ErrOutside when path resolves outside the
+// allowed roots. Symlinks in the existing part of the path are followed, so
+// a link inside the project can't be used to write elsewhere.
+func Check(path string) error {
+	mu.RLock()
+	defer mu.RUnlock()
+	if !confined {
+		return nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", path, err)
+	}
+	target := resolve(abs)
+	for _, root := range roots {
+		if within(root, target) {
+			return nil
+		}
+	}
+	return fmt.Errorf("refusing to write %s: %w", path, ErrOutside)
+}
+
+// resolve evaluates symlinks in the longest existing prefix of abs and
+// re-attaches the part that doesn't exist yet.
+func resolve(abs string) string {
+	existing, rest := abs, ""
+	for {
+		if real, err := filepath.EvalSymlinks(existing); err == nil {
+			return filepath.Join(real, rest)
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return abs
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+}
+
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// WriteFile is os.WriteFile behind Check.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	if err := Check(path); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, perm)
+}
+
+// MkdirAll is os.MkdirAll behind Check.
+func MkdirAll(path string, perm os.FileMode) error {
+	if err := Check(path); err != nil {
+		return err
+	}
+	return os.MkdirAll(path, perm)
+}
+
+// OpenFile is os.OpenFile behind Check. Read-only opens are not checked.
+func OpenFile(path string, flag int, perm os.FileMode) (*os.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0 {
+		if err := Check(path); err != nil {
+			return nil, err
+		}
+	}
+	return os.OpenFile(path, flag, perm)
+}
+
+// Rename is os.Rename with both ends behind Check.
+func Rename(from, to string) error {
+	if err := Check(from); err != nil {
+		return err
+	}
+	if err := Check(to); err != nil {
+		return err
+	}
+	return os.Rename(from, to)
+}
+
+// Remove is os.Remove behind Check.
+func Remove(path string) error {
+	if err := Check(path); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// RemoveAll is os.RemoveAll behind Check.
+func RemoveAll(path string) error {
+	if err := Check(path); err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
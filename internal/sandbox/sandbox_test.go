@@ -0,0 +1,74 @@
+package sandbox
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	project := t.TempDir()
+	outside := t.TempDir()
+	if err := Confine(project); err != nil {
+		t.Fatal(err)
+	}
+	defer Release()
+
+	tests := []struct {
+		name string
+		path string
+		ok   bool
+	}{
+		{"project root", project, true},
+		{"nested new file", filepath.Join(project, ".launchpad", "backup", "x", "AGENTS.md"), true},
+		{"dot-dot escape", filepath.Join(project, "..", filepath.Base(outside), "f"), false},
+		{"sibling dir", filepath.Join(outside, "f"), false},
+		{"prefix lookalike", project + "-evil/f", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.path)
+			if tt.ok && err != nil {
+				t.Errorf("Check(%s) = %v, want nil", tt.path, err)
+			}
+			if !tt.ok && !errors.Is(err, ErrOutside) {
+				t.Errorf("Check(%s) = %v, want ErrOutside", tt.path, err)
+			}
+		})
+	}
+}
+
+func TestCheckFollowsSymlinks(t *testing.T) {
+	project := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(project, "link")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	if err := Confine(project); err != nil {
+		t.Fatal(err)
+	}
+	defer Release()
+
+	err := WriteFile(filepath.Join(project, "link", "escaped.md"), []byte("x"), 0o644)
+	if !errors.Is(err, ErrOutside) {
+		t.Fatalf("write through symlink = %v, want ErrOutside", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "escaped.md")); err == nil {
+		t.Error("file was written outside the project")
+	}
+}
+
+func TestUnconfined(t *testing.T) {
+	Release()
+	path := filepath.Join(t.TempDir(), "a", "b.txt")
+	if err := MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(path, []byte("ok"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if Roots() != nil {
+		t.Errorf("Roots() = %v, want nil", Roots())
+	}
+}
@@ -8,7 +8,7 @@ type Profile struct {
 	Dir         string // directory name inside templates/profiles/
 	ScaffoldCmd string // CLI command the framework provides to bootstrap a project
 	UseCase     string // what kind of projects this is best for
-	Layer       string // architectural role: coordination, worker, enterprise, ai-boundary, web-ui, mobile-ui, rapid-product
+	Layer       string // architectural role: coordination, worker, enterprise, ai-boundary, web-ui, mobile-ui, desktop-ui, platform, data, game, rapid-product
 	HasUI       bool   // whether this profile includes a user interface surface
 	Tier        int    // 1 = canonical coherence set, 2 = additional supported stacks
 }
@@ -144,6 +144,17 @@ var Profiles = []Profile{
 		HasUI:       true,
 		Tier:        2,
 	},
+	{
+		ID:          "typescript-nuxt",
+		Title:       "TypeScript + Nuxt",
+		Summary:     "Vue ecosystem full-stack — file-based routing, auto-imports, Nitro server",
+		Dir:         "typescript-nuxt",
+		ScaffoldCmd: "npx nuxi init {{name}}",
+		UseCase:     "Vue teams, full-stack web apps and content sites built on the Vue ecosystem",
+		Layer:       "web-ui",
+		HasUI:       true,
+		Tier:        2,
+	},
 	{
 		ID:          "typescript-fastify",
 		Title:       "TypeScript + Fastify",
@@ -177,6 +188,139 @@ var Profiles = []Profile{
 		HasUI:       true,
 		Tier:        2,
 	},
+	{
+		ID:      "android-compose",
+		Title:   "Android + Jetpack Compose",
+		Summary: "Native Android in Kotlin — Compose UI, coroutines, unidirectional data flow",
+		Dir:     "android-compose",
+		UseCase: "Android-only apps, teams that want native Kotlin over a cross-platform toolkit",
+		Layer:   "mobile-ui",
+		HasUI:   true,
+		Tier:    2,
+	},
+	{
+		ID:      "ios-swiftui",
+		Title:   "iOS + SwiftUI",
+		Summary: "Native iOS in Swift — SwiftUI views, Observation, structured concurrency",
+		Dir:     "ios-swiftui",
+		UseCase: "iPhone and iPad apps, teams that want native Swift over a cross-platform toolkit",
+		Layer:   "mobile-ui",
+		HasUI:   true,
+		Tier:    2,
+	},
+	{
+		ID:      "cpp-service",
+		Title:   "C++ Service (CMake)",
+		Summary: "Modern C++20 services — CMake presets, RAII everywhere, sanitizers in CI",
+		Dir:     "cpp-service",
+		UseCase: "Systems teams on C++, latency-sensitive services, code sharing with existing C++ libraries",
+		Layer:   "worker",
+		Tier:    2,
+	},
+	{
+		ID:          "platform-infra",
+		Title:       "Platform/Infra (Terraform + Kubernetes)",
+		Summary:     "Infrastructure as code — Terraform modules and state, Kustomize manifests, GitOps delivery",
+		Dir:         "platform-infra",
+		ScaffoldCmd: "terraform init",
+		UseCase:     "Platform teams provisioning cloud infrastructure and clusters that other teams deploy to",
+		Layer:       "platform",
+		Tier:        2,
+	},
+	{
+		ID:          "data-dbt",
+		Title:       "Data Engineering (dbt + Airflow/Dagster)",
+		Summary:     "Analytics pipelines — layered dbt models, contracts and data tests, orchestrated assets",
+		Dir:         "data-dbt",
+		ScaffoldCmd: "dbt init {{name}}",
+		UseCase:     "Warehouse pipelines, analytics engineering, scheduled ELT feeding dashboards and ML",
+		Layer:       "data",
+		Tier:        2,
+	},
+	{
+		ID:          "python-ml",
+		Title:       "Python ML Research (notebooks)",
+		Summary:     "Reproducible ML research — tidy notebooks, configured and tracked experiments, code promoted to a package",
+		Dir:         "python-ml",
+		ScaffoldCmd: "uv init --package {{name}}",
+		UseCase:     "Researchers and data scientists training and evaluating models in notebooks",
+		Layer:       "data",
+		Tier:        2,
+	},
+	{
+		ID:      "godot",
+		Title:   "Godot (GDScript / C#)",
+		Summary: "Godot 4 games — composable scenes, signals up and calls down, typed GDScript or C#",
+		Dir:     "godot",
+		UseCase: "Hobbyist and indie game developers building 2D or 3D games",
+		Layer:   "game",
+		Tier:    2,
+	},
+	{
+		ID:          "bun-hono",
+		Title:       "Bun + Hono",
+		Summary:     "Lightweight TypeScript APIs on Bun — Hono routing, Zod-validated typed routes",
+		Dir:         "bun-hono",
+		ScaffoldCmd: "bun create hono@latest {{name}}",
+		UseCase:     "Small, fast TypeScript APIs and BFFs, services that may later move to the edge",
+		Layer:       "worker",
+		Tier:        2,
+	},
+	{
+		ID:          "deno-fresh",
+		Title:       "Deno + Fresh",
+		Summary:     "Server-rendered web on Deno — islands architecture, Preact, zero build step",
+		Dir:         "deno-fresh",
+		ScaffoldCmd: "deno run -A -r https://fresh.deno.dev {{name}}",
+		UseCase:     "Teams standardizing on Deno, content-heavy sites with islands of interactivity",
+		Layer:       "web-ui",
+		HasUI:       true,
+		Tier:        2,
+	},
+	{
+		ID:          "react-native-expo",
+		Title:       "React Native + Expo",
+		Summary:     "Cross-platform mobile in TypeScript — Expo Router, EAS Build and Update",
+		Dir:         "react-native-expo",
+		ScaffoldCmd: "npx create-expo-app@latest {{name}}",
+		UseCase:     "Mobile apps for teams with existing React expertise",
+		Layer:       "mobile-ui",
+		HasUI:       true,
+		Tier:        2,
+	},
+	{
+		ID:          "electron",
+		Title:       "Electron",
+		Summary:     "Desktop apps in TypeScript — isolated renderer, typed preload bridge, Electron Forge packaging",
+		Dir:         "electron",
+		ScaffoldCmd: "npx create-electron-app@latest {{name}} --template=vite-typescript",
+		UseCase:     "Cross-platform desktop apps, web teams shipping an installable client",
+		Layer:       "desktop-ui",
+		HasUI:       true,
+		Tier:        2,
+	},
+	{
+		ID:          "browser-extension",
+		Title:       "Browser Extension (Manifest V3)",
+		Summary:     "Cross-browser MV3 extensions — event-driven service worker, isolated content scripts, typed messaging",
+		Dir:         "browser-extension",
+		ScaffoldCmd: "npm create wxt@latest {{name}}",
+		UseCase:     "Chrome, Edge, and Firefox extensions that add features to the pages people already use",
+		Layer:       "web-ui",
+		HasUI:       true,
+		Tier:        2,
+	},
+	{
+		ID:          "swift-vapor",
+		Title:       "Swift + Vapor",
+		Summary:     "Swift on the server — async/await, Fluent ORM, Codable content",
+		Dir:         "swift-vapor",
+		ScaffoldCmd: "vapor new {{name}}",
+		UseCase:     "Swift teams building APIs, backends shared with iOS/macOS apps",
+		Layer:       "worker",
+		HasUI:       false,
+		Tier:        2,
+	},
 }
 
 // Addons lists every available add-on.
@@ -193,6 +337,84 @@ var Addons = []Addon{
 		Summary: "Visual discipline, component composition, accessibility, and motion — framework agnostic",
 		Dir:     "frontend-craft",
 	},
+	{
+		ID:      "observability",
+		Title:   "Observability",
+		Summary: "OpenTelemetry tracing and metrics, structured logs, health endpoints",
+		Dir:     "observability",
+	},
+	{
+		ID:      "ci-cd",
+		Title:   "CI/CD",
+		Summary: "Lint, test, build, and deploy stages; keeping main green",
+		Dir:     "ci-cd",
+	},
+	{
+		ID:      "iac",
+		Title:   "Infrastructure as Code",
+		Summary: "Terraform or Pulumi, separate environments, plan-reviewed changes, drift checks",
+		Dir:     "iac",
+	},
+	{
+		ID:      "auth",
+		Title:   "Auth",
+		Summary: "Sessions vs tokens, password hashing, OAuth with PKCE, roles and policies",
+		Dir:     "auth",
+	},
+	{
+		ID:      "graphql",
+		Title:   "GraphQL API",
+		Summary: "Client-first schema design, thin resolvers, DataLoader batching, query limits",
+		Dir:     "graphql",
+	},
+	{
+		ID:      "api-design",
+		Title:   "REST API Design",
+		Summary: "Resource naming, status codes, cursor pagination, problem+json errors, OpenAPI in CI",
+		Dir:     "api-design",
+	},
+	{
+		ID:      "a11y",
+		Title:   "Accessibility",
+		Summary: "WCAG 2.2 AA, keyboard navigation, disciplined ARIA, axe in CI plus manual screen reader passes",
+		Dir:     "a11y",
+	},
+	{
+		ID:      "performance",
+		Title:   "Performance Budgets",
+		Summary: "Bundle-size, LCP, and query-latency budgets enforced in CI, plus code-splitting, query analysis, and caching",
+		Dir:     "performance",
+	},
+	{
+		ID:      "multi-tenancy",
+		Title:   "Multi-Tenancy",
+		Summary: "Row-level, schema, or database isolation, tenant-scoped queries, and two-tenant isolation tests",
+		Dir:     "multi-tenancy",
+	},
+	{
+		ID:      "event-sourcing",
+		Title:   "Event Sourcing & CQRS",
+		Summary: "Aggregates that decide with events, rebuildable projections, versioned events with upcasters",
+		Dir:     "event-sourcing",
+	},
+	{
+		ID:      "ai-integration",
+		Title:   "LLM Integration",
+		Summary: "Versioned prompts, schema-validated output, evals in CI, cost controls, streaming UX",
+		Dir:     "ai-integration",
+	},
+	{
+		ID:      "realtime",
+		Title:   "Realtime",
+		Summary: "Channel topology, join authorization, presence, reconnection with resume, bounded queues",
+		Dir:     "realtime",
+	},
+	{
+		ID:      "compliance",
+		Title:   "Compliance",
+		Summary: "GDPR and SOC 2 posture: least-privilege access, audit logging, data retention, change management evidence",
+		Dir:     "compliance",
+	},
 }
 
 // FindProfile returns the profile with the given ID, or nil if not found.
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoKey is returned by a KeyBroker that has no key for a user. The user
+// is refused rather than falling back to a shared key.
+var ErrNoKey = errors.New("no provider key for this user")
+
+// KeyBroker looks up the provider API key a user's sessions are billed to.
+type KeyBroker interface {
+	APIKey(ctx context.Context, user string) (string, error)
+}
+
+// StaticKey gives every user the same key, as when the service runs with
+// its own OPENAI_API_KEY.
+type StaticKey string
+
+func (k StaticKey) APIKey(context.Context, string) (string, error) {
+	if k == "" {
+		return "", ErrNoKey
+	}
+	return string(k), nil
+}
+
+// validUser limits the identities substituted into secret paths and
+// commands to what SSO proxies put in user headers: names and emails.
+var validUser = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._@+-]*$`)
+
+// expand replaces {user} in template, refusing identities that could
+// escape the secret path or be read as a flag.
+func expand(template, user string) (string, error) {
+	if !validUser.MatchString(user) {
+		return "", fmt.Errorf("%w: %q cannot be used in a secret lookup", ErrNoKey, user)
+	}
+	return strings.ReplaceAll(template, "{user}", user), nil
+}
+
+// VaultBroker reads keys from a HashiCorp Vault KV secret per user, such
+// as "secret/data/launchpad/{user}" (KV v2) or "kv/launchpad/{user}" (KV v1).
+type VaultBroker struct {
+	Addr      string // e.g. https://vault.internal:8200
+	Token     string
+	Namespace string // Vault Enterprise namespace, optional
+	Path      string // {user} is replaced with the user's identity
+	Field     string // the secret's field holding the key
+	Client    *http.Client
+}
+
+func (v VaultBroker) APIKey(ctx context.Context, user string) (string, error) {
+	path, err := expand(v.Path, user)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimRight(v.Addr, "/")+"/v1/"+strings.TrimLeft(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+	client := v.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return "", ErrNoKey
+	}
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return "", fmt.Errorf("vault: %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+
+	// KV v2 nests the secret under data.data; KV v1 returns it as data.
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("vault: parse response: %w", err)
+	}
+	fields := secret.Data
+	if nested, ok := secret.Data["data"]; ok {
+		if err := json.Unmarshal(nested, &fields); err != nil {
+			return "", fmt.Errorf("vault: parse secret: %w", err)
+		}
+	}
+	var key string
+	if raw, ok := fields[v.Field]; !ok || json.Unmarshal(raw, &key) != nil || key == "" {
+		return "", ErrNoKey
+	}
+	return key, nil
+}
+
+// CommandBroker runs a program that prints the user's key on stdout, for
+// secret managers reached through their own CLI, e.g.
+//
+//	aws secretsmanager get-secret-value --secret-id launchpad/{user} --query SecretString --output text
+//
+// {user} is replaced in each argument. No shell is involved.
+type CommandBroker struct {
+	Args []string
+}
+
+func (c CommandBroker) APIKey(ctx context.Context, user string) (string, error) {
+	if len(c.Args) == 0 {
+		return "", fmt.Errorf("key command is empty")
+	}
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		var err error
+		if args[i], err = expand(a, user); err != nil {
+			return "", err
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("key command: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("key command: %w", err)
+	}
+	key := strings.TrimSpace(stdout.String())
+	if key == "" {
+		return "", ErrNoKey
+	}
+	return key, nil
+}
+
+// Cached remembers the keys b returns for ttl, so opening a session doesn't
+// reach the secret store every time. Failures are not cached.
+func Cached(b KeyBroker, ttl time.Duration) KeyBroker {
+	return &cachedBroker{broker: b, ttl: ttl, now: time.Now, keys: map[string]cachedKey{}}
+}
+
+type cachedKey struct {
+	key     string
+	fetched time.Time
+}
+
+type cachedBroker struct {
+	broker KeyBroker
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu   sync.Mutex
+	keys map[string]cachedKey
+}
+
+func (c *cachedBroker) APIKey(ctx context.Context, user string) (string, error) {
+	c.mu.Lock()
+	k, ok := c.keys[user]
+	c.mu.Unlock()
+	if ok && c.now().Sub(k.fetched) < c.ttl {
+		return k.key, nil
+	}
+	key, err := c.broker.APIKey(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.keys[user] = cachedKey{key: key, fetched: c.now()}
+	c.mu.Unlock()
+	return key, nil
+}
+
+// keyError maps a broker failure onto a response: users the broker has no
+// key for are refused, anything else is the broker's fault.
+func keyError(err error) (int, string) {
+	if errors.Is(err, ErrNoKey) {
+		return http.StatusForbidden, err.Error()
+	}
+	return http.StatusBadGateway, "fetching provider credentials: " + err.Error()
+}
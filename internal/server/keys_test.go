@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVaultBroker(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/launchpad/ada@example.com":
+			w.Write([]byte(`{"data": {"data": {"openai_api_key": "sk-ada"}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/launchpad/bob":
+			w.Write([]byte(`{"data": {"openai_api_key": "sk-bob"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+
+	v2 := VaultBroker{Addr: vault.URL, Token: "root", Path: "secret/data/launchpad/{user}", Field: "openai_api_key"}
+	if key, err := v2.APIKey(context.Background(), "ada@example.com"); err != nil || key != "sk-ada" {
+		t.Errorf("KV v2 = %q, %v", key, err)
+	}
+	v1 := VaultBroker{Addr: vault.URL, Token: "root", Path: "kv/launchpad/{user}", Field: "openai_api_key"}
+	if key, err := v1.APIKey(context.Background(), "bob"); err != nil || key != "sk-bob" {
+		t.Errorf("KV v1 = %q, %v", key, err)
+	}
+	if _, err := v2.APIKey(context.Background(), "carol"); !errors.Is(err, ErrNoKey) {
+		t.Errorf("missing secret = %v, want ErrNoKey", err)
+	}
+	if _, err := v2.APIKey(context.Background(), "../../sys/policy"); !errors.Is(err, ErrNoKey) {
+		t.Errorf("path traversal = %v, want refused", err)
+	}
+	bad := VaultBroker{Addr: vault.URL, Token: "wrong", Path: v2.Path, Field: v2.Field}
+	if _, err := bad.APIKey(context.Background(), "ada@example.com"); err == nil || errors.Is(err, ErrNoKey) {
+		t.Errorf("bad token = %v, want a broker error", err)
+	}
+}
+
+func TestCommandBroker(t *testing.T) {
+	c := CommandBroker{Args: []string{"echo", "sk-{user}"}}
+	if key, err := c.APIKey(context.Background(), "ada"); err != nil || key != "sk-ada" {
+		t.Errorf("APIKey = %q, %v", key, err)
+	}
+	if _, err := c.APIKey(context.Background(), "-n"); !errors.Is(err, ErrNoKey) {
+		t.Errorf("flag-like user = %v, want refused", err)
+	}
+}
+
+func TestCachedBroker(t *testing.T) {
+	calls := 0
+	c := Cached(brokerFunc(func(user string) (string, error) {
+		calls++
+		return "sk-" + user, nil
+	}), time.Minute).(*cachedBroker)
+	now := time.Unix(0, 0)
+	c.now = func() time.Time { return now }
+
+	for range 3 {
+		c.APIKey(context.Background(), "ada")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 within the TTL", calls)
+	}
+	now = now.Add(2 * time.Minute)
+	c.APIKey(context.Background(), "ada")
+	if calls != 2 {
+		t.Errorf("calls = %d, want a refetch after the TTL", calls)
+	}
+}
@@ -0,0 +1,452 @@
+// Package server hosts launchpad conversations over HTTP, so one instance
+// can serve many people at once. Each session has its own provider thread,
+// its own rate limit, and its own token and cost accounting, and is billed
+// to the key a KeyBroker returns for its user.
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+)
+
+// Metered is implemented by providers that count the tokens they were
+// billed for, like ai.OpenAIProvider.
+type Metered interface {
+	Usage() (input, output int)
+}
+
+// Server keeps the open sessions. The zero value is not usable; call New.
+type Server struct {
+	keys        KeyBroker
+	newProvider func(apiKey string) ai.Provider
+	engineOpts  []ai.EngineOption
+	userHeader  string          // set by the SSO proxy; empty trusts the request body
+	admins      map[string]bool // users whose /usage covers everyone
+	rate        int             // requests per minute per session; 0 is unlimited
+	idle        time.Duration   // sessions unused this long are closed
+	priceIn     float64         // USD per million input tokens
+	priceOut    float64         // USD per million output tokens
+	now         func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithRateLimit caps each session at n model requests per minute.
+func WithRateLimit(n int) Option {
+	return func(s *Server) { s.rate = n }
+}
+
+// WithIdleTimeout closes sessions that go unused for d.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(s *Server) { s.idle = d }
+}
+
+// WithPricing sets the USD price per million input and output tokens used
+// to estimate each session's cost. Without it, only tokens are reported.
+func WithPricing(in, out float64) Option {
+	return func(s *Server) { s.priceIn, s.priceOut = in, out }
+}
+
+// WithEngineOptions applies opts to every session's engine.
+func WithEngineOptions(opts ...ai.EngineOption) Option {
+	return func(s *Server) { s.engineOpts = append(s.engineOpts, opts...) }
+}
+
+// WithUserHeader takes each request's user from header, as set by an SSO
+// proxy in front of the server (e.g. X-Forwarded-Email). Requests without
+// it are refused, and users only see their own sessions.
+func WithUserHeader(header string) Option {
+	return func(s *Server) { s.userHeader = header }
+}
+
+// WithAdmins lets users see every user's totals on GET /usage; everyone
+// else only sees their own. It only matters with WithUserHeader.
+func WithAdmins(users ...string) Option {
+	return func(s *Server) {
+		if s.admins == nil {
+			s.admins = map[string]bool{}
+		}
+		for _, u := range users {
+			s.admins[u] = true
+		}
+	}
+}
+
+// New returns a server that gives every session a provider from
+// newProvider, called with the key keys returns for the session's user.
+// Providers must not share conversational state.
+func New(keys KeyBroker, newProvider func(apiKey string) ai.Provider, opts ...Option) *Server {
+	s := &Server{
+		keys:        keys,
+		newProvider: newProvider,
+		rate:        20,
+		idle:        30 * time.Minute,
+		now:         time.Now,
+		sessions:    map[string]*session{},
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// session is one person's conversation. mu serializes its model calls,
+// since a provider thread can only advance one message at a time, and
+// guards the fields below it. The counters are read without it so listing
+// sessions never waits on a model call.
+type session struct {
+	id       string
+	user     string
+	created  time.Time
+	provider ai.Provider
+	engine   *ai.Engine
+
+	lastUsed atomic.Int64 // unix nanoseconds
+	turns    atomic.Int64 // conversation messages answered
+	calls    atomic.Int64 // messages and generations let through the rate limit
+
+	mu       sync.Mutex
+	requests []time.Time // within the last minute, for the rate limit
+	warnings []string
+}
+
+// Usage is what a session has consumed so far.
+type Usage struct {
+	Requests     int     `json:"requests"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd,omitempty"`
+}
+
+// SessionInfo describes a session in API responses.
+type SessionInfo struct {
+	ID       string    `json:"id"`
+	User     string    `json:"user,omitempty"`
+	Created  time.Time `json:"created"`
+	LastUsed time.Time `json:"last_used"`
+	Usage    Usage     `json:"usage"`
+}
+
+// Handler returns the HTTP API:
+//
+//	POST   /sessions                 start a session; body {"user": "..."} is optional
+//	GET    /sessions                 list sessions and their usage
+//	GET    /sessions/{id}            one session and its usage
+//	DELETE /sessions/{id}            end a session
+//	POST   /sessions/{id}/messages   {"message": "..."} → {"reply", "ready"}
+//	POST   /sessions/{id}/generate   {"project_name": "..."} → {"selection", "files", "warnings"}
+//	GET    /usage                    totals across open sessions
+//
+// With WithUserHeader, every request must carry the header, the session
+// routes only reach the caller's own sessions, and /usage only covers the
+// caller unless they are one of WithAdmins.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /sessions", s.handleCreate)
+	mux.HandleFunc("GET /sessions", s.handleList)
+	mux.HandleFunc("GET /sessions/{id}", s.withSession(s.handleGet))
+	mux.HandleFunc("DELETE /sessions/{id}", s.withSession(s.handleDelete))
+	mux.HandleFunc("POST /sessions/{id}/messages", s.withSession(s.handleMessage))
+	mux.HandleFunc("POST /sessions/{id}/generate", s.withSession(s.handleGenerate))
+	mux.HandleFunc("GET /usage", s.handleUsage)
+	return mux
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		User string `json:"user"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+			return
+		}
+	}
+	if s.userHeader != "" {
+		user, ok := s.authenticated(w, r)
+		if !ok {
+			return
+		}
+		req.User = user
+	}
+	key, err := s.keys.APIKey(r.Context(), req.User)
+	if err != nil {
+		status, msg := keyError(err)
+		writeError(w, status, msg)
+		return
+	}
+	id, err := newID()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	now := s.now()
+	sess := &session{id: id, user: req.User, created: now, provider: s.newProvider(key)}
+	sess.lastUsed.Store(now.UnixNano())
+	opts := append(append([]ai.EngineOption(nil), s.engineOpts...), ai.WithWarningHandler(func(msg string) {
+		sess.warnings = append(sess.warnings, msg)
+	}))
+	sess.engine = ai.NewEngine(sess.provider, opts...)
+
+	s.mu.Lock()
+	s.expireLocked(now)
+	s.sessions[id] = sess
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, s.info(sess))
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	user := ""
+	if s.userHeader != "" {
+		var ok bool
+		if user, ok = s.authenticated(w, r); !ok {
+			return
+		}
+	}
+	s.mu.Lock()
+	s.expireLocked(s.now())
+	list := make([]*session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		if s.userHeader == "" || sess.user == user {
+			list = append(list, sess)
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].created.Before(list[j].created) })
+	infos := make([]SessionInfo, 0, len(list))
+	for _, sess := range list {
+		infos = append(infos, s.info(sess))
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request, sess *session) {
+	writeJSON(w, http.StatusOK, s.info(sess))
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request, sess *session) {
+	s.mu.Lock()
+	delete(s.sessions, sess.id)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.info(sess))
+}
+
+func (s *Server) handleMessage(w http.ResponseWriter, r *http.Request, sess *session) {
+	var req struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Message) == "" {
+		writeError(w, http.StatusBadRequest, `body must be {"message": "..."}`)
+		return
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if !s.allowLocked(w, sess) {
+		return
+	}
+	reply, err := sess.engine.Chat(r.Context(), req.Message)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "conversation error: "+err.Error())
+		return
+	}
+	sess.turns.Add(1)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"reply": strings.TrimSpace(strings.ReplaceAll(reply, ai.ReadyToken, "")),
+		"ready": sess.engine.Ready(r.Context(), reply),
+	})
+}
+
+func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request, sess *session) {
+	var req struct {
+		ProjectName string `json:"project_name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.ProjectName) == "" {
+		writeError(w, http.StatusBadRequest, `body must be {"project_name": "..."}`)
+		return
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.turns.Load() == 0 {
+		writeError(w, http.StatusConflict, "describe the project in a message first")
+		return
+	}
+	if !s.allowLocked(w, sess) {
+		return
+	}
+	sess.warnings = nil
+	sel, err := sess.engine.ExtractDecision(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "extracting decision: "+err.Error())
+		return
+	}
+	files, err := sess.engine.GenerateFiles(r.Context(), req.ProjectName, sel)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "generation error: "+err.Error())
+		return
+	}
+	type file struct {
+		Path    string `json:"path"`
+		Content string `json:"content"`
+	}
+	out := make([]file, 0, len(files))
+	for _, f := range files {
+		out = append(out, file{f.Path, f.Content})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"selection": sel, "files": out, "warnings": sess.warnings})
+}
+
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	all, user := true, ""
+	if s.userHeader != "" {
+		var ok bool
+		if user, ok = s.authenticated(w, r); !ok {
+			return
+		}
+		all = s.admins[user]
+	}
+	s.mu.Lock()
+	s.expireLocked(s.now())
+	list := make([]*session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		if all || sess.user == user {
+			list = append(list, sess)
+		}
+	}
+	s.mu.Unlock()
+	var total Usage
+	byUser := map[string]Usage{}
+	for _, sess := range list {
+		u := s.usage(sess)
+		total = add(total, u)
+		byUser[sess.user] = add(byUser[sess.user], u)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"sessions": len(list), "total": total, "by_user": byUser})
+}
+
+// withSession resolves the {id} path value and marks the session used.
+// Behind an SSO proxy, other users' sessions are reported as missing.
+func (s *Server) withSession(h func(http.ResponseWriter, *http.Request, *session)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := ""
+		if s.userHeader != "" {
+			var ok bool
+			if user, ok = s.authenticated(w, r); !ok {
+				return
+			}
+		}
+		now := s.now()
+		s.mu.Lock()
+		s.expireLocked(now)
+		sess, ok := s.sessions[r.PathValue("id")]
+		s.mu.Unlock()
+		if !ok || s.userHeader != "" && sess.user != user {
+			writeError(w, http.StatusNotFound, "no session "+r.PathValue("id"))
+			return
+		}
+		sess.lastUsed.Store(now.UnixNano())
+		h(w, r, sess)
+	}
+}
+
+// authenticated returns the user the SSO proxy identified, or answers 401
+// and returns false when the header is missing.
+func (s *Server) authenticated(w http.ResponseWriter, r *http.Request) (string, bool) {
+	user := strings.TrimSpace(r.Header.Get(s.userHeader))
+	if user == "" {
+		writeError(w, http.StatusUnauthorized, "missing "+s.userHeader+" header; sign in through the SSO proxy")
+		return "", false
+	}
+	return user, true
+}
+
+// allowLocked records a request against the session's rate limit, or
+// answers 429 and returns false when the limit is reached. sess.mu must be
+// held.
+func (s *Server) allowLocked(w http.ResponseWriter, sess *session) bool {
+	now := s.now()
+	recent := sess.requests[:0]
+	for _, t := range sess.requests {
+		if now.Sub(t) < time.Minute {
+			recent = append(recent, t)
+		}
+	}
+	sess.requests = recent
+	if s.rate > 0 && len(recent) >= s.rate {
+		retry := time.Minute - now.Sub(recent[0])
+		w.Header().Set("Retry-After", fmt.Sprint(int(retry.Seconds())+1))
+		writeError(w, http.StatusTooManyRequests, fmt.Sprintf("session limit of %d requests per minute reached", s.rate))
+		return false
+	}
+	sess.requests = append(sess.requests, now)
+	sess.calls.Add(1)
+	return true
+}
+
+// expireLocked drops sessions idle longer than s.idle. s.mu must be held.
+func (s *Server) expireLocked(now time.Time) {
+	if s.idle <= 0 {
+		return
+	}
+	for id, sess := range s.sessions {
+		if now.Sub(time.Unix(0, sess.lastUsed.Load())) > s.idle {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+func (s *Server) info(sess *session) SessionInfo {
+	return SessionInfo{ID: sess.id, User: sess.user, Created: sess.created,
+		LastUsed: time.Unix(0, sess.lastUsed.Load()), Usage: s.usage(sess)}
+}
+
+// usage reads the session's token counts from its provider. Requests count
+// messages and generations, not the model calls within them.
+func (s *Server) usage(sess *session) Usage {
+	u := Usage{Requests: int(sess.calls.Load())}
+	if m, ok := sess.provider.(Metered); ok {
+		u.InputTokens, u.OutputTokens = m.Usage()
+	}
+	u.CostUSD = (float64(u.InputTokens)*s.priceIn + float64(u.OutputTokens)*s.priceOut) / 1e6
+	return u
+}
+
+func add(a, b Usage) Usage {
+	return Usage{
+		Requests:     a.Requests + b.Requests,
+		InputTokens:  a.InputTokens + b.InputTokens,
+		OutputTokens: a.OutputTokens + b.OutputTokens,
+		CostUSD:      a.CostUSD + b.CostUSD,
+	}
+}
+
+func newID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("session id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
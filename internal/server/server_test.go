@@ -0,0 +1,221 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ecoker/launchpad/internal/ai"
+)
+
+// meteredProvider remembers its own thread and bills 100 tokens in and 10
+// out per call.
+type meteredProvider struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (p *meteredProvider) Send(ctx context.Context, message, systemPrompt string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, message)
+	return "Tell me more. " + ai.ReadyToken, nil
+}
+
+func (p *meteredProvider) Usage() (int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return 100 * len(p.messages), 10 * len(p.messages)
+}
+
+func do(t *testing.T, h http.Handler, method, path string, body any) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, &buf))
+	var out map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &out)
+	return rec, out
+}
+
+func TestSessionsAreIsolated(t *testing.T) {
+	var providers []*meteredProvider
+	s := New(StaticKey("sk-test"), func(string) ai.Provider {
+		p := &meteredProvider{}
+		providers = append(providers, p)
+		return p
+	}, WithRateLimit(2), WithPricing(1, 10))
+	h := s.Handler()
+
+	rec, a := do(t, h, "POST", "/sessions", map[string]string{"user": "ada"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create = %d %s", rec.Code, rec.Body)
+	}
+	_, b := do(t, h, "POST", "/sessions", map[string]string{"user": "bob"})
+	idA, idB := a["id"].(string), b["id"].(string)
+	if idA == "" || idA == idB {
+		t.Fatalf("ids = %q, %q", idA, idB)
+	}
+
+	rec, reply := do(t, h, "POST", "/sessions/"+idA+"/messages", map[string]string{"message": "a voting app"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("message = %d %s", rec.Code, rec.Body)
+	}
+	if reply["reply"] != "Tell me more." || reply["ready"] != true {
+		t.Errorf("reply = %v", reply)
+	}
+	do(t, h, "POST", "/sessions/"+idA+"/messages", map[string]string{"message": "with realtime results"})
+	if len(providers[0].messages) != 2 || len(providers[1].messages) != 0 {
+		t.Fatalf("messages per provider = %d, %d", len(providers[0].messages), len(providers[1].messages))
+	}
+
+	// A's limit doesn't hold up B.
+	rec, _ = do(t, h, "POST", "/sessions/"+idA+"/messages", map[string]string{"message": "one more"})
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("third message = %d, Retry-After %q; want 429 with Retry-After", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if rec, _ = do(t, h, "POST", "/sessions/"+idB+"/messages", map[string]string{"message": "a blog"}); rec.Code != http.StatusOK {
+		t.Errorf("B message = %d", rec.Code)
+	}
+
+	_, usage := do(t, h, "GET", "/usage", nil)
+	total := usage["total"].(map[string]any)
+	if total["requests"] != 3.0 || total["input_tokens"] != 300.0 || total["output_tokens"] != 30.0 {
+		t.Errorf("total = %v", total)
+	}
+	// 300 in at $1/M plus 30 out at $10/M.
+	if cost := total["cost_usd"].(float64); cost < 0.000599 || cost > 0.000601 {
+		t.Errorf("cost = %v, want 0.0006", cost)
+	}
+	ada := usage["by_user"].(map[string]any)["ada"].(map[string]any)
+	if ada["requests"] != 2.0 {
+		t.Errorf("ada = %v", ada)
+	}
+
+	if rec, _ = do(t, h, "DELETE", "/sessions/"+idA, nil); rec.Code != http.StatusOK {
+		t.Errorf("delete = %d", rec.Code)
+	}
+	if rec, _ = do(t, h, "GET", "/sessions/"+idA, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("get after delete = %d, want 404", rec.Code)
+	}
+}
+
+func TestGenerateNeedsConversation(t *testing.T) {
+	h := New(StaticKey("sk-test"), func(string) ai.Provider { return &meteredProvider{} }).Handler()
+	_, sess := do(t, h, "POST", "/sessions", nil)
+	rec, _ := do(t, h, "POST", "/sessions/"+sess["id"].(string)+"/generate", map[string]string{"project_name": "votes"})
+	if rec.Code != http.StatusConflict {
+		t.Errorf("generate before any message = %d, want 409", rec.Code)
+	}
+}
+
+type brokerFunc func(user string) (string, error)
+
+func (f brokerFunc) APIKey(ctx context.Context, user string) (string, error) { return f(user) }
+
+func TestUserHeaderScopesSessionsAndKeys(t *testing.T) {
+	var keys []string
+	broker := brokerFunc(func(user string) (string, error) {
+		if user == "mallory@example.com" {
+			return "", ErrNoKey
+		}
+		return "sk-" + user, nil
+	})
+	h := New(broker, func(key string) ai.Provider {
+		keys = append(keys, key)
+		return &meteredProvider{}
+	}, WithUserHeader("X-Forwarded-Email")).Handler()
+
+	as := func(user, method, path string, body any) (*httptest.ResponseRecorder, map[string]any) {
+		t.Helper()
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		if user != "" {
+			req.Header.Set("X-Forwarded-Email", user)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		var out map[string]any
+		_ = json.Unmarshal(rec.Body.Bytes(), &out)
+		return rec, out
+	}
+
+	if rec, _ := as("", "POST", "/sessions", nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("create without header = %d, want 401", rec.Code)
+	}
+	if rec, _ := as("mallory@example.com", "POST", "/sessions", nil); rec.Code != http.StatusForbidden {
+		t.Errorf("create without a brokered key = %d, want 403", rec.Code)
+	}
+
+	// The body can't claim someone else's identity.
+	rec, sess := as("ada@example.com", "POST", "/sessions", map[string]string{"user": "bob@example.com"})
+	if rec.Code != http.StatusCreated || sess["user"] != "ada@example.com" {
+		t.Fatalf("create = %d %v", rec.Code, sess)
+	}
+	if len(keys) != 1 || keys[0] != "sk-ada@example.com" {
+		t.Errorf("provider keys = %v", keys)
+	}
+
+	id := sess["id"].(string)
+	if rec, _ := as("bob@example.com", "GET", "/sessions/"+id, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("other user's session = %d, want 404", rec.Code)
+	}
+	if rec, _ := as("bob@example.com", "DELETE", "/sessions/"+id, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("deleting other user's session = %d, want 404", rec.Code)
+	}
+	rec, _ = as("bob@example.com", "GET", "/sessions", nil)
+	if body := rec.Body.String(); body != "[]\n" {
+		t.Errorf("bob's list = %s, want none", body)
+	}
+	if rec, _ := as("ada@example.com", "GET", "/sessions/"+id, nil); rec.Code != http.StatusOK {
+		t.Errorf("own session = %d", rec.Code)
+	}
+}
+
+func TestUsageScopedToCaller(t *testing.T) {
+	h := New(brokerFunc(func(user string) (string, error) { return "sk-" + user, nil }),
+		func(string) ai.Provider { return &meteredProvider{} },
+		WithUserHeader("X-Forwarded-Email"), WithAdmins("root@example.com")).Handler()
+	as := func(user, method, path string) (int, map[string]any) {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		if user != "" {
+			req.Header.Set("X-Forwarded-Email", user)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		var out map[string]any
+		_ = json.Unmarshal(rec.Body.Bytes(), &out)
+		return rec.Code, out
+	}
+	for _, user := range []string{"ada@example.com", "bob@example.com", "bob@example.com"} {
+		if code, _ := as(user, "POST", "/sessions"); code != http.StatusCreated {
+			t.Fatalf("create as %s = %d", user, code)
+		}
+	}
+
+	if code, _ := as("", "GET", "/usage"); code != http.StatusUnauthorized {
+		t.Errorf("usage without header = %d, want 401", code)
+	}
+	code, usage := as("ada@example.com", "GET", "/usage")
+	byUser, _ := usage["by_user"].(map[string]any)
+	if code != http.StatusOK || usage["sessions"] != 1.0 || len(byUser) != 1 || byUser["ada@example.com"] == nil {
+		t.Errorf("ada's usage = %d %v, want only her own session", code, usage)
+	}
+	_, usage = as("root@example.com", "GET", "/usage")
+	if byUser, _ := usage["by_user"].(map[string]any); usage["sessions"] != 3.0 || len(byUser) != 2 {
+		t.Errorf("admin usage = %v, want every user", usage)
+	}
+}
@@ -0,0 +1,222 @@
+// Package session records an init conversation — the user's turns, the
+// model's replies, the extracted selection, and the generated files — so it
+// can be replayed later against a different model or prompt version.
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+	"github.com/ecoker/launchpad/internal/diff"
+	"github.com/ecoker/launchpad/internal/store"
+)
+
+// Dir is where init --debug writes sessions, relative to the project.
+const Dir = ".launchpad/debug"
+
+// metaFile holds everything but the generated files, which are written
+// under filesDir so a session can be browsed like the project itself.
+const (
+	metaFile = "session.json"
+	filesDir = "files"
+)
+
+// Session is one recorded init run.
+type Session struct {
+	At          time.Time         `json:"at"`
+	Model       string            `json:"model"`
+	ProjectName string            `json:"project_name"`
+	Turns       []string          `json:"turns"`   // messages sent to the engine, in order
+	Replies     []string          `json:"replies"` // the model's reply to each turn
+	Agents      []string          `json:"agents,omitempty"`
+	Targets     []string          `json:"targets,omitempty"`
+	Packages    []ai.PackageScope `json:"packages,omitempty"`
+	Selection   *ai.Selection     `json:"selection,omitempty"`
+	Error       string            `json:"error,omitempty"`
+
+	Files []ai.FileOutput `json:"-"`
+}
+
+// AddTurn records a message and the reply to it. It is a no-op on a nil
+// session so callers can record unconditionally.
+func (s *Session) AddTurn(message, reply string) {
+	if s == nil {
+		return
+	}
+	s.Turns = append(s.Turns, message)
+	s.Replies = append(s.Replies, reply)
+}
+
+// NewDir returns a fresh session directory under the project's debug dir.
+func NewDir(projectDir string, at time.Time) string {
+	return filepath.Join(projectDir, Dir, fmt.Sprintf("session-%d", at.Unix()))
+}
+
+// ProjectDir returns the project a session directory was recorded in, or
+// the current directory when the session has been moved out of it or lives
+// in a remote store.
+func ProjectDir(sessionDir string) string {
+	if store.IsRemote(sessionDir) {
+		return "."
+	}
+	abs, err := filepath.Abs(sessionDir)
+	if err != nil {
+		return "."
+	}
+	debug := filepath.Dir(abs)
+	if strings.HasSuffix(debug, filepath.FromSlash(Dir)) {
+		return filepath.Dir(filepath.Dir(debug))
+	}
+	return "."
+}
+
+// Save writes s into dir, creating it.
+func (s *Session) Save(dir string) error {
+	return s.SaveTo(context.Background(), store.Dir(dir))
+}
+
+// SaveTo writes s into st, with the generated files under files/.
+func (s *Session) SaveTo(ctx context.Context, st store.Store) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode session: %w", err)
+	}
+	if err := st.Put(ctx, metaFile, append(data, '\n')); err != nil {
+		return fmt.Errorf("write session: %w", err)
+	}
+	for _, f := range s.Files {
+		if err := st.Put(ctx, filesDir+"/"+f.Path, []byte(f.Content)); err != nil {
+			return fmt.Errorf("write %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// Load reads a session saved by Save.
+func Load(dir string) (*Session, error) {
+	return LoadFrom(context.Background(), store.Dir(dir))
+}
+
+// LoadFrom reads a session saved by SaveTo.
+func LoadFrom(ctx context.Context, st store.Store) (*Session, error) {
+	data, err := st.Get(ctx, metaFile)
+	if err != nil {
+		return nil, fmt.Errorf("read session: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse session: %w", err)
+	}
+	keys, err := st.List(ctx, filesDir+"/")
+	if err != nil {
+		return nil, fmt.Errorf("read session files: %w", err)
+	}
+	for _, k := range keys {
+		content, err := st.Get(ctx, k)
+		if err != nil {
+			return nil, fmt.Errorf("read session files: %w", err)
+		}
+		s.Files = append(s.Files, ai.FileOutput{Path: strings.TrimPrefix(k, filesDir+"/"), Content: string(content)})
+	}
+	return &s, nil
+}
+
+// SelectionChanges describes how the extracted selection moved between two
+// runs, one line per differing field. Confidence is reported with its delta.
+func SelectionChanges(before, after *ai.Selection) []string {
+	if before == nil || after == nil {
+		if before == after {
+			return nil
+		}
+		return []string{"selection: only one run produced one"}
+	}
+	var out []string
+	if before.ProfileID != after.ProfileID {
+		out = append(out, fmt.Sprintf("profile: %s → %s", before.ProfileID, after.ProfileID))
+	}
+	for _, f := range []struct {
+		name          string
+		before, after []string
+	}{
+		{"add-ons", before.AddonIDs, after.AddonIDs},
+		{"assets", before.AssetIDs, after.AssetIDs},
+		{"agents", before.Agents, after.Agents},
+		{"tags", before.Tags, after.Tags},
+	} {
+		if added, removed := setDiff(f.before, f.after); len(added)+len(removed) > 0 {
+			out = append(out, fmt.Sprintf("%s: +[%s] -[%s]", f.name, strings.Join(added, ", "), strings.Join(removed, ", ")))
+		}
+	}
+	if before.Confidence != after.Confidence {
+		out = append(out, fmt.Sprintf("confidence: %.2f → %.2f (%+.2f)", before.Confidence, after.Confidence, after.Confidence-before.Confidence))
+	}
+	return out
+}
+
+// FileChange is one generated file that differs between two runs.
+type FileChange struct {
+	Path   string
+	Status string // "added", "removed", or "changed"
+	Diff   string // unified diff for changed files
+}
+
+// FileChanges compares two sets of generated files by path.
+func FileChanges(before, after []ai.FileOutput) []FileChange {
+	old := make(map[string]string, len(before))
+	for _, f := range before {
+		old[f.Path] = f.Content
+	}
+	seen := make(map[string]bool, len(after))
+	var out []FileChange
+	for _, f := range after {
+		seen[f.Path] = true
+		prev, ok := old[f.Path]
+		switch {
+		case !ok:
+			out = append(out, FileChange{Path: f.Path, Status: "added"})
+		case prev != f.Content:
+			out = append(out, FileChange{Path: f.Path, Status: "changed",
+				Diff: diff.Unified(prev, f.Content, "recorded/"+f.Path, "replay/"+f.Path)})
+		}
+	}
+	for _, f := range before {
+		if !seen[f.Path] {
+			out = append(out, FileChange{Path: f.Path, Status: "removed"})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
+
+// setDiff returns the items only in after and the items only in before.
+func setDiff(before, after []string) (added, removed []string) {
+	if reflect.DeepEqual(before, after) {
+		return nil, nil
+	}
+	in := func(list []string, v string) bool {
+		for _, item := range list {
+			if item == v {
+				return true
+			}
+		}
+		return false
+	}
+	for _, v := range after {
+		if !in(before, v) {
+			added = append(added, v)
+		}
+	}
+	for _, v := range before {
+		if !in(after, v) {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,86 @@
+package session
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecoker/launchpad/internal/ai"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir := NewDir(t.TempDir(), time.Unix(1700000000, 0))
+	s := &Session{
+		Model:       "gpt-4.1",
+		ProjectName: "demo",
+		Turns:       []string{"a chat app", "yes"},
+		Replies:     []string{"Phoenix?", "READY_TO_GENERATE"},
+		Packages:    []ai.PackageScope{{Path: "apps/web", ProfileID: "typescript-sveltekit"}},
+		Selection:   &ai.Selection{ProfileID: "elixir-phoenix", Confidence: 0.9},
+		Files: []ai.FileOutput{
+			{Path: "AGENTS.md", Content: "# Agents\n"},
+			{Path: ".github/copilot-instructions.md", Content: "# Standards\n"},
+		},
+	}
+	if err := s.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Turns, s.Turns) || got.Selection.ProfileID != "elixir-phoenix" || len(got.Packages) != 1 {
+		t.Errorf("loaded %+v", got)
+	}
+	if len(got.Files) != 2 {
+		t.Fatalf("files = %v", got.Files)
+	}
+	for _, f := range got.Files {
+		if f.Path == "AGENTS.md" && f.Content != "# Agents\n" {
+			t.Errorf("AGENTS.md = %q", f.Content)
+		}
+	}
+}
+
+func TestSelectionChanges(t *testing.T) {
+	before := &ai.Selection{ProfileID: "go-service", AssetIDs: []string{"asset.app.cli", "asset.ci.github"}, Confidence: 0.8}
+	after := &ai.Selection{ProfileID: "rust-axum", AssetIDs: []string{"asset.app.cli", "asset.release.semver"}, Confidence: 0.9}
+	got := SelectionChanges(before, after)
+	want := []string{
+		"profile: go-service → rust-axum",
+		"assets: +[asset.release.semver] -[asset.ci.github]",
+		"confidence: 0.80 → 0.90 (+0.10)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+	if got := SelectionChanges(before, before); got != nil {
+		t.Errorf("identical selections reported %v", got)
+	}
+}
+
+func TestFileChanges(t *testing.T) {
+	before := []ai.FileOutput{{Path: "AGENTS.md", Content: "a\n"}, {Path: "old.md", Content: "x"}, {Path: "same.md", Content: "s"}}
+	after := []ai.FileOutput{{Path: "AGENTS.md", Content: "b\n"}, {Path: "new.md", Content: "y"}, {Path: "same.md", Content: "s"}}
+	got := FileChanges(before, after)
+	if len(got) != 3 {
+		t.Fatalf("got %+v", got)
+	}
+	if got[0].Path != "AGENTS.md" || got[0].Status != "changed" || !strings.Contains(got[0].Diff, "+b") {
+		t.Errorf("AGENTS.md change = %+v", got[0])
+	}
+	if got[1].Status != "added" || got[2].Status != "removed" {
+		t.Errorf("statuses = %s, %s", got[1].Status, got[2].Status)
+	}
+}
+
+func TestProjectDir(t *testing.T) {
+	project := t.TempDir()
+	if got := ProjectDir(NewDir(project, time.Unix(1, 0))); got != project {
+		t.Errorf("ProjectDir = %s, want %s", got, project)
+	}
+	if got := ProjectDir(t.TempDir()); got != "." {
+		t.Errorf("moved session ProjectDir = %s, want .", got)
+	}
+}
@@ -0,0 +1,273 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bucket is an S3-compatible object store. Google Cloud Storage is reached
+// through its S3-compatible XML API with HMAC keys, so one client covers
+// both.
+type bucket struct {
+	endpoint  string // scheme://host, without the bucket
+	pathStyle bool   // bucket in the path rather than the host
+	name      string
+	region    string
+	accessKey string
+	secretKey string
+	token     string // session token, for temporary credentials
+	client    *http.Client
+	now       func() time.Time
+}
+
+// newBucket configures a bucket from the environment:
+//
+//	s3://  AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN,
+//	       AWS_REGION, and AWS_ENDPOINT_URL for S3-compatible servers
+//	gs://  GCS_HMAC_ACCESS_ID and GCS_HMAC_SECRET
+func newBucket(scheme, name string) (*bucket, error) {
+	b := &bucket{name: name, client: &http.Client{Timeout: 60 * time.Second}, now: time.Now}
+	if scheme == "gs" {
+		b.endpoint, b.pathStyle, b.region = "https://storage.googleapis.com", true, "auto"
+		b.accessKey, b.secretKey = os.Getenv("GCS_HMAC_ACCESS_ID"), os.Getenv("GCS_HMAC_SECRET")
+		if b.accessKey == "" || b.secretKey == "" {
+			return nil, fmt.Errorf("gs:// needs GCS_HMAC_ACCESS_ID and GCS_HMAC_SECRET")
+		}
+		return b, nil
+	}
+	b.accessKey, b.secretKey = os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	b.token = os.Getenv("AWS_SESSION_TOKEN")
+	if b.accessKey == "" || b.secretKey == "" {
+		return nil, fmt.Errorf("s3:// needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	b.region = os.Getenv("AWS_REGION")
+	if b.region == "" {
+		b.region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if b.region == "" {
+		b.region = "us-east-1"
+	}
+	if ep := os.Getenv("AWS_ENDPOINT_URL"); ep != "" {
+		b.endpoint, b.pathStyle = strings.TrimRight(ep, "/"), true
+	} else {
+		b.endpoint = "https://s3." + b.region + ".amazonaws.com"
+	}
+	return b, nil
+}
+
+func (b *bucket) Get(ctx context.Context, key string) ([]byte, error) {
+	res, err := b.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", key, fs.ErrNotExist)
+	}
+	if err := check(res); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(res.Body)
+}
+
+func (b *bucket) Put(ctx context.Context, key string, data []byte) error {
+	res, err := b.do(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return check(res)
+}
+
+func (b *bucket) Delete(ctx context.Context, key string) error {
+	res, err := b.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return check(res)
+}
+
+func (b *bucket) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		q := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		res, err := b.do(ctx, http.MethodGet, "", q, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Contents []struct {
+				Key string
+			}
+			IsTruncated           bool
+			NextContinuationToken string
+		}
+		err = check(res)
+		if err == nil {
+			err = xml.NewDecoder(res.Body).Decode(&page)
+		}
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range page.Contents {
+			keys = append(keys, c.Key)
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			break
+		}
+		token = page.NextContinuationToken
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// do sends a request signed with AWS Signature Version 4.
+func (b *bucket) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	host, path := strings.TrimPrefix(strings.TrimPrefix(b.endpoint, "https://"), "http://"), "/"+key
+	if b.pathStyle {
+		path = "/" + b.name + path
+	} else {
+		host = b.name + "." + host
+	}
+	scheme := "https"
+	if strings.HasPrefix(b.endpoint, "http://") {
+		scheme = "http"
+	}
+	rawQuery := canonicalQuery(query)
+	target := scheme + "://" + host + uriEncode(path, false)
+	if rawQuery != "" {
+		target += "?" + rawQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	b.sign(req, host, path, rawQuery, body)
+	res, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, key, err)
+	}
+	return res, nil
+}
+
+func (b *bucket) sign(req *http.Request, host, path, rawQuery string, body []byte) {
+	now := b.now().UTC()
+	stamp, day := now.Format("20060102T150405Z"), now.Format("20060102")
+	payload := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(payload[:])
+
+	headers := map[string]string{
+		"host":                 host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           stamp,
+	}
+	if b.token != "" {
+		headers["x-amz-security-token"] = b.token
+	}
+	names := make([]string, 0, len(headers))
+	for n := range headers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var canonHeaders strings.Builder
+	for _, n := range names {
+		canonHeaders.WriteString(n + ":" + headers[n] + "\n")
+		if n != "host" {
+			req.Header.Set(n, headers[n])
+		}
+	}
+	signed := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{req.Method, uriEncode(path, false), rawQuery,
+		canonHeaders.String(), signed, payloadHash}, "\n")
+	scope := day + "/" + b.region + "/s3/aws4_request"
+	sum := sha256.Sum256([]byte(canonical))
+	toSign := "AWS4-HMAC-SHA256\n" + stamp + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+
+	k := hmacSHA256([]byte("AWS4"+b.secretKey), day)
+	for _, part := range []string{b.region, "s3", "aws4_request"} {
+		k = hmacSHA256(k, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(k, toSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+b.accessKey+"/"+scope+
+		", SignedHeaders="+signed+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery encodes query the way SigV4 expects: sorted by key, with
+// every reserved character percent-encoded.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything but unreserved characters, and
+// slashes too when encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+// check turns an error response into an error carrying the service's
+// message.
+func check(res *http.Response) error {
+	if res.StatusCode/100 == 2 {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	var e struct {
+		Code    string
+		Message string
+	}
+	if xml.Unmarshal(body, &e) == nil && e.Code != "" {
+		return fmt.Errorf("%s %s: %s: %s", res.Request.Method, res.Request.URL.Path, e.Code, e.Message)
+	}
+	return fmt.Errorf("%s %s: %s", res.Request.Method, res.Request.URL.Path, res.Status)
+}
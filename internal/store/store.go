@@ -0,0 +1,188 @@
+// Package store persists launchpad's state — manifests, base copies, run
+// snapshots, and debug sessions — behind a small key/value interface, so
+// hosted and CI runs can keep it somewhere other than the local disk.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ecoker/launchpad/internal/sandbox"
+)
+
+// Store holds blobs under slash-separated keys. Get returns an error
+// wrapping fs.ErrNotExist for keys that are not there.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) error
+	// List returns every key under prefix, sorted.
+	List(ctx context.Context, prefix string) ([]string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Open returns the store at location: s3://bucket/prefix, gs://bucket/prefix,
+// or a local directory.
+func Open(location string) (Store, error) {
+	switch scheme, rest, _ := strings.Cut(location, "://"); scheme {
+	case "s3", "gs":
+		bucket, prefix, _ := strings.Cut(rest, "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("%s: no bucket", location)
+		}
+		b, err := newBucket(scheme, bucket)
+		if err != nil {
+			return nil, err
+		}
+		return Sub(b, prefix), nil
+	case "file":
+		return Dir(rest), nil
+	default:
+		if rest != "" {
+			return nil, fmt.Errorf("%s: unsupported store (use s3://, gs://, or a path)", location)
+		}
+		return Dir(location), nil
+	}
+}
+
+// IsRemote reports whether location names a bucket rather than a path.
+func IsRemote(location string) bool {
+	return strings.HasPrefix(location, "s3://") || strings.HasPrefix(location, "gs://")
+}
+
+// Dir is a store on the local filesystem, rooted at a directory. Writes go
+// through the sandbox like every other file launchpad writes.
+type Dir string
+
+func (d Dir) file(key string) (string, error) {
+	clean := path.Clean("/" + key)[1:]
+	if clean == "" || clean != strings.TrimSuffix(key, "/") {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(string(d), filepath.FromSlash(clean)), nil
+}
+
+func (d Dir) Get(_ context.Context, key string) ([]byte, error) {
+	full, err := d.file(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(full)
+}
+
+func (d Dir) Put(_ context.Context, key string, data []byte) error {
+	full, err := d.file(key)
+	if err != nil {
+		return err
+	}
+	if err := sandbox.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(full), err)
+	}
+	if err := sandbox.WriteFile(full, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", key, err)
+	}
+	return nil
+}
+
+func (d Dir) List(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(string(d), func(full string, e fs.DirEntry, err error) error {
+		if err != nil {
+			if e == nil {
+				return nil // nothing stored yet
+			}
+			return err
+		}
+		if e.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(string(d), full)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	sort.Strings(keys)
+	return keys, err
+}
+
+func (d Dir) Delete(_ context.Context, key string) error {
+	full, err := d.file(key)
+	if err != nil {
+		return err
+	}
+	if err := sandbox.Remove(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Sub returns a view of s with every key under prefix.
+func Sub(s Store, prefix string) Store {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return s
+	}
+	if d, ok := s.(Dir); ok {
+		return Dir(filepath.Join(string(d), filepath.FromSlash(prefix)))
+	}
+	return prefixed{s, prefix + "/"}
+}
+
+type prefixed struct {
+	s      Store
+	prefix string
+}
+
+func (p prefixed) Get(ctx context.Context, key string) ([]byte, error) {
+	return p.s.Get(ctx, p.prefix+key)
+}
+
+func (p prefixed) Put(ctx context.Context, key string, data []byte) error {
+	return p.s.Put(ctx, p.prefix+key, data)
+}
+
+func (p prefixed) List(ctx context.Context, prefix string) ([]string, error) {
+	keys, err := p.s.List(ctx, p.prefix+prefix)
+	for i, k := range keys {
+		keys[i] = strings.TrimPrefix(k, p.prefix)
+	}
+	return keys, err
+}
+
+func (p prefixed) Delete(ctx context.Context, key string) error {
+	return p.s.Delete(ctx, p.prefix+key)
+}
+
+// Copy copies every key under prefix from src to dst for which keep
+// returns true (nil keeps everything), and returns how many it copied.
+func Copy(ctx context.Context, dst, src Store, prefix string, keep func(key string) bool) (int, error) {
+	keys, err := src.List(ctx, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("list %s: %w", prefix, err)
+	}
+	n := 0
+	for _, k := range keys {
+		if keep != nil && !keep(k) {
+			continue
+		}
+		data, err := src.Get(ctx, k)
+		if err != nil {
+			return n, fmt.Errorf("read %s: %w", k, err)
+		}
+		if err := dst.Put(ctx, k, data); err != nil {
+			return n, fmt.Errorf("write %s: %w", k, err)
+		}
+		n++
+	}
+	return n, nil
+}
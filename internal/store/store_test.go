@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	ctx := context.Background()
+	d := Dir(t.TempDir())
+	for _, k := range []string{".launchpad/manifest.json", ".launchpad/base/AGENTS.md", "other.txt"} {
+		if err := d.Put(ctx, k, []byte(k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, err := d.Get(ctx, ".launchpad/base/AGENTS.md"); err != nil || string(got) != ".launchpad/base/AGENTS.md" {
+		t.Errorf("Get = %q, %v", got, err)
+	}
+	if _, err := d.Get(ctx, "missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Get(missing) = %v, want fs.ErrNotExist", err)
+	}
+	if err := d.Put(ctx, "../escape", nil); err == nil {
+		t.Error("keys must not leave the directory")
+	}
+	keys, err := d.List(ctx, ".launchpad/")
+	if want := []string{".launchpad/base/AGENTS.md", ".launchpad/manifest.json"}; err != nil || !reflect.DeepEqual(keys, want) {
+		t.Errorf("List = %v, %v; want %v", keys, err, want)
+	}
+	if err := d.Delete(ctx, "other.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Delete(ctx, "other.txt"); err != nil {
+		t.Errorf("deleting a missing key = %v, want nil", err)
+	}
+	if keys, _ := Dir(t.TempDir()+"/none").List(ctx, ""); len(keys) != 0 {
+		t.Errorf("List on a missing dir = %v", keys)
+	}
+}
+
+func TestSubAndCopy(t *testing.T) {
+	ctx := context.Background()
+	src := Dir(t.TempDir())
+	src.Put(ctx, "a/keep.json", []byte("1"))
+	src.Put(ctx, "a/skip.json", []byte("2"))
+	src.Put(ctx, "b/other.json", []byte("3"))
+
+	mem := memStore{}
+	dst := Sub(mem, "projects/app")
+	n, err := Copy(ctx, dst, src, "a/", func(k string) bool { return k != "a/skip.json" })
+	if err != nil || n != 1 {
+		t.Fatalf("Copy = %d, %v", n, err)
+	}
+	if string(mem["projects/app/a/keep.json"]) != "1" || len(mem) != 1 {
+		t.Errorf("stored = %v", mem)
+	}
+	if keys, _ := dst.List(ctx, ""); !reflect.DeepEqual(keys, []string{"a/keep.json"}) {
+		t.Errorf("Sub List = %v", keys)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	if s, err := Open("state/dir"); err != nil || s != Dir("state/dir") {
+		t.Errorf("Open(path) = %v, %v", s, err)
+	}
+	if _, err := Open("ftp://host/x"); err == nil {
+		t.Error("unknown schemes should be rejected")
+	}
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	if _, err := Open("s3://bucket/prefix"); err == nil {
+		t.Error("s3 without credentials should fail")
+	}
+}
+
+// memStore is an in-memory Store for tests.
+type memStore map[string][]byte
+
+func (m memStore) Get(_ context.Context, key string) ([]byte, error) {
+	if v, ok := m[key]; ok {
+		return v, nil
+	}
+	return nil, fs.ErrNotExist
+}
+
+func (m memStore) Put(_ context.Context, key string, data []byte) error {
+	m[key] = data
+	return nil
+}
+
+func (m memStore) List(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for k := range m {
+		if len(k) >= len(prefix) && k[:len(prefix)] == prefix {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (m memStore) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
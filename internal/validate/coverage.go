@@ -0,0 +1,206 @@
+package validate
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// sourceExts are the file types coverage counts, with the catalog entry
+// that adds scoped guidance for each, where one clearly does. Languages
+// several profiles share (TypeScript, Python, Swift) have no suggestion.
+var sourceExts = map[string]string{
+	".go": "profile.go-service", ".rs": "profile.rust-axum", ".ex": "profile.elixir-phoenix",
+	".exs": "profile.elixir-phoenix", ".heex": "profile.elixir-phoenix", ".rb": "profile.ruby-rails",
+	".erb": "profile.ruby-rails", ".php": "profile.laravel", ".cs": "profile.dotnet-api",
+	".java": "profile.java-spring", ".kt": "profile.java-spring", ".dart": "profile.dart-flutter",
+	".gd": "profile.godot", ".tf": "addon.iac", ".hcl": "addon.iac", ".sql": "addon.data-intensive",
+	".css": "addon.frontend-craft", ".scss": "addon.frontend-craft",
+	".vue": "addon.frontend-craft", ".svelte": "addon.frontend-craft",
+	".ts": "", ".tsx": "", ".js": "", ".jsx": "", ".mjs": "", ".py": "", ".swift": "",
+	".c": "", ".cc": "", ".cpp": "", ".h": "", ".hpp": "", ".m": "", ".sh": "",
+}
+
+// testDirs hold tests whatever the language; uncovered ones suggest the
+// testing asset.
+var testDirs = map[string]bool{"test": true, "tests": true, "spec": true, "__tests__": true}
+
+// coverageSkip are dependency and build directories coverage never walks.
+// Hidden directories are skipped too.
+var coverageSkip = map[string]bool{
+	"node_modules": true, "vendor": true, "deps": true, "_build": true, "target": true,
+	"dist": true, "build": true, "venv": true, "Pods": true, "coverage": true,
+}
+
+// Area is the source files of one type in one directory, two levels deep
+// at most, and the instruction files whose applyTo glob reaches them.
+type Area struct {
+	Dir          string   `json:"dir"` // slash-separated, "." for the root
+	Ext          string   `json:"ext"`
+	Files        int      `json:"files"`
+	Covered      int      `json:"covered"`
+	Instructions []string `json:"instructions,omitempty"`
+	Suggest      string   `json:"suggest,omitempty"` // catalog ID, set when nothing covers the area
+}
+
+// Gap reports whether no file in the area has scoped guidance.
+func (a Area) Gap() bool { return a.Covered == 0 }
+
+// Coverage maps the source files under root against the applyTo globs of
+// its .github/instructions files. Globs that match everything ("**") are
+// not scoped guidance and are left out.
+func Coverage(root string) ([]Area, error) {
+	globs, err := scopedGlobs(root)
+	if err != nil {
+		return nil, err
+	}
+	type key struct{ dir, ext string }
+	areas := map[key]*Area{}
+	err = filepath.WalkDir(root, func(full string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if full != root && (strings.HasPrefix(name, ".") || coverageSkip[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(name))
+		if _, ok := sourceExts[ext]; !ok {
+			return nil
+		}
+		rel, err := filepath.Rel(root, full)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		k := key{areaDir(rel), ext}
+		a := areas[k]
+		if a == nil {
+			a = &Area{Dir: k.dir, Ext: ext}
+			areas[k] = a
+		}
+		a.Files++
+		matched := false
+		for file, g := range globs {
+			if matchGlob(g, rel) {
+				matched = true
+				if !contains(a.Instructions, file) {
+					a.Instructions = append(a.Instructions, file)
+				}
+			}
+		}
+		if matched {
+			a.Covered++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Area, 0, len(areas))
+	for _, a := range areas {
+		sort.Strings(a.Instructions)
+		if a.Gap() {
+			a.Suggest = suggestFor(a.Dir, a.Ext)
+		}
+		out = append(out, *a)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Dir != out[j].Dir {
+			return out[i].Dir < out[j].Dir
+		}
+		return out[i].Ext < out[j].Ext
+	})
+	return out, nil
+}
+
+// scopedGlobs returns the applyTo glob of every instruction file under
+// root, keyed by the file's path.
+func scopedGlobs(root string) (map[string]string, error) {
+	paths, err := instructionPaths(root)
+	if err != nil {
+		return nil, err
+	}
+	globs := map[string]string{}
+	for _, p := range paths {
+		if kindOf(p) != kindInstructions {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, p))
+		if err != nil {
+			return nil, err
+		}
+		fm, _ := parseFrontmatter(p, string(data))
+		if fm == nil {
+			continue
+		}
+		f, ok := fm.Fields["applyTo"]
+		if !ok || f.IsList || checkGlob(f.Value) != nil {
+			continue
+		}
+		if g := strings.TrimSpace(f.Value); g != "**" && g != "**/*" {
+			globs[p] = g
+		}
+	}
+	return globs, nil
+}
+
+// areaDir is the directory of rel, cut to its first two segments so a
+// large tree reports by package rather than by folder.
+func areaDir(rel string) string {
+	dir := path.Dir(rel)
+	if parts := strings.Split(dir, "/"); len(parts) > 2 {
+		dir = parts[0] + "/" + parts[1]
+	}
+	return dir
+}
+
+func suggestFor(dir, ext string) string {
+	for _, seg := range strings.Split(dir, "/") {
+		if testDirs[seg] {
+			return "asset.testing.pragmatic"
+		}
+	}
+	return sourceExts[ext]
+}
+
+// matchGlob reports whether the slash-separated path rel matches an applyTo
+// value: comma-separated globs with ** segments and {alternatives}.
+func matchGlob(value, rel string) bool {
+	for _, g := range splitTopLevel(value, ',') {
+		for _, alt := range expandBraces(strings.TrimSpace(g)) {
+			if matchSegments(strings.Split(alt, "/"), strings.Split(rel, "/")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(pattern[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
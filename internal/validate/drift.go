@@ -0,0 +1,271 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RuleDrift marks guidance that no longer matches the repository.
+const RuleDrift = "drift"
+
+// evidence is a root-level file whose presence (and, when contains is set,
+// content) shows a tool is in use.
+type evidence struct {
+	glob     string
+	contains string
+}
+
+// tool is something instructions name that the repository can confirm or
+// contradict. Tools in the same category replace each other, so naming one
+// while the repository uses another is drift.
+type tool struct {
+	name     string
+	category string
+	mention  *regexp.Regexp
+	npm      []string // package.json dependencies
+	files    []evidence
+}
+
+var tools = []tool{
+	{name: "Jest", category: "test runner", mention: regexp.MustCompile(`\bJest\b`),
+		npm: []string{"jest"}, files: []evidence{{glob: "jest.config.*"}}},
+	{name: "Vitest", category: "test runner", mention: regexp.MustCompile(`\bVitest\b`),
+		npm: []string{"vitest"}, files: []evidence{{glob: "vitest.config.*"}}},
+	{name: "Mocha", category: "test runner", mention: regexp.MustCompile(`\bMocha\b`),
+		npm: []string{"mocha"}, files: []evidence{{glob: ".mocharc*"}}},
+	{name: "pytest", category: "Python test runner", mention: regexp.MustCompile(`\bpytest\b`),
+		files: []evidence{{glob: "pytest.ini"}, {glob: "conftest.py"}, {glob: "pyproject.toml", contains: "[tool.pytest"}}},
+	{name: "unittest", category: "Python test runner", mention: regexp.MustCompile(`\bunittest\b`)},
+	{name: "RSpec", category: "Ruby test framework", mention: regexp.MustCompile(`\bRSpec\b`),
+		files: []evidence{{glob: ".rspec"}, {glob: "Gemfile", contains: "rspec"}}},
+	{name: "Minitest", category: "Ruby test framework", mention: regexp.MustCompile(`\bMinitest\b`),
+		files: []evidence{{glob: "test/test_helper.rb"}}},
+	{name: "Playwright", category: "end-to-end runner", mention: regexp.MustCompile(`\bPlaywright\b`),
+		npm: []string{"@playwright/test"}, files: []evidence{{glob: "playwright.config.*"}}},
+	{name: "Cypress", category: "end-to-end runner", mention: regexp.MustCompile(`\bCypress\b`),
+		npm: []string{"cypress"}, files: []evidence{{glob: "cypress.config.*"}}},
+	{name: "Tailwind CSS", category: "styling system", mention: regexp.MustCompile(`\bTailwind\b`),
+		npm: []string{"tailwindcss"}, files: []evidence{{glob: "tailwind.config.*"}}},
+	{name: "styled-components", category: "styling system", mention: regexp.MustCompile(`\bstyled-components\b`),
+		npm: []string{"styled-components"}},
+	{name: "Emotion", category: "styling system", mention: regexp.MustCompile(`\bEmotion\b`),
+		npm: []string{"@emotion/react", "@emotion/styled"}},
+	{name: "UnoCSS", category: "styling system", mention: regexp.MustCompile(`\bUnoCSS\b`),
+		npm: []string{"unocss"}, files: []evidence{{glob: "uno.config.*"}}},
+	{name: "npm", category: "package manager", mention: regexp.MustCompile(`\bnpm (?:install|i|ci|run)\b`),
+		files: []evidence{{glob: "package-lock.json"}}},
+	{name: "pnpm", category: "package manager", mention: regexp.MustCompile(`\bpnpm\b`),
+		files: []evidence{{glob: "pnpm-lock.yaml"}}},
+	{name: "Yarn", category: "package manager", mention: regexp.MustCompile(`\b[Yy]arn\b`),
+		files: []evidence{{glob: "yarn.lock"}}},
+	{name: "Bun", category: "package manager", mention: regexp.MustCompile(`\bbun (?:install|add|run)\b`),
+		files: []evidence{{glob: "bun.lock"}, {glob: "bun.lockb"}}},
+	{name: "Poetry", category: "Python package manager", mention: regexp.MustCompile(`\b[Pp]oetry\b`),
+		files: []evidence{{glob: "poetry.lock"}}},
+	{name: "uv", category: "Python package manager", mention: regexp.MustCompile("\\buv (?:add|sync|run|pip)\\b|`uv`"),
+		files: []evidence{{glob: "uv.lock"}}},
+	{name: "ESLint", category: "linter", mention: regexp.MustCompile(`\bESLint\b`),
+		npm: []string{"eslint"}, files: []evidence{{glob: "eslint.config.*"}, {glob: ".eslintrc*"}}},
+	{name: "Biome", category: "linter", mention: regexp.MustCompile(`\bBiome\b`),
+		npm: []string{"@biomejs/biome"}, files: []evidence{{glob: "biome.json*"}}},
+}
+
+// versioned is a framework whose major version instructions often state.
+type versioned struct {
+	name    string
+	npm     string
+	mention *regexp.Regexp // group 1 is the stated major version
+}
+
+var versions = []versioned{
+	{"Next.js", "next", regexp.MustCompile(`\bNext\.?js\s+v?(\d+)\b`)},
+	{"React", "react", regexp.MustCompile(`\bReact\s+v?(\d+)\b`)},
+	{"Nuxt", "nuxt", regexp.MustCompile(`\bNuxt\s+v?(\d+)\b`)},
+	{"Vue", "vue", regexp.MustCompile(`\bVue\s+v?(\d+)\b`)},
+	{"Svelte", "svelte", regexp.MustCompile(`\bSvelte\s+v?(\d+)\b`)},
+	{"Tailwind CSS", "tailwindcss", regexp.MustCompile(`\bTailwind(?:\s+CSS)?\s+v?(\d+)\b`)},
+	{"Expo SDK", "expo", regexp.MustCompile(`\bExpo\s+SDK\s+(\d+)\b`)},
+	{"Electron", "electron", regexp.MustCompile(`\bElectron\s+v?(\d+)\b`)},
+}
+
+// goVersion matches a stated Go release, such as "Go 1.22"; goDirective
+// finds the release go.mod declares.
+var (
+	goVersion   = regexp.MustCompile(`\bGo\s+(1\.\d+)\b`)
+	goDirective = regexp.MustCompile(`(?m)^go\s+(1\.\d+)`)
+)
+
+// repo is what the drift check reads from the code itself.
+type repo struct {
+	root string
+	deps map[string]string // package.json dependencies and devDependencies
+	goV  string            // go directive from go.mod, major.minor
+}
+
+func loadRepo(root string) *repo {
+	r := &repo{root: root, deps: map[string]string{}}
+	if data, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
+		var pkg struct {
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			for k, v := range pkg.Dependencies {
+				r.deps[k] = v
+			}
+			for k, v := range pkg.DevDependencies {
+				r.deps[k] = v
+			}
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+		if m := goDirective.FindSubmatch(data); m != nil {
+			r.goV = string(m[1])
+		}
+	}
+	return r
+}
+
+// uses reports whether the repository uses t, and the file that shows it.
+func (r *repo) uses(t tool) (string, bool) {
+	for _, dep := range t.npm {
+		if _, ok := r.deps[dep]; ok {
+			return "package.json", true
+		}
+	}
+	for _, e := range t.files {
+		matches, _ := filepath.Glob(filepath.Join(r.root, e.glob))
+		for _, m := range matches {
+			if e.contains != "" {
+				data, err := os.ReadFile(m)
+				if err != nil || !strings.Contains(string(data), e.contains) {
+					continue
+				}
+			}
+			return filepath.Base(m), true
+		}
+	}
+	return "", false
+}
+
+// Drift compares what the instruction files under root say about the test
+// runner, styling system, package manager, linter, and framework versions
+// with the repository's manifests, lockfiles, and configs, and warns about
+// guidance the code has outgrown. A tool only counts as stale when the
+// instructions never name the one actually in use, so "Vitest, not Jest"
+// is fine.
+func Drift(root string) ([]Diagnostic, error) {
+	paths, err := instructionPaths(root)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(root, "AGENTS.md")); err == nil {
+		paths = append(paths, "AGENTS.md")
+	}
+	contents := make(map[string]string, len(paths))
+	var all strings.Builder
+	for _, p := range paths {
+		data, err := os.ReadFile(filepath.Join(root, p))
+		if err != nil {
+			return nil, err
+		}
+		contents[p] = string(data)
+		all.Write(data)
+		all.WriteByte('\n')
+	}
+	r := loadRepo(root)
+
+	// Per category: which tools the instructions name, and which the
+	// repository uses.
+	mentioned := map[string]bool{}
+	inUse := map[string]string{} // tool name → evidence file
+	for _, t := range tools {
+		if t.mention.MatchString(all.String()) {
+			mentioned[t.name] = true
+		}
+		if file, ok := r.uses(t); ok {
+			inUse[t.name] = file
+		}
+	}
+
+	var diags []Diagnostic
+	for _, t := range tools {
+		if !mentioned[t.name] || inUse[t.name] != "" {
+			continue
+		}
+		var actual []string
+		for _, other := range tools {
+			if other.category == t.category && inUse[other.name] != "" && !mentioned[other.name] {
+				actual = append(actual, fmt.Sprintf("%s (%s)", other.name, inUse[other.name]))
+			}
+		}
+		if len(actual) == 0 {
+			continue
+		}
+		msg := fmt.Sprintf("names %s as the %s, but the repository uses %s", t.name, t.category, strings.Join(actual, " and "))
+		for _, p := range paths {
+			if loc := t.mention.FindStringIndex(contents[p]); loc != nil {
+				diags = append(diags, warningAt(p, lineOf(contents[p], loc[0]), RuleDrift, msg))
+			}
+		}
+	}
+
+	for _, v := range versions {
+		if have := majorOf(r.deps[v.npm]); have != "" {
+			diags = append(diags, staleVersions(paths, contents, v.mention, have,
+				fmt.Sprintf("package.json has %s %s", v.npm, r.deps[v.npm]), v.name)...)
+		}
+	}
+	if r.goV != "" {
+		diags = append(diags, staleVersions(paths, contents, goVersion, r.goV,
+			"go.mod declares go "+r.goV, "Go")...)
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].File != diags[j].File {
+			return diags[i].File < diags[j].File
+		}
+		return diags[i].Line < diags[j].Line
+	})
+	return diags, nil
+}
+
+// staleVersions reports, per file, the first version stated by pattern that
+// isn't have. "18+" style minimums are left alone; they stay true.
+func staleVersions(paths []string, contents map[string]string, pattern *regexp.Regexp, have, actual, name string) []Diagnostic {
+	var diags []Diagnostic
+	for _, p := range paths {
+		c := contents[p]
+		for _, m := range pattern.FindAllStringSubmatchIndex(c, -1) {
+			said := c[m[2]:m[3]]
+			if said == have || strings.HasPrefix(c[m[3]:], "+") {
+				continue
+			}
+			diags = append(diags, warningAt(p, lineOf(c, m[0]), RuleDrift,
+				fmt.Sprintf("says %s %s, but %s", name, said, actual)))
+			break
+		}
+	}
+	return diags
+}
+
+// majorOf returns the major version a package.json range pins, or "" when
+// it names none (a tag, a URL, a workspace reference).
+func majorOf(spec string) string {
+	spec = strings.TrimLeft(strings.TrimSpace(spec), "^~>=v ")
+	end := 0
+	for end < len(spec) && spec[end] >= '0' && spec[end] <= '9' {
+		end++
+	}
+	return spec[:end]
+}
+
+// lineOf returns the 1-based line of byte offset i in s.
+func lineOf(s string, i int) int {
+	return strings.Count(s[:i], "\n") + 1
+}
@@ -0,0 +1,140 @@
+package validate
+
+import (
+	"strings"
+)
+
+// field is one top-level key parsed from YAML frontmatter.
+type field struct {
+	Key    string
+	Value  string   // scalar value with surrounding quotes removed
+	List   []string // items for inline ([a, b]) or block (- a) lists
+	IsList bool
+	Line   int // 1-based line of the key in the file
+}
+
+// frontmatter is the parsed header of a markdown instruction file.
+type frontmatter struct {
+	Fields map[string]field
+	Start  int // line of the opening ---
+	End    int // line of the closing ---
+}
+
+// parseFrontmatter extracts the YAML frontmatter block from content. It
+// understands the subset instruction files use — scalars, quoted strings,
+// and inline or block lists — and reports syntax problems as diagnostics.
+// It returns nil when the file has no frontmatter.
+func parseFrontmatter(file, content string) (*frontmatter, []Diagnostic) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimRight(lines[0], "\r") != "---" {
+		return nil, nil
+	}
+
+	fm := &frontmatter{Fields: make(map[string]field), Start: 1}
+	var diags []Diagnostic
+	var current *field
+
+	for i := 1; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimRight(lines[i], "\r")
+		if line == "---" {
+			fm.End = lineNo
+			if current != nil {
+				fm.Fields[current.Key] = *current
+			}
+			return fm, diags
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if current == nil || (current.Value != "" && !current.IsList) {
+				diags = append(diags, errorAt(file, lineNo, RuleFrontmatterSyntax, "list item without a key"))
+				continue
+			}
+			current.IsList = true
+			current.List = append(current.List, unquote(strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))))
+			continue
+		}
+
+		if line != trimmed {
+			diags = append(diags, errorAt(file, lineNo, RuleFrontmatterSyntax, "unexpected indentation"))
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			diags = append(diags, errorAt(file, lineNo, RuleFrontmatterSyntax, "expected \"key: value\""))
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if current != nil {
+			fm.Fields[current.Key] = *current
+		}
+		if _, dup := fm.Fields[key]; dup {
+			diags = append(diags, errorAt(file, lineNo, RuleFrontmatterSyntax, "duplicate key "+key))
+		}
+		current = &field{Key: key, Line: lineNo}
+
+		switch {
+		case strings.HasPrefix(value, "["):
+			if !strings.HasSuffix(value, "]") {
+				diags = append(diags, errorAt(file, lineNo, RuleFrontmatterSyntax, "unterminated inline list for "+key))
+				continue
+			}
+			current.IsList = true
+			for _, item := range splitTopLevel(value[1:len(value)-1], ',') {
+				if item = strings.TrimSpace(item); item != "" {
+					current.List = append(current.List, unquote(item))
+				}
+			}
+		case value == "":
+			// Either an empty scalar or the start of a block list.
+		default:
+			if q := value[0]; (q == '"' || q == '\'') && (len(value) < 2 || value[len(value)-1] != q) {
+				diags = append(diags, errorAt(file, lineNo, RuleFrontmatterSyntax, "unterminated quoted value for "+key))
+			}
+			current.Value = unquote(value)
+		}
+	}
+
+	diags = append(diags, errorAt(file, 1, RuleFrontmatterSyntax, "frontmatter is not closed with ---"))
+	return nil, diags
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// splitTopLevel splits s on sep, ignoring separators inside quotes, braces,
+// or brackets.
+func splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	depth := 0
+	var quote rune
+	start := 0
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '{' || r == '[':
+			depth++
+		case r == '}' || r == ']':
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,94 @@
+package validate
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// checkGlob reports why an applyTo value is not a valid glob, or nil. The
+// value may hold several comma-separated globs, each using **, *, ?,
+// [classes], and {alternatives}.
+func checkGlob(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("glob is empty")
+	}
+	for _, g := range splitTopLevel(value, ',') {
+		g = strings.TrimSpace(g)
+		if g == "" {
+			return fmt.Errorf("empty glob in comma-separated list")
+		}
+		if err := checkBalanced(g); err != nil {
+			return fmt.Errorf("%q: %w", g, err)
+		}
+		if strings.HasPrefix(g, "/") {
+			return fmt.Errorf("%q: globs are relative to the workspace root and must not start with /", g)
+		}
+		for _, alt := range expandBraces(g) {
+			for _, seg := range strings.Split(alt, "/") {
+				if seg == "**" {
+					continue
+				}
+				if strings.Contains(seg, "**") {
+					return fmt.Errorf("%q: ** must be a whole path segment", g)
+				}
+				if _, err := path.Match(seg, ""); err != nil {
+					return fmt.Errorf("%q: invalid pattern segment %q", g, seg)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func checkBalanced(g string) error {
+	braces, brackets := 0, 0
+	for _, r := range g {
+		switch r {
+		case '{':
+			braces++
+		case '}':
+			braces--
+		case '[':
+			brackets++
+		case ']':
+			brackets--
+		}
+		if braces < 0 || brackets < 0 {
+			return fmt.Errorf("unbalanced %q", r)
+		}
+	}
+	if braces != 0 {
+		return fmt.Errorf("unclosed {")
+	}
+	if brackets != 0 {
+		return fmt.Errorf("unclosed [")
+	}
+	return nil
+}
+
+// expandBraces expands {a,b} alternatives, innermost-first, into every
+// concrete pattern. The input must be balanced.
+func expandBraces(g string) []string {
+	open := strings.Index(g, "{")
+	if open == -1 {
+		return []string{g}
+	}
+	depth := 0
+	for i := open; i < len(g); i++ {
+		switch g[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				var out []string
+				for _, alt := range splitTopLevel(g[open+1:i], ',') {
+					out = append(out, expandBraces(g[:open]+alt+g[i+1:])...)
+				}
+				return out
+			}
+		}
+	}
+	return []string{g}
+}
@@ -0,0 +1,211 @@
+// Package validate checks generated AI instruction files — frontmatter,
+// applyTo globs, and prompt tool lists — and reports problems by line.
+package validate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Rule identifiers attached to every diagnostic.
+const (
+	RuleFrontmatterSyntax   = "frontmatter-syntax"
+	RuleFrontmatterRequired = "frontmatter-required"
+	RuleApplyToRequired     = "applyto-required"
+	RuleApplyToGlob         = "applyto-glob"
+	RulePromptTools         = "prompt-tools"
+	RulePromptDescription   = "prompt-description"
+	RulePromptMode          = "prompt-mode"
+)
+
+// AllowedPromptTools are the tool identifiers Copilot prompt files may use.
+var AllowedPromptTools = []string{"terminal", "editFiles", "codebase", "fetch"}
+
+// allowedPromptModes are the values Copilot accepts for mode:.
+var allowedPromptModes = []string{"agent", "ask", "edit"}
+
+// Severity ranks a diagnostic. Errors break how Copilot reads the file;
+// warnings are worth fixing but the file still works.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a single problem found in an instruction file.
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s (%s)", d.File, d.Line, d.Severity, d.Message, d.Rule)
+}
+
+func errorAt(file string, line int, rule, msg string) Diagnostic {
+	return Diagnostic{File: file, Line: line, Rule: rule, Severity: SeverityError, Message: msg}
+}
+
+func warningAt(file string, line int, rule, msg string) Diagnostic {
+	return Diagnostic{File: file, Line: line, Rule: rule, Severity: SeverityWarning, Message: msg}
+}
+
+// Errors returns only the error-severity diagnostics.
+func Errors(diags []Diagnostic) []Diagnostic {
+	var out []Diagnostic
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Kind of instruction file, derived from its path.
+type kind int
+
+const (
+	kindOther kind = iota
+	kindCopilot
+	kindInstructions
+	kindPrompt
+)
+
+func kindOf(path string) kind {
+	p := filepath.ToSlash(path)
+	switch {
+	case p == ".github/copilot-instructions.md":
+		return kindCopilot
+	case strings.HasPrefix(p, ".github/instructions/") && strings.HasSuffix(p, ".instructions.md"):
+		return kindInstructions
+	case strings.HasPrefix(p, ".github/prompts/") && strings.HasSuffix(p, ".prompt.md"):
+		return kindPrompt
+	}
+	return kindOther
+}
+
+// Applies reports whether path is a file the validator knows how to check.
+func Applies(path string) bool {
+	return kindOf(path) != kindOther
+}
+
+// File validates the content of one instruction file. path is relative to
+// the repository root and decides which rules apply.
+func File(path, content string) []Diagnostic {
+	k := kindOf(path)
+	if k == kindOther {
+		return nil
+	}
+	fm, diags := parseFrontmatter(path, content)
+	if fm == nil {
+		if len(diags) == 0 && k != kindCopilot {
+			diags = append(diags, errorAt(path, 1, RuleFrontmatterRequired, "missing YAML frontmatter"))
+		}
+		return diags
+	}
+
+	switch k {
+	case kindInstructions:
+		f, ok := fm.Fields["applyTo"]
+		switch {
+		case !ok:
+			diags = append(diags, errorAt(path, fm.Start, RuleApplyToRequired, "missing applyTo"))
+		case f.IsList:
+			diags = append(diags, errorAt(path, f.Line, RuleApplyToGlob, "applyTo must be a string, not a list"))
+		default:
+			if err := checkGlob(f.Value); err != nil {
+				diags = append(diags, errorAt(path, f.Line, RuleApplyToGlob, err.Error()))
+			}
+		}
+	case kindPrompt:
+		if f, ok := fm.Fields["description"]; !ok || strings.TrimSpace(f.Value) == "" {
+			diags = append(diags, warningAt(path, fm.Start, RulePromptDescription,
+				"missing description — the prompt picker shows it next to the command"))
+		}
+		if f, ok := fm.Fields["mode"]; ok && !contains(allowedPromptModes, f.Value) {
+			diags = append(diags, errorAt(path, f.Line, RulePromptMode,
+				fmt.Sprintf("unknown mode %q (allowed: %s)", f.Value, strings.Join(allowedPromptModes, ", "))))
+		}
+		if f, ok := fm.Fields["tools"]; ok {
+			if !f.IsList {
+				diags = append(diags, errorAt(path, f.Line, RulePromptTools, "tools must be a list"))
+			}
+			for _, tool := range f.List {
+				if !contains(AllowedPromptTools, tool) {
+					diags = append(diags, errorAt(path, f.Line, RulePromptTools,
+						fmt.Sprintf("unknown tool %q (allowed: %s)", tool, strings.Join(AllowedPromptTools, ", "))))
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// Dir validates every instruction file under root. It returns the
+// diagnostics and how many files were checked.
+func Dir(root string) ([]Diagnostic, int, error) {
+	paths, err := instructionPaths(root)
+	if err != nil {
+		return nil, 0, err
+	}
+	var diags []Diagnostic
+	for _, p := range paths {
+		data, err := os.ReadFile(filepath.Join(root, p))
+		if err != nil {
+			return nil, 0, err
+		}
+		diags = append(diags, File(p, string(data))...)
+	}
+	return diags, len(paths), nil
+}
+
+// instructionPaths returns the instruction and prompt files under root,
+// slash-separated and sorted.
+func instructionPaths(root string) ([]string, error) {
+	var paths []string
+	for _, p := range []string{".github/copilot-instructions.md"} {
+		if _, err := os.Stat(filepath.Join(root, p)); err == nil {
+			paths = append(paths, p)
+		}
+	}
+	for _, dir := range []string{".github/instructions", ".github/prompts"} {
+		err := filepath.WalkDir(filepath.Join(root, dir), func(full string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if d == nil {
+					return nil // directory doesn't exist
+				}
+				return err
+			}
+			rel, relErr := filepath.Rel(root, full)
+			if relErr != nil {
+				return relErr
+			}
+			if !d.IsDir() && Applies(rel) {
+				paths = append(paths, filepath.ToSlash(rel))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		content   string
+		wantRules []string
+		wantLines []int
+	}{
+		{
+			name:    "valid instructions file",
+			path:    ".github/instructions/go.instructions.md",
+			content: "---\nname: Go\napplyTo: \"**/*.{go,mod}\"\n---\n# Go\n",
+		},
+		{
+			name:      "missing frontmatter",
+			path:      ".github/instructions/go.instructions.md",
+			content:   "# Go\n",
+			wantRules: []string{RuleFrontmatterRequired},
+			wantLines: []int{1},
+		},
+		{
+			name:      "missing applyTo",
+			path:      ".github/instructions/go.instructions.md",
+			content:   "---\nname: Go\n---\n",
+			wantRules: []string{RuleApplyToRequired},
+			wantLines: []int{1},
+		},
+		{
+			name:      "bad glob",
+			path:      ".github/instructions/go.instructions.md",
+			content:   "---\nname: Go\napplyTo: \"**/*.{go,mod\"\n---\n",
+			wantRules: []string{RuleApplyToGlob},
+			wantLines: []int{3},
+		},
+		{
+			name:      "unclosed frontmatter",
+			path:      ".github/instructions/go.instructions.md",
+			content:   "---\napplyTo: \"**\"\n# Go\n",
+			wantRules: []string{RuleFrontmatterSyntax},
+			wantLines: []int{1},
+		},
+		{
+			name:    "copilot instructions without frontmatter",
+			path:    ".github/copilot-instructions.md",
+			content: "# Standards\n",
+		},
+		{
+			name:    "valid prompt",
+			path:    ".github/prompts/start.prompt.md",
+			content: "---\ndescription: \"Start\"\nmode: agent\ntools: [\"terminal\", \"editFiles\", \"codebase\"]\n---\nGo.\n",
+		},
+		{
+			name:      "prompt with invented tools",
+			path:      ".github/prompts/start.prompt.md",
+			content:   "---\ndescription: \"Start\"\nmode: agent\ntools:\n  - terminal\n  - runTests\n---\n",
+			wantRules: []string{RulePromptTools},
+			wantLines: []int{4},
+		},
+		{
+			name:      "prompt with unknown mode",
+			path:      ".github/prompts/start.prompt.md",
+			content:   "---\ndescription: \"Start\"\nmode: autopilot\n---\n",
+			wantRules: []string{RulePromptMode},
+			wantLines: []int{3},
+		},
+		{
+			name:      "prompt without description",
+			path:      ".github/prompts/start.prompt.md",
+			content:   "---\nmode: agent\n---\n",
+			wantRules: []string{RulePromptDescription},
+			wantLines: []int{1},
+		},
+		{
+			name:    "unrelated file ignored",
+			path:    "README.md",
+			content: "no frontmatter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := File(tt.path, tt.content)
+			if len(diags) != len(tt.wantRules) {
+				t.Fatalf("got %d diagnostics, want %d: %v", len(diags), len(tt.wantRules), diags)
+			}
+			for i, d := range diags {
+				if d.Rule != tt.wantRules[i] || d.Line != tt.wantLines[i] {
+					t.Errorf("diag[%d] = %s, want rule %s at line %d", i, d, tt.wantRules[i], tt.wantLines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckGlob(t *testing.T) {
+	valid := []string{"**", "**/*.go", "**/*.{ex,exs,heex}", "src/**/*.ts,tests/**/*.ts", "apps/web/**", "**/[a-z]*.py"}
+	for _, g := range valid {
+		if err := checkGlob(g); err != nil {
+			t.Errorf("checkGlob(%q) unexpected error: %v", g, err)
+		}
+	}
+	invalid := []string{"", "**/*.{ts", "**/*.ts}", "/abs/**", "src/**.go", "**/[a-z.py", "a,,b"}
+	for _, g := range invalid {
+		if err := checkGlob(g); err == nil {
+			t.Errorf("checkGlob(%q) = nil, want error", g)
+		}
+	}
+}
+
+func TestDir(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".github/copilot-instructions.md":          "# Standards\n",
+		".github/instructions/ok.instructions.md":  "---\napplyTo: \"**\"\n---\n",
+		".github/instructions/bad.instructions.md": "# no frontmatter\n",
+		".github/instructions/notes.md":            "ignored\n",
+		".github/prompts/start.prompt.md":          "---\ndescription: \"Start\"\nmode: agent\ntools: [\"terminal\"]\n---\n",
+	}
+	for p, c := range files {
+		full := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(c), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	diags, checked, err := Dir(root)
+	if err != nil {
+		t.Fatalf("Dir: %v", err)
+	}
+	if checked != 4 {
+		t.Errorf("checked %d files, want 4", checked)
+	}
+	if len(diags) != 1 || diags[0].File != ".github/instructions/bad.instructions.md" {
+		t.Errorf("diagnostics = %v, want one for bad.instructions.md", diags)
+	}
+}
+
+func TestDir_NoInstructions(t *testing.T) {
+	diags, checked, err := Dir(t.TempDir())
+	if err != nil || checked != 0 || len(diags) != 0 {
+		t.Errorf("Dir on empty repo = (%v, %d, %v), want nothing", diags, checked, err)
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	diags := File(".github/prompts/start.prompt.md", "---\nmode: autopilot\n---\n")
+	if len(diags) != 2 {
+		t.Fatalf("got %v, want a warning and an error", diags)
+	}
+	if diags[0].Severity != SeverityWarning || diags[1].Severity != SeverityError {
+		t.Errorf("severities = %s, %s", diags[0].Severity, diags[1].Severity)
+	}
+	if errs := Errors(diags); len(errs) != 1 || errs[0].Rule != RulePromptMode {
+		t.Errorf("Errors() = %v", errs)
+	}
+}
+
+func TestDrift(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".github/copilot-instructions.md": "# Standards\n\nUse Next.js 14 and Tailwind CSS.\nStyle with Tailwind 3.\n",
+		".github/instructions/testing.instructions.md": "---\napplyTo: \"**/*.test.ts\"\n---\n# Testing\n\n" +
+			"Write tests with Jest.\nRun them with `npm run test`.\n",
+		"AGENTS.md":      "Prefer pnpm over npm install. React 18+ is fine.\n",
+		"package.json":   `{"dependencies":{"next":"^15.1.0","react":"^19.0.0","tailwindcss":"^3.4.0"},"devDependencies":{"vitest":"^2.0.0"}}`,
+		"pnpm-lock.yaml": "lockfileVersion: '9.0'\n",
+	}
+	for p, c := range files {
+		full := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(c), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	diags, err := Drift(root)
+	if err != nil {
+		t.Fatalf("Drift: %v", err)
+	}
+	// Jest is stale (Vitest is in use and never named); Next.js 14 is stale.
+	// npm is named, but so is pnpm, the package manager in use; Tailwind 3
+	// and React 18+ still hold.
+	want := []string{
+		".github/copilot-instructions.md:3",
+		".github/instructions/testing.instructions.md:6",
+	}
+	var got []string
+	for _, d := range diags {
+		if d.Rule != RuleDrift || d.Severity != SeverityWarning {
+			t.Errorf("unexpected diagnostic %s", d)
+		}
+		got = append(got, fmt.Sprintf("%s:%d", d.File, d.Line))
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("drift at %v, want %v\n%v", got, want, diags)
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		glob, path string
+		want       bool
+	}{
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "internal/cli/root.go", true},
+		{"**/*.{ts,tsx}", "src/app/page.tsx", true},
+		{"src/**", "src/lib/a.ts", true},
+		{"src/**", "lib/a.ts", false},
+		{"infra/**/*.tf", "infra/main.tf", true},
+		{"lib/**/*.ex, test/**/*.exs", "test/app_test.exs", true},
+		{"*.go", "cmd/main.go", false},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.glob, tt.path); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.glob, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".github/instructions/go.instructions.md", "---\napplyTo: \"internal/**/*.go\"\n---\n")
+	write(".github/instructions/guardrails.instructions.md", "---\napplyTo: \"**\"\n---\n")
+	write("internal/cli/root.go", "package cli\n")
+	write("internal/cli/deep/x/y.go", "package x\n")
+	write("infra/main.tf", "")
+	write("tests/e2e/login.ts", "")
+	write("node_modules/pkg/index.js", "")
+	write("README.md", "")
+
+	areas, err := Coverage(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]Area{}
+	for _, a := range areas {
+		got[a.Dir+" "+a.Ext] = a
+	}
+	if len(got) != 3 {
+		t.Fatalf("areas = %+v, want internal/cli, infra, tests/e2e", areas)
+	}
+	if a := got["internal/cli .go"]; a.Files != 2 || a.Covered != 2 || a.Gap() ||
+		len(a.Instructions) != 1 || a.Instructions[0] != ".github/instructions/go.instructions.md" {
+		t.Errorf("internal/cli = %+v", a)
+	}
+	if a := got["infra .tf"]; !a.Gap() || a.Suggest != "addon.iac" {
+		t.Errorf("infra = %+v, want a gap suggesting addon.iac", a)
+	}
+	if a := got["tests/e2e .ts"]; !a.Gap() || a.Suggest != "asset.testing.pragmatic" {
+		t.Errorf("tests/e2e = %+v, want a gap suggesting the testing asset", a)
+	}
+}